@@ -20,8 +20,12 @@ type AlertGroupBy = HealthRuleGroupBy
 
 const (
 	// Alert-only templates (cannot be used as recording rules)
-	AlertNoFlows   AlertTemplate = "NetObservNoFlows"
-	AlertLokiError AlertTemplate = "NetObservLokiError"
+	AlertNoFlows          AlertTemplate = "NetObservNoFlows"
+	AlertLokiError        AlertTemplate = "NetObservLokiError"
+	AlertKafkaConsumerLag AlertTemplate = "NetObservKafkaConsumerLag"
+	AlertFLPMemoryHigh    AlertTemplate = "NetObservFLPMemoryHigh"
+	AlertEncodeErrors     AlertTemplate = "NetObservMetricsEncodeErrors"
+	AlertExporterErrors   AlertTemplate = "NetObservFLPExporterErrors"
 
 	// Health rule templates (can be either alerts or recording rules depending on mode)
 	HealthRulePacketDropsByKernel      HealthRuleTemplate = "PacketDropsByKernel"
@@ -35,6 +39,8 @@ const (
 	HealthRuleExternalIngressHighTrend HealthRuleTemplate = "ExternalIngressHighTrend"
 	HealthRuleIngress5xxErrors         HealthRuleTemplate = "Ingress5xxErrors"
 	HealthRuleIngressHTTPLatencyTrend  HealthRuleTemplate = "IngressHTTPLatencyTrend"
+	HealthRuleTCPResetsHigh            HealthRuleTemplate = "TCPResetsHigh"
+	HealthRuleSYNWithoutACK            HealthRuleTemplate = "SYNWithoutACK"
 
 	GroupByNode      HealthRuleGroupBy = "Node"
 	GroupByNamespace HealthRuleGroupBy = "Namespace"
@@ -44,13 +50,21 @@ const (
 	ModeRecording HealthRuleMode = "Recording"
 )
 
+// OperationalAlerts are the alert-only templates monitoring NetObserv itself, which cannot be used as health rules
+var OperationalAlerts = []AlertTemplate{AlertNoFlows, AlertLokiError, AlertKafkaConsumerLag, AlertFLPMemoryHigh, AlertEncodeErrors, AlertExporterErrors}
+
+func (t HealthRuleTemplate) IsOperationalAlert() bool {
+	return slices.Contains(OperationalAlerts, t)
+}
+
 type FLPHealthRule struct {
 	// Health rule template name.
 	// Possible values are: `PacketDropsByKernel`, `PacketDropsByDevice`, `IPsecErrors`, `NetpolDenied`,
-	// `LatencyHighTrend`, `DNSErrors`, `DNSNxDomain`, `ExternalEgressHighTrend`, `ExternalIngressHighTrend`, `Ingress5xxErrors`, `IngressHTTPLatencyTrend`.
-	// Note: `NetObservNoFlows` and `NetObservLokiError` are alert-only and cannot be used as health rules.
+	// `LatencyHighTrend`, `DNSErrors`, `DNSNxDomain`, `ExternalEgressHighTrend`, `ExternalIngressHighTrend`, `Ingress5xxErrors`, `IngressHTTPLatencyTrend`,
+	// `TCPResetsHigh`, `SYNWithoutACK`.
+	// Note: operational alerts such as `NetObservNoFlows` and `NetObservLokiError` are alert-only and cannot be used as health rules.
 	// More information on health rules: https://github.com/netobserv/network-observability-operator/blob/main/docs/HealthRules.md
-	// +kubebuilder:validation:Enum:="PacketDropsByKernel";"PacketDropsByDevice";"IPsecErrors";"NetpolDenied";"LatencyHighTrend";"DNSErrors";"DNSNxDomain";"ExternalEgressHighTrend";"ExternalIngressHighTrend";"Ingress5xxErrors";"IngressHTTPLatencyTrend"
+	// +kubebuilder:validation:Enum:="PacketDropsByKernel";"PacketDropsByDevice";"IPsecErrors";"NetpolDenied";"LatencyHighTrend";"DNSErrors";"DNSNxDomain";"ExternalEgressHighTrend";"ExternalIngressHighTrend";"Ingress5xxErrors";"IngressHTTPLatencyTrend";"TCPResetsHigh";"SYNWithoutACK"
 	// +required
 	Template HealthRuleTemplate `json:"template,omitempty"`
 
@@ -111,6 +125,50 @@ type HealthRuleThresholds struct {
 	Critical string `json:"critical,omitempty"`
 }
 
+// `MaintenanceWindow` defines a period of planned maintenance, during which health rules are silenced.
+// The period is either recurring, with `schedule` and `duration`, or absolute, with `start` and `end`.
+type MaintenanceWindow struct {
+	// Name of the maintenance window. It identifies the window in the health dashboard and in the Alertmanager silences.
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength:=63
+	// +required
+	Name string `json:"name"`
+
+	// `schedule` is a cron expression defining when a recurring window starts, in UTC, with the five standard fields:
+	// minute, hour, day of month, month and day of week. For example, `0 2 * * 6` starts every Saturday at 2:00.
+	// Month and day names, such as `SAT`, and descriptors, such as `@weekly`, are also accepted.
+	// It requires `duration`, and cannot be used together with `start` and `end`.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// `duration` of each recurring window, such as `2h`. It is used with `schedule`.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// `start` time of an absolute window, in RFC 3339 format, such as `2026-11-07T22:00:00Z`. It requires `end`.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// `end` time of an absolute window, in RFC 3339 format. It must be after `start`.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+
+	// `namespaces` restricts the window to the health rules grouped by namespace or workload, for the listed namespaces.
+	// When both `namespaces` and `nodes` are empty, the window applies to all health rules.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// `nodes` restricts the window to the health rules grouped by node, for the listed nodes.
+	// When both `namespaces` and `nodes` are empty, the window applies to all health rules.
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+
+	// `templates` restricts the window to the listed health rule templates. When empty, the window applies to all templates.
+	// Operational alerts, such as `NetObservNoFlows`, are not affected by maintenance windows.
+	// +optional
+	Templates []HealthRuleTemplate `json:"templates,omitempty"`
+}
+
 func (s *FlowCollectorSpec) GetIncludeList() []string {
 	var list []string
 	if s.Processor.Metrics.IncludeList == nil {
@@ -211,7 +269,8 @@ func (g *FLPHealthRule) IsAllowed(spec *FlowCollectorSpec) (bool, string) {
 		if !spec.Agent.EBPF.IsNetworkEventsEnabled() {
 			return false, fmt.Sprintf("HealthRule %s requires the %s agent feature to be enabled", g.Template, NetworkEvents)
 		}
-	case AlertNoFlows, AlertLokiError, HealthRulePacketDropsByDevice, HealthRuleExternalEgressHighTrend, HealthRuleExternalIngressHighTrend, HealthRuleIngress5xxErrors, HealthRuleIngressHTTPLatencyTrend:
+	case AlertNoFlows, AlertLokiError, AlertKafkaConsumerLag, AlertFLPMemoryHigh, AlertEncodeErrors, AlertExporterErrors, HealthRulePacketDropsByDevice, HealthRuleExternalEgressHighTrend, HealthRuleExternalIngressHighTrend, HealthRuleIngress5xxErrors, HealthRuleIngressHTTPLatencyTrend,
+		HealthRuleTCPResetsHigh, HealthRuleSYNWithoutACK:
 		return true, ""
 	}
 	return true, ""
@@ -199,14 +199,27 @@ const (
 	IPSec             AgentFeature = "IPSec"
 )
 
+type UnsupportedFeaturesPolicy string
+
+const (
+	UnsupportedFeaturesReport         UnsupportedFeaturesPolicy = "Report"
+	UnsupportedFeaturesDisablePerNode UnsupportedFeaturesPolicy = "DisablePerNode"
+)
+
 // Name of an eBPF agent alert.
 // Possible values are:<br>
 // `NetObservDroppedFlows`, which is triggered when the eBPF agent is missing packets or flows, such as when the BPF hashmap is busy or full, or the capacity limiter is being triggered.<br>
-// +kubebuilder:validation:Enum:="NetObservDroppedFlows"
+// `NetObservAgentMapFull`, which is triggered when the BPF hashmap of an eBPF agent is often full, making flows go through the less efficient ring buffer.<br>
+// `NetObservAgentExporterErrors`, which is triggered when an eBPF agent fails to write flows to its exporter, such as flowlogs-pipeline or Kafka.<br>
+// `NetObservAgentNoFlows`, which is triggered when an eBPF agent, hence the node where it runs, is not capturing any flow.<br>
+// +kubebuilder:validation:Enum:="NetObservDroppedFlows";"NetObservAgentMapFull";"NetObservAgentExporterErrors";"NetObservAgentNoFlows"
 type EBPFAgentAlert string
 
 const (
-	AlertDroppedFlows EBPFAgentAlert = "NetObservDroppedFlows"
+	AlertDroppedFlows        EBPFAgentAlert = "NetObservDroppedFlows"
+	AlertAgentMapFull        EBPFAgentAlert = "NetObservAgentMapFull"
+	AlertAgentExporterErrors EBPFAgentAlert = "NetObservAgentExporterErrors"
+	AlertAgentNoFlows        EBPFAgentAlert = "NetObservAgentNoFlows"
 )
 
 // `EBPFMetrics` defines the desired eBPF agent configuration regarding metrics
@@ -222,6 +235,9 @@ type EBPFMetrics struct {
 	// `disableAlerts` is a list of alerts that should be disabled.
 	// Possible values are:<br>
 	// `NetObservDroppedFlows`, which is triggered when the eBPF agent is missing packets or flows, such as when the BPF hashmap is busy or full, or the capacity limiter is being triggered.<br>
+	// `NetObservAgentMapFull`, which is triggered when the BPF hashmap of an eBPF agent is often full, making flows go through the less efficient ring buffer.<br>
+	// `NetObservAgentExporterErrors`, which is triggered when an eBPF agent fails to write flows to its exporter, such as flowlogs-pipeline or Kafka.<br>
+	// `NetObservAgentNoFlows`, which is triggered when an eBPF agent, hence the node where it runs, is not capturing any flow.<br>
 	// +optional
 	DisableAlerts []EBPFAgentAlert `json:"disableAlerts"`
 }
@@ -298,6 +314,105 @@ type EBPFFlowFilterRule struct {
 	Sampling *uint32 `json:"sampling,omitempty"`
 }
 
+// Mode used to attach the eBPF programs to the network interfaces. Possible values are:<br>
+// - `TCX`: use the TCX hook, which is recommended but requires a recent kernel (6.6, or 5.14.0-427 on RHEL).<br>
+// - `TC`: use the legacy traffic control (TC) hook.<br>
+// - `Any`: try `TCX`, and fall back on `TC` if not available.<br>
+// +kubebuilder:validation:Enum:="TCX";"TC";"Any"
+type EBPFAttachMode string
+
+const (
+	AttachModeTCX EBPFAttachMode = "TCX"
+	AttachModeTC  EBPFAttachMode = "TC"
+	AttachModeAny EBPFAttachMode = "Any"
+)
+
+// Position of the eBPF programs in the TCX chain. Possible values are:<br>
+// - `None`: no specific anchor is used, programs are generally inserted at the end.<br>
+// - `Head`: programs are inserted at the head.<br>
+// - `Tail`: programs are inserted at the tail.<br>
+// +kubebuilder:validation:Enum:="None";"Head";"Tail"
+type TCXAnchor string
+
+const (
+	TCXAnchorNone TCXAnchor = "None"
+	TCXAnchorHead TCXAnchor = "Head"
+	TCXAnchorTail TCXAnchor = "Tail"
+)
+
+// Direction of the traffic to trace. Possible values are `Both`, `Ingress` or `Egress`.
+// +kubebuilder:validation:Enum:="Both";"Ingress";"Egress"
+type EBPFDirection string
+
+const (
+	DirectionBoth    EBPFDirection = "Both"
+	DirectionIngress EBPFDirection = "Ingress"
+	DirectionEgress  EBPFDirection = "Egress"
+)
+
+// Mechanism used to detect added or removed network interfaces. Possible values are:<br>
+// - `Watch`: interfaces are traced as soon as they are created. This is the recommended setting.<br>
+// - `Poll`: interfaces are periodically listed, according to `pollPeriod`. This is a fallback mechanism, in case `Watch` doesn't work in your environment.<br>
+// +kubebuilder:validation:Enum:="Watch";"Poll"
+type InterfaceDiscoveryMode string
+
+const (
+	InterfaceDiscoveryWatch InterfaceDiscoveryMode = "Watch"
+	InterfaceDiscoveryPoll  InterfaceDiscoveryMode = "Poll"
+)
+
+// `EBPFAttachment` defines how the eBPF programs are attached to the network interfaces.
+type EBPFAttachment struct {
+	// `mode` is the mode used to attach the eBPF programs to the network interfaces: `TCX`, `TC` or `Any`.
+	// When unset, `TCX` is used, except on OpenShift versions older than 4.16 where `TC` is used.
+	// This setting is ignored when the `EbpfManager` feature is enabled.
+	// +optional
+	Mode EBPFAttachMode `json:"mode,omitempty"`
+
+	// `tcxAnchorIngress` defines the position of the ingress eBPF program in the TCX chain: `None` (default), `Head` or `Tail`.
+	// It cannot be used with the `TC` attachment mode.
+	// +optional
+	TCXAnchorIngress TCXAnchor `json:"tcxAnchorIngress,omitempty"`
+
+	// `tcxAnchorEgress` defines the position of the egress eBPF program in the TCX chain: `None` (default), `Head` or `Tail`.
+	// It cannot be used with the `TC` attachment mode.
+	// +optional
+	TCXAnchorEgress TCXAnchor `json:"tcxAnchorEgress,omitempty"`
+
+	// `direction` defines which traffic direction is traced: `Both` (default), `Ingress` or `Egress`.
+	// +optional
+	Direction EBPFDirection `json:"direction,omitempty"`
+}
+
+// `EBPFInterfaceDiscovery` defines how the agent discovers network interfaces and resolves their names.
+type EBPFInterfaceDiscovery struct {
+	// `mode` is the mechanism used to detect added or removed network interfaces: `Watch` (default) or `Poll`.
+	// +optional
+	Mode InterfaceDiscoveryMode `json:"mode,omitempty"`
+
+	// `pollPeriod` is the period for listing network interfaces, when `mode` is `Poll`. Default: 10s.
+	//+kubebuilder:validation:Pattern:=^\d+(ns|ms|s|m)?$
+	// +optional
+	PollPeriod string `json:"pollPeriod,omitempty"`
+
+	// `preferredInterfaces` helps resolving interface names when the same interface index is found in several network namespaces,
+	// by providing the preferred interface name for a given MAC address prefix. When unset, `eth0` is preferred
+	// for the `0a:58` prefix, which is used by OVN-Kubernetes.
+	// +optional
+	PreferredInterfaces []EBPFPreferredInterface `json:"preferredInterfaces,omitempty"`
+}
+
+// `EBPFPreferredInterface` maps a MAC address prefix to a preferred interface name.
+type EBPFPreferredInterface struct {
+	// `macPrefix` is a MAC address prefix, such as `0a:58`.
+	//+kubebuilder:validation:Pattern:=`^[0-9a-fA-F]{2}(:[0-9a-fA-F]{2}){0,5}$`
+	MACPrefix string `json:"macPrefix"`
+
+	// `interface` is the preferred interface name for this MAC address prefix, such as `eth0`.
+	//+kubebuilder:validation:MinLength=1
+	Interface string `json:"interface"`
+}
+
 // `EBPFFlowFilter` defines the desired eBPF agent configuration regarding flow filtering.
 type EBPFFlowFilter struct {
 	// Set `enable` to `true` to enable the eBPF flow filtering feature.
@@ -404,6 +519,17 @@ type FlowCollectorEBPF struct {
 	// +optional
 	Features []AgentFeature `json:"features,omitempty"`
 
+	// `unsupportedFeaturesPolicy` defines how to handle features that are not supported by the kernel of some nodes.
+	// The kernel version of each node is checked against the requirements of the `NetworkEvents`, `PacketTranslation`
+	// and `IPSec` features, and of the TCX hook attachment mode. Possible values are:<br>
+	// - `Report` (default): incompatible nodes are reported in webhook warnings and in the FlowCollector status, without other changes.<br>
+	// - `DisablePerNode`: in addition to reporting, a separate agent DaemonSet is deployed on the incompatible nodes, with the unsupported
+	// features disabled, and falling back to the TC attachment mode when TCX is not supported.<br>
+	// +kubebuilder:validation:Enum:="Report";"DisablePerNode"
+	// +kubebuilder:default:=Report
+	// +optional
+	UnsupportedFeaturesPolicy UnsupportedFeaturesPolicy `json:"unsupportedFeaturesPolicy,omitempty"`
+
 	// `metrics` defines the eBPF agent configuration regarding metrics.
 	// +optional
 	Metrics EBPFMetrics `json:"metrics,omitempty"`
@@ -411,6 +537,14 @@ type FlowCollectorEBPF struct {
 	// `flowFilter` defines the eBPF agent configuration regarding flow filtering.
 	// +optional
 	FlowFilter *EBPFFlowFilter `json:"flowFilter,omitempty"`
+
+	// `attachment` defines how the eBPF programs are attached to the network interfaces.
+	// +optional
+	Attachment EBPFAttachment `json:"attachment,omitempty"`
+
+	// `interfaceDiscovery` defines how the agent discovers network interfaces and resolves their names.
+	// +optional
+	InterfaceDiscovery EBPFInterfaceDiscovery `json:"interfaceDiscovery,omitempty"`
 }
 
 // `FlowCollectorKafka` defines the desired Kafka config of FlowCollector
@@ -432,6 +566,66 @@ type FlowCollectorKafka struct {
 	// SASL authentication configuration. [Unsupported (*)].
 	// +optional
 	SASL SASLConfig `json:"sasl"`
+
+	// `provisioning` lets the operator create the Kafka topic and user with Strimzi, and configure `tls` or `sasl` from
+	// the generated credentials. It requires the Strimzi API to be installed, and is only used with `spec.deploymentModel` set to `Kafka`.
+	// +optional
+	Provisioning *KafkaProvisioning `json:"provisioning,omitempty"`
+
+	// Custom fields mapping, only used by Kafka exporters (`spec.exporters`).
+	// Rules are merged into the exporter `schema` mapping per `input` field: a rule replaces the schema rule for the same `input`,
+	// or removes that field when `output` is empty. Use the `NetObserv` schema for a fully custom mapping.
+	// +optional
+	FieldsMapping *[]GenericTransformRule `json:"fieldsMapping,omitempty"`
+}
+
+type KafkaProvisioningAuthentication string
+
+const (
+	KafkaProvisioningAuthTLS         KafkaProvisioningAuthentication = "TLS"
+	KafkaProvisioningAuthScramSHA512 KafkaProvisioningAuthentication = "ScramSHA512"
+)
+
+// `KafkaProvisioning` defines the Strimzi `KafkaTopic` and `KafkaUser` to create for the Kafka deployment model
+type KafkaProvisioning struct {
+	// Set `enable` to `true` to create and own the `KafkaTopic` and `KafkaUser` resources. When set back to `false`, when `provisioning`
+	// is removed, or when `namespace` changes, the previously created resources are deleted, which can delete the topic depending
+	// on the Strimzi Topic Operator configuration.
+	// +optional
+	Enable bool `json:"enable,omitempty"`
+
+	// `cluster` is the name of the Strimzi `Kafka` resource.
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	Cluster string `json:"cluster"`
+
+	// `namespace` of the Strimzi `Kafka` resource. The `KafkaTopic` and `KafkaUser` are created in this namespace.
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	Namespace string `json:"namespace"`
+
+	// `partitions` is the number of partitions of the topic. When unset, it is aligned with the number of `flowlogs-pipeline`
+	// replicas, or with the autoscaler maximum replicas when it is enabled.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Partitions *int32 `json:"partitions,omitempty"`
+
+	// `replicas` is the replication factor of the topic. When unset, the Kafka cluster default is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// `retention` is the retention time of the topic messages. When unset, the Kafka cluster default is used.
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty"`
+
+	// `authentication` selects how the `KafkaUser` authenticates:<br>
+	// - `TLS` (default) configures `spec.kafka.tls` with the user certificate and the cluster CA.<br>
+	// - `ScramSHA512` configures `spec.kafka.sasl` with the generated password.
+	// +kubebuilder:validation:Enum:="TLS";"ScramSHA512"
+	// +kubebuilder:default:="TLS"
+	// +optional
+	Authentication KafkaProvisioningAuthentication `json:"authentication,omitempty"`
 }
 
 type FlowCollectorIPFIXReceiver struct {
@@ -506,6 +700,8 @@ type FlowCollectorOpenTelemetry struct {
 	// Custom fields mapping to an OpenTelemetry conformant format.
 	// By default, NetObserv format proposal is used: https://github.com/rhobs/observability-data-model/blob/main/network-observability.md#format-proposal .
 	// As there is currently no accepted standard for L3 or L4 enriched network logs, you can freely override it with your own.
+	// Rules are merged into the exporter `schema` mapping per `input` field: a rule replaces the schema rule for the same `input`,
+	// or removes that field when `output` is empty. Use the `NetObserv` schema for a fully custom mapping.
 	// +optional
 	FieldsMapping *[]GenericTransformRule `json:"fieldsMapping,omitempty"`
 
@@ -531,7 +727,7 @@ type ServerTLS struct {
 	// Select the type of TLS configuration:<br>
 	// - `Disabled` (default) to not configure TLS for the endpoint.
 	// - `Provided` to manually provide cert file and a key file. [Unsupported (*)].
-	// - `Auto` to use OpenShift auto generated certificate using annotations.
+	// - `Auto` to use OpenShift auto generated certificate using annotations, or certificates issued by cert-manager when installed on other platforms.
 	// +unionDiscriminator
 	// +kubebuilder:validation:Enum:="Disabled";"Provided";"Auto"
 	// +kubebuilder:validation:Required
@@ -566,7 +762,7 @@ type MetricsServerConfig struct {
 }
 
 // Metric name. More information in https://github.com/netobserv/network-observability-operator/blob/main/docs/Metrics.md.
-// +kubebuilder:validation:Enum:="namespace_egress_bytes_total";"namespace_egress_packets_total";"namespace_ingress_bytes_total";"namespace_ingress_packets_total";"namespace_flows_total";"node_egress_bytes_total";"node_egress_packets_total";"node_ingress_bytes_total";"node_ingress_packets_total";"node_flows_total";"workload_egress_bytes_total";"workload_egress_packets_total";"workload_ingress_bytes_total";"workload_ingress_packets_total";"workload_flows_total";"namespace_drop_bytes_total";"namespace_drop_packets_total";"node_drop_bytes_total";"node_drop_packets_total";"workload_drop_bytes_total";"workload_drop_packets_total";"namespace_rtt_seconds";"node_rtt_seconds";"workload_rtt_seconds";"namespace_dns_latency_seconds";"node_dns_latency_seconds";"workload_dns_latency_seconds";"node_network_policy_events_total";"namespace_network_policy_events_total";"workload_network_policy_events_total";"node_ipsec_flows_total";"node_to_node_ingress_flows_total"
+// +kubebuilder:validation:Enum:="namespace_egress_bytes_total";"namespace_egress_packets_total";"namespace_ingress_bytes_total";"namespace_ingress_packets_total";"namespace_flows_total";"node_egress_bytes_total";"node_egress_packets_total";"node_ingress_bytes_total";"node_ingress_packets_total";"node_flows_total";"workload_egress_bytes_total";"workload_egress_packets_total";"workload_ingress_bytes_total";"workload_ingress_packets_total";"workload_flows_total";"namespace_drop_bytes_total";"namespace_drop_packets_total";"node_drop_bytes_total";"node_drop_packets_total";"workload_drop_bytes_total";"workload_drop_packets_total";"namespace_rtt_seconds";"node_rtt_seconds";"workload_rtt_seconds";"namespace_dns_latency_seconds";"node_dns_latency_seconds";"workload_dns_latency_seconds";"node_network_policy_events_total";"namespace_network_policy_events_total";"workload_network_policy_events_total";"node_ipsec_flows_total";"node_to_node_ingress_flows_total";"node_tcp_flags_total";"namespace_tcp_flags_total";"workload_tcp_flags_total"
 type FLPMetric string
 
 // `FLPMetrics` define the desired FLP configuration regarding metrics
@@ -589,8 +785,9 @@ type FLPMetrics struct {
 	IncludeList *[]FLPMetric `json:"includeList,omitempty"`
 
 	// `disableAlerts` is a list of alert groups that should be disabled from the default set of alerts.
-	// Possible values are: `NetObservNoFlows`, `NetObservLokiError`, `PacketDropsByKernel`, `PacketDropsByDevice`, `IPsecErrors`, `NetpolDenied`,
-	// `LatencyHighTrend`, `DNSErrors`, `DNSNxDomain`, `ExternalEgressHighTrend`, `ExternalIngressHighTrend`, `Ingress5xxErrors`, `IngressHTTPLatencyTrend`.
+	// Possible values are: `NetObservNoFlows`, `NetObservLokiError`, `NetObservKafkaConsumerLag`, `NetObservFLPMemoryHigh`, `NetObservMetricsEncodeErrors`, `NetObservFLPExporterErrors`, `PacketDropsByKernel`, `PacketDropsByDevice`, `IPsecErrors`, `NetpolDenied`,
+	// `LatencyHighTrend`, `DNSErrors`, `DNSNxDomain`, `ExternalEgressHighTrend`, `ExternalIngressHighTrend`, `Ingress5xxErrors`, `IngressHTTPLatencyTrend`,
+	// `TCPResetsHigh`, `SYNWithoutACK`.
 	// More information on alerts: https://github.com/netobserv/network-observability-operator/blob/main/docs/HealthRules.md
 	// +optional
 	DisableAlerts []HealthRuleTemplate `json:"disableAlerts"`
@@ -600,6 +797,84 @@ type FLPMetrics struct {
 	// More information on health rules: https://github.com/netobserv/network-observability-operator/blob/main/docs/HealthRules.md
 	// +optional
 	HealthRules *[]FLPHealthRule `json:"healthRules"`
+
+	// `maintenanceWindows` is a list of planned maintenance periods, during which health rules are silenced.
+	// When an Alertmanager URL is configured in `spec.prometheus.querier.manual.alertManager`, the operator creates Alertmanager silences
+	// for the health rule alerts. Otherwise, or when Alertmanager cannot be reached, the alerts are inhibited directly in the generated rules.
+	// Active windows are displayed in the health dashboard.
+	// More information on maintenance windows: https://github.com/netobserv/network-observability-operator/blob/main/docs/HealthRules.md
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// `dashboards` configures the dashboards built from the metrics. On OpenShift, they are always installed in the Console.
+	// This section allows to provide them to Grafana as well.
+	// +optional
+	Dashboards *FLPDashboards `json:"dashboards,omitempty"`
+}
+
+type FLPDashboards struct {
+	// `grafana` configures the dashboards provided to Grafana.
+	// +optional
+	Grafana GrafanaDashboards `json:"grafana,omitempty"`
+
+	// `recordingRules` configures Prometheus recording rules for the aggregations queried by the dashboards.
+	// +optional
+	RecordingRules DashboardsRecordingRules `json:"recordingRules,omitempty"`
+}
+
+type DashboardsRecordingRules struct {
+	// Set `enable` to `true` to generate a `PrometheusRule` with recording rules that precompute the rates of flow metrics
+	// queried by the dashboards, and to make the dashboards query the recorded series instead. This reduces the Prometheus load
+	// when dashboards are opened, at the cost of continuously evaluating the rules and storing the recorded series.
+	// Recorded series have no history prior to the rules creation. This requires the Prometheus Operator API (`monitoring.coreos.com/v1`).
+	//+kubebuilder:default:=false
+	// +optional
+	Enable *bool `json:"enable,omitempty"`
+}
+
+type GrafanaDashboardsMode string
+
+const (
+	GrafanaDashboardsAuto            GrafanaDashboardsMode = "Auto"
+	GrafanaDashboardsGrafanaOperator GrafanaDashboardsMode = "GrafanaOperator"
+	GrafanaDashboardsSidecar         GrafanaDashboardsMode = "Sidecar"
+	GrafanaDashboardsDisabled        GrafanaDashboardsMode = "Disabled"
+)
+
+type GrafanaDashboards struct {
+	// `mode` defines how dashboards are provided to Grafana:<br>
+	// - `Auto` (default) creates `GrafanaDashboard` resources when the Grafana Operator API (`grafana.integreatly.org/v1beta1`) is detected, and nothing otherwise.<br>
+	// - `GrafanaOperator` creates `GrafanaDashboard` resources.<br>
+	// - `Sidecar` creates ConfigMaps labelled for the Grafana dashboards sidecar, as deployed by the Grafana Helm chart.<br>
+	// - `Disabled` does not provide dashboards to Grafana.
+	//+kubebuilder:validation:Enum:="Auto";"GrafanaOperator";"Sidecar";"Disabled"
+	//+kubebuilder:default:="Auto"
+	// +optional
+	Mode GrafanaDashboardsMode `json:"mode,omitempty"`
+
+	// `namespace` where the `GrafanaDashboard` resources or the ConfigMaps are created. When empty, the FlowCollector namespace is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// `datasource` is the name of the Prometheus datasource in Grafana, used by all the dashboard panels.
+	//+kubebuilder:default:="prometheus"
+	// +optional
+	Datasource string `json:"datasource,omitempty"`
+
+	// `folder` is the Grafana folder where dashboards are created.
+	//+kubebuilder:default:="NetObserv"
+	// +optional
+	Folder string `json:"folder,omitempty"`
+
+	// `instanceSelector` are the labels selecting the Grafana instances that import the dashboards, in `GrafanaOperator` mode.
+	//+kubebuilder:default:={"dashboards":"grafana"}
+	// +optional
+	InstanceSelector map[string]string `json:"instanceSelector,omitempty"`
+
+	// `sidecarLabel` is the label key, set to "1", that the Grafana sidecar watches to discover dashboard ConfigMaps, in `Sidecar` mode.
+	//+kubebuilder:default:="grafana_dashboard"
+	// +optional
+	SidecarLabel string `json:"sidecarLabel,omitempty"`
 }
 
 type FLPLogTypes string
@@ -709,6 +984,10 @@ type FlowCollectorFLP struct {
 	//+optional
 	SlicesConfig *SlicesConfig `json:"slicesConfig,omitempty"`
 
+	// `agentsTLS` configures TLS on the connection from the eBPF agents to the flow processor, when Kafka is not used.
+	// +optional
+	AgentsTLS FLPAgentsTLS `json:"agentsTLS,omitempty"`
+
 	// `advanced` allows setting some aspects of the internal configuration of the flow processor.
 	// This section is aimed mostly for debugging and fine-grained performance optimizations,
 	// such as `GOGC` and `GOMAXPROCS` environment variables. Set these values at your own risk.
@@ -716,6 +995,64 @@ type FlowCollectorFLP struct {
 	Advanced *AdvancedProcessorConfig `json:"advanced,omitempty"`
 }
 
+type FLPAgentsTLSMode string
+
+const (
+	FLPAgentsTLSDefault FLPAgentsTLSMode = "Default"
+	FLPAgentsTLSMutual  FLPAgentsTLSMode = "Mutual"
+)
+
+type FLPAgentsCertificatesType string
+
+const (
+	FLPAgentsCertificatesAuto     FLPAgentsCertificatesType = "Auto"
+	FLPAgentsCertificatesProvided FLPAgentsCertificatesType = "Provided"
+)
+
+// `FLPAgentsTLS` defines the TLS configuration between the eBPF agents and the flow processor.
+type FLPAgentsTLS struct {
+	// `mode` defines how the connection from the eBPF agents to the flow processor is secured.<br>
+	// - `Default`: in `Service` deployment model, agents verify the flow processor server certificate, without client authentication.
+	// In `Direct` deployment model, the connection is not encrypted.<br>
+	// - `Mutual`: in all deployment models, agents and the flow processor authenticate each other with mutual TLS,
+	// so that only the agents can send flows to the flow processor.<br>
+	// +kubebuilder:validation:Enum:="Default";"Mutual"
+	// +kubebuilder:default:=Default
+	// +optional
+	Mode FLPAgentsTLSMode `json:"mode,omitempty"`
+
+	// `certificates` defines where the certificates used for mutual TLS come from.<br>
+	// - `Auto`: certificates are issued and renewed automatically. When cert-manager is installed, except on OpenShift, it issues them in the privileged namespace.
+	// With the cert-manager CSI driver, each agent pod gets its own client certificate, named after the pod, and agents are restarted every 30 days to renew it;
+	// otherwise, agents share a client certificate. Without cert-manager, the operator manages a dedicated Certificate Authority, and agents share a client certificate.
+	// The OpenShift service CA isn't used, since it only issues serving certificates. When the operator renews its Certificate Authority, the new one is trusted
+	// by both sides for a day before it signs certificates, and the previous one remains trusted until it expires.
+	// In `Direct` deployment model, agents connect through the flow processor service, which routes traffic to the instance on the same node,
+	// so that the server certificate only covers the service name.<br>
+	// - `Provided`: use the certificates referenced in `provided`, for instance issued by cert-manager.<br>
+	// +kubebuilder:validation:Enum:="Auto";"Provided"
+	// +kubebuilder:default:=Auto
+	// +optional
+	Certificates FLPAgentsCertificatesType `json:"certificates,omitempty"`
+
+	// `provided` references the certificates to use when `certificates` is `Provided`.
+	// +optional
+	Provided *FLPAgentsProvidedCertificates `json:"provided,omitempty"`
+}
+
+// `FLPAgentsProvidedCertificates` references user-provided certificates for mutual TLS between the eBPF agents and the flow processor.
+type FLPAgentsProvidedCertificates struct {
+	// `caCert` references the Certificate Authority used by both sides to verify their peer certificate.
+	CACert CertificateReference `json:"caCert,omitempty"`
+
+	// `serverCert` references the flow processor server certificate and private key.
+	// It must be valid for the flow processor service name, such as `flowlogs-pipeline.netobserv.svc`, in all deployment models.
+	ServerCert CertificateReference `json:"serverCert,omitempty"`
+
+	// `clientCert` references the eBPF agents client certificate and private key.
+	ClientCert CertificateReference `json:"clientCert,omitempty"`
+}
+
 type FLPDeduperMode string
 
 const (
@@ -871,6 +1208,11 @@ type LokiManualParams struct {
 	// When using the Loki Operator, this must be set to `Forward`.
 	AuthToken LokiAuthToken `json:"authToken,omitempty"`
 
+	// `auth` defines credentials read from secrets or config maps, sent with each request to Loki, both for writing flows and for querying them.
+	// It cannot be used together with `authToken`.
+	// +optional
+	Auth HTTPClientAuth `json:"auth,omitempty"`
+
 	// TLS client configuration for Loki URL.
 	// +optional
 	TLS ClientTLS `json:"tls"`
@@ -922,7 +1264,8 @@ type LokiMonolithParams struct {
 
 // LokiStackRef defines the name and namespace of the LokiStack instance
 type LokiStackRef struct {
-	// Name of an existing LokiStack resource to use.
+	// Name of an existing LokiStack resource to use. When `provisioning` is enabled, renaming it creates a new `LokiStack`,
+	// and the previous one is kept until `provisioning.enable` is set to `false`.
 	//+kubebuilder:default:="loki"
 	//+kubebuilder:validation:Required
 	Name string `json:"name,omitempty"`
@@ -930,6 +1273,84 @@ type LokiStackRef struct {
 	// Namespace where this `LokiStack` resource is located. If omitted, it is assumed to be the same as `spec.namespace`.
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
+
+	// `provisioning` allows the operator to create and own the `LokiStack` resource, instead of referencing an existing one.
+	// It requires the Loki Operator to be installed.
+	// +optional
+	Provisioning *LokiStackProvisioning `json:"provisioning,omitempty"`
+}
+
+type LokiStackStorageType string
+
+const (
+	LokiStackStorageS3           LokiStackStorageType = "s3"
+	LokiStackStorageAzure        LokiStackStorageType = "azure"
+	LokiStackStorageGCS          LokiStackStorageType = "gcs"
+	LokiStackStorageSwift        LokiStackStorageType = "swift"
+	LokiStackStorageAlibabaCloud LokiStackStorageType = "alibabacloud"
+)
+
+// `LokiStackProvisioning` defines the `LokiStack` resource created by the operator
+type LokiStackProvisioning struct {
+	// Set `enable` to `true` to let the operator create the `LokiStack` resource named `spec.loki.lokiStack.name`,
+	// and keep it in sync with this configuration. The `LokiStack` is configured with the `openshift-network` tenant mode.
+	// Setting `enable` back to `false` deletes every `LokiStack` created by the operator, including the ones left after
+	// renaming `spec.loki.lokiStack.name`. Stored flows are no longer queryable, and data not yet flushed to the object storage is lost.
+	// Switching `spec.loki.mode`, disabling Loki or removing `provisioning` keeps the `LokiStack`; it is still deleted
+	// along with the `FlowCollector` resource.
+	//+kubebuilder:default:=false
+	Enable bool `json:"enable,omitempty"`
+
+	// `size` of the `LokiStack` deployment. When omitted, it is chosen at creation from the number of nodes in the cluster
+	// and the eBPF agent sampling ratio (`spec.agent.ebpf.sampling`), and kept afterwards.
+	//+kubebuilder:validation:Enum:="1x.demo";"1x.extra-small";"1x.small";"1x.medium"
+	// +optional
+	Size string `json:"size,omitempty"`
+
+	// `storage` references the object storage used by the `LokiStack`.
+	// +optional
+	Storage LokiStackStorage `json:"storage,omitempty"`
+
+	// `storageClassName` is the name of the storage class used for the `LokiStack` persistent volumes.
+	// When omitted, the default storage class of the cluster is used.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// `retention` defines how long flow logs are kept, per log type.
+	// +optional
+	Retention *LokiStackRetention `json:"retention,omitempty"`
+}
+
+// `LokiStackStorage` references the object storage secret of the `LokiStack`
+type LokiStackStorage struct {
+	// `secretName` is the name of the secret, in the `LokiStack` namespace, that contains the object storage configuration.
+	// Refer to the Loki Operator documentation for the expected content of this secret.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// `secretType` is the type of object storage described in the secret.
+	//+kubebuilder:validation:Enum:="s3";"azure";"gcs";"swift";"alibabacloud"
+	//+kubebuilder:default:="s3"
+	SecretType LokiStackStorageType `json:"secretType,omitempty"`
+}
+
+// `LokiStackRetention` defines the retention of flow logs, in days
+type LokiStackRetention struct {
+	// `days` is the default retention of flow logs, in days. When omitted, the Loki Operator default is used.
+	//+kubebuilder:validation:Minimum=1
+	// +optional
+	Days *int32 `json:"days,omitempty"`
+
+	// `flows` is the retention of flow logs (`Flows` log type), in days. When omitted, `days` applies.
+	//+kubebuilder:validation:Minimum=1
+	// +optional
+	Flows *int32 `json:"flows,omitempty"`
+
+	// `conversations` is the retention of conversation events (`Conversations` and `EndedConversations` log types), in days.
+	// When omitted, `days` applies.
+	//+kubebuilder:validation:Minimum=1
+	// +optional
+	Conversations *int32 `json:"conversations,omitempty"`
 }
 
 type LokiMode string
@@ -1026,6 +1447,12 @@ type PrometheusQuerierManual struct {
 	// +optional
 	ForwardUserToken bool `json:"forwardUserToken"`
 
+	// `auth` defines credentials read from secrets or config maps, sent with each query to Prometheus, such as basic auth,
+	// a static bearer token or a tenant header. It is used with the rest of the manual configuration, in `Manual` mode, or in `Auto` mode outside of OpenShift.
+	// It cannot be used together with `forwardUserToken`.
+	// +optional
+	Auth HTTPClientAuth `json:"auth,omitempty"`
+
 	// AlertManager configuration. This is used in the console to query silenced alerts, for displaying health information.
 	// When used in OpenShift it can be left empty to use the Console API instead.
 	// [Unsupported (*)].
@@ -1042,6 +1469,10 @@ type AlertManagerQuerierManual struct {
 	// TLS client configuration for Prometheus AlertManager URL.
 	// +optional
 	TLS ClientTLS `json:"tls"`
+
+	// `auth` defines credentials read from secrets or config maps, sent with each query to AlertManager.
+	// +optional
+	Auth HTTPClientAuth `json:"auth,omitempty"`
 }
 
 type PrometheusMode string
@@ -1285,6 +1716,55 @@ type SASLConfig struct {
 	ClientSecretReference FileReference `json:"clientSecretReference,omitempty"`
 }
 
+type HTTPClientAuthType string
+
+const (
+	HTTPClientAuthNone   HTTPClientAuthType = "None"
+	HTTPClientAuthBasic  HTTPClientAuthType = "Basic"
+	HTTPClientAuthBearer HTTPClientAuthType = "Bearer"
+)
+
+// `HTTPClientAuth` defines credentials read from secrets or config maps, used to authenticate HTTP requests.
+// They are mounted in the Console plugin pod, and passed as file paths in its configuration: querying with these credentials
+// requires a Console plugin version that supports the `usernamePath`, `passwordPath`, `tokenPath` and `headerPaths` settings.
+type HTTPClientAuth struct {
+	//+kubebuilder:validation:Enum:="None";"Basic";"Bearer"
+	//+kubebuilder:default:="None"
+	// `type` is the authentication scheme:<br>
+	// - `None` does not send any credentials.<br>
+	// - `Basic` uses HTTP basic authentication, with the `username` and `password` references.<br>
+	// - `Bearer` sends the token from the `token` reference in the `Authorization` header.
+	// +optional
+	Type HTTPClientAuthType `json:"type,omitempty"`
+
+	// Reference to the secret or config map containing the username, when `type` is `Basic`.
+	// +optional
+	Username *FileReference `json:"username,omitempty"`
+
+	// Reference to the secret containing the password, when `type` is `Basic`.
+	// +optional
+	Password *FileReference `json:"password,omitempty"`
+
+	// Reference to the secret containing the bearer token, when `type` is `Bearer`.
+	// +optional
+	Token *FileReference `json:"token,omitempty"`
+
+	// `headers` is a list of additional HTTP headers sent with each request, whose values are read from secrets or config maps,
+	// such as `X-Scope-OrgID` for multi-tenant backends.
+	// +optional
+	Headers []HTTPHeaderReference `json:"headers,omitempty"`
+}
+
+// `HTTPHeaderReference` defines an HTTP header whose value is read from a secret or a config map
+type HTTPHeaderReference struct {
+	//+kubebuilder:validation:MinLength:=1
+	// Name of the HTTP header.
+	Name string `json:"name"`
+
+	// Reference to the secret or config map containing the header value.
+	ValueFrom FileReference `json:"valueFrom"`
+}
+
 // `SchedulingConfig` defines the scheduling configuration for NetObserv pods
 type SchedulingConfig struct {
 	// `tolerations` is a list of tolerations that allow the pod to schedule onto nodes with matching taints.
@@ -1521,6 +2001,15 @@ const (
 	OpenTelemetryExporter ExporterType = "OpenTelemetry"
 )
 
+type ExporterSchema string
+
+const (
+	ExporterSchemaNetObserv ExporterSchema = "NetObserv"
+	ExporterSchemaOTel      ExporterSchema = "OTel"
+	ExporterSchemaOCSF      ExporterSchema = "OCSF-NetworkActivity"
+	ExporterSchemaECS       ExporterSchema = "ECS"
+)
+
 // `FlowCollectorExporter` defines an additional exporter to send enriched flows to.
 type FlowCollectorExporter struct {
 	// `type` selects the type of exporters. The available options are `Kafka`, `IPFIX`, and `OpenTelemetry`.
@@ -1529,6 +2018,21 @@ type FlowCollectorExporter struct {
 	// +kubebuilder:validation:Required
 	Type ExporterType `json:"type"`
 
+	// `schema` selects the format of the exported flows. The available options are:<br>
+	// - `NetObserv` to keep the native flow format, as stored in Loki.<br>
+	// - `OTel` for the NetObserv format proposal for OpenTelemetry.<br>
+	// - `OCSF-NetworkActivity` for the Open Cybersecurity Schema Framework Network Activity class [4001]. As `flowlogs-pipeline`
+	// cannot build nested objects, OCSF attributes are written as flat, dotted keys, such as `src_endpoint.ip`. Consumers
+	// that expect nested objects must expand these keys.<br>
+	// - `ECS` for the Elastic Common Schema.<br>
+	// Besides renaming fields, `OCSF-NetworkActivity` and `ECS` convert timestamps to the schema unit, and add fields derived from
+	// enumerated values, such as the protocol name from the protocol number. Other values are kept unchanged.
+	// When unset, Kafka exporters use `NetObserv` and OpenTelemetry exporters use `OTel`. The `fieldsMapping` of the Kafka
+	// or OpenTelemetry configuration is merged into the schema mapping. This setting does not apply to IPFIX.
+	// +kubebuilder:validation:Enum:="NetObserv";"OTel";"OCSF-NetworkActivity";"ECS"
+	// +optional
+	Schema ExporterSchema `json:"schema,omitempty"`
+
 	// Kafka configuration, such as the address and topic, to send enriched flows to.
 	// +optional
 	Kafka FlowCollectorKafka `json:"kafka,omitempty"`
@@ -1553,6 +2057,59 @@ type FlowCollectorStatus struct {
 	//
 	// Deprecated: annotations are used instead
 	Namespace string `json:"namespace,omitempty"`
+
+	// `agent` summarizes the health of the eBPF agents, per node. It is refreshed periodically.
+	// +optional
+	Agent *FlowCollectorAgentStatus `json:"agent,omitempty"`
+}
+
+// `FlowCollectorAgentStatus` is a compact view of the eBPF agents health across the cluster.
+type FlowCollectorAgentStatus struct {
+	// `summary` is a short, human-readable description of the agents health, such as `10/10 ready, 1 without flows, 1 with issues`.
+	Summary string `json:"summary"`
+
+	// `desiredNodes` is the number of nodes where an agent is expected to run.
+	DesiredNodes int32 `json:"desiredNodes"`
+
+	// `readyNodes` is the number of nodes where the agent pod is ready.
+	ReadyNodes int32 `json:"readyNodes"`
+
+	// `nodesWithoutFlows` is the number of nodes where the agent is ready but did not export any flow since the previous check.
+	NodesWithoutFlows int32 `json:"nodesWithoutFlows"`
+
+	// `nodesWithIssues` lists the nodes that need attention. To keep the status compact, this list is truncated to 10 nodes.
+	// +optional
+	NodesWithIssues []FlowCollectorAgentNodeStatus `json:"nodesWithIssues,omitempty"`
+
+	// `lastUpdateTime` is the last time this status was refreshed.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// `FlowCollectorAgentNodeStatus` describes the health of the eBPF agent on a node.
+type FlowCollectorAgentNodeStatus struct {
+	// `node` is the node name.
+	Node string `json:"node"`
+
+	// `pod` is the agent pod name.
+	Pod string `json:"pod"`
+
+	// `ready` is true when the agent pod is ready.
+	Ready bool `json:"ready"`
+
+	// `restarts` is the number of restarts of the agent container.
+	Restarts int32 `json:"restarts"`
+
+	// `flowsPerSecond` is the rate of flows exported by the agent since the previous check, when metrics are available.
+	// +optional
+	FlowsPerSecond string `json:"flowsPerSecond,omitempty"`
+
+	// `dropRatio` is the ratio of flows dropped by the agent since the previous check, when metrics are available.
+	// +optional
+	DropRatio string `json:"dropRatio,omitempty"`
+
+	// `issues` lists the detected issues, such as eBPF attach errors or the absence of flows.
+	// +optional
+	Issues []string `json:"issues,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -1563,6 +2120,7 @@ type FlowCollectorStatus struct {
 // +kubebuilder:printcolumn:name="Deployment Model",type="string",JSONPath=`.spec.deploymentModel`
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:printcolumn:name="Warnings",type="string",JSONPath=`.status.conditions[?(@.type=="ConfigurationIssue")].message`
+// +kubebuilder:printcolumn:name="Agents",type="string",JSONPath=`.status.agent.summary`,priority=1
 // +kubebuilder:storageversion
 // `FlowCollector` is the schema for the network flows collection API, which pilots and configures the underlying deployments.
 type FlowCollector struct {
@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
+	"net/http"
 	"reflect"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/netobserv/flowlogs-pipeline/pkg/dsl"
+	"github.com/robfig/cron/v3"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -29,6 +33,7 @@ type clusterInfo interface {
 	IsOpenShiftVersionAtLeast(v string) (bool, string, error)
 	GetNbNodes() (uint16, error)
 	GetCNI() (NetworkType, error)
+	GetNodesKernelInfo() ([]NodeKernelInfo, error)
 }
 
 var (
@@ -67,6 +72,10 @@ func (r *FlowCollector) Validate(_ context.Context, fc *FlowCollector) (admissio
 	v.validateNetPol()
 	v.validateAgent()
 	v.validateFLP()
+	v.validateCredentials()
+	v.validateKafkaProvisioning()
+	v.validateLokiStackProvisioning()
+	v.validateExporters()
 	v.warnLogLevels()
 	v.warnLokiDemo()
 	return v.warnings, errors.Join(v.errors...)
@@ -93,6 +102,124 @@ func (v *validator) warnLokiDemo() {
 	}
 }
 
+func (v *validator) validateCredentials() {
+	if v.fc.UseLoki() {
+		manual := &v.fc.Loki.Manual
+		if v.fc.Loki.Mode != LokiModeManual {
+			if manual.Auth.UseCredentials() || len(manual.Auth.Headers) > 0 {
+				v.warnings = append(v.warnings, "The credentials configured in spec.loki.manual.auth are ignored since spec.loki.mode is not Manual")
+			}
+		} else {
+			v.validateHTTPClientAuth(&manual.Auth, "spec.loki.manual.auth")
+			if len(manual.Auth.Headers) > 0 {
+				v.warnings = append(v.warnings, "The headers configured in spec.loki.manual.auth.headers are only used for querying Loki; flowlogs-pipeline only sends the tenant header from spec.loki.manual.tenantID")
+			}
+			if manual.Auth.UseCredentials() && manual.AuthToken != "" && manual.AuthToken != LokiAuthDisabled {
+				v.errors = append(v.errors, errors.New("spec.loki.manual.auth cannot be used together with spec.loki.manual.authToken; set authToken to Disabled"))
+			}
+		}
+	}
+	if v.fc.UsePrometheus() {
+		manual := &v.fc.Prometheus.Querier.Manual
+		if v.usePromManualConfig() {
+			v.validateHTTPClientAuth(&manual.Auth, "spec.prometheus.querier.manual.auth")
+			if manual.Auth.UseCredentials() && manual.ForwardUserToken {
+				v.errors = append(v.errors, errors.New("spec.prometheus.querier.manual.auth cannot be used together with spec.prometheus.querier.manual.forwardUserToken"))
+			}
+		} else if manual.Auth.UseCredentials() || len(manual.Auth.Headers) > 0 {
+			v.warnings = append(v.warnings, "The credentials configured in spec.prometheus.querier.manual.auth are ignored since spec.prometheus.querier.mode is not Manual")
+		}
+		// AlertManager is only configured in Manual mode
+		if am := manual.AlertManager; am != nil {
+			if v.fc.Prometheus.Querier.Mode == PromModeManual {
+				v.validateHTTPClientAuth(&am.Auth, "spec.prometheus.querier.manual.alertManager.auth")
+			} else if am.Auth.UseCredentials() || len(am.Auth.Headers) > 0 {
+				v.warnings = append(v.warnings, "The credentials configured in spec.prometheus.querier.manual.alertManager.auth are ignored since spec.prometheus.querier.mode is not Manual")
+			}
+		}
+	}
+}
+
+// usePromManualConfig returns true when the manual Prometheus configuration is used: in Manual mode, or in Auto mode outside of OpenShift
+func (v *validator) usePromManualConfig() bool {
+	return v.fc.Prometheus.Querier.Mode == PromModeManual || (CurrentClusterInfo != nil && !CurrentClusterInfo.IsOpenShift())
+}
+
+func (v *validator) validateKafkaProvisioning() {
+	prov := v.fc.Kafka.Provisioning
+	if prov != nil && prov.Enable {
+		if !v.fc.UseKafka() {
+			v.warnings = append(v.warnings, "spec.kafka.provisioning is ignored since spec.deploymentModel is not Kafka")
+		} else if prov.Authentication == KafkaProvisioningAuthScramSHA512 {
+			if v.fc.Kafka.SASL.UseSASL() {
+				v.warnings = append(v.warnings, "spec.kafka.sasl is overridden by the credentials of the provisioned KafkaUser")
+			}
+		} else if v.fc.Kafka.TLS.Enable {
+			v.warnings = append(v.warnings, "spec.kafka.tls is overridden by the certificates of the provisioned KafkaUser")
+		}
+	}
+	for i, exp := range v.fc.Exporters {
+		if exp != nil && exp.Type == KafkaExporter && exp.Kafka.Provisioning != nil && exp.Kafka.Provisioning.Enable {
+			v.warnings = append(v.warnings, fmt.Sprintf("spec.exporters[%d].kafka.provisioning is ignored: provisioning is only available for spec.kafka", i))
+		}
+	}
+}
+
+func (v *validator) validateLokiStackProvisioning() {
+	prov := v.fc.Loki.LokiStack.Provisioning
+	if prov == nil || !prov.Enable {
+		return
+	}
+	if !v.fc.UseLoki() || v.fc.Loki.Mode != LokiModeLokiStack {
+		v.warnings = append(v.warnings, "spec.loki.lokiStack.provisioning is ignored since Loki is not enabled in LokiStack mode")
+		return
+	}
+	if prov.Storage.SecretName == "" {
+		v.errors = append(v.errors, errors.New("spec.loki.lokiStack.provisioning.storage.secretName is required to provision a LokiStack"))
+	}
+	if CurrentClusterInfo != nil && !CurrentClusterInfo.IsOpenShift() {
+		v.warnings = append(v.warnings, "The provisioned LokiStack uses the openshift-network tenant mode, which is only supported on OpenShift")
+	}
+}
+
+func (v *validator) validateExporters() {
+	for i, exp := range v.fc.Exporters {
+		if exp == nil {
+			continue
+		}
+		if exp.Type == IpfixExporter && exp.Schema != "" {
+			v.errors = append(v.errors, fmt.Errorf("spec.exporters[%d].schema cannot be used with the IPFIX exporter", i))
+		}
+	}
+	if v.fc.Kafka.FieldsMapping != nil {
+		v.warnings = append(v.warnings, "spec.kafka.fieldsMapping is ignored: it only applies to Kafka exporters, in spec.exporters")
+	}
+}
+
+func (v *validator) validateHTTPClientAuth(auth *HTTPClientAuth, path string) {
+	switch auth.Type {
+	case HTTPClientAuthBasic:
+		if auth.Username == nil || auth.Username.Name == "" || auth.Password == nil || auth.Password.Name == "" {
+			v.errors = append(v.errors, fmt.Errorf("%s.username and %s.password must be set when %s.type is Basic", path, path, path))
+		}
+	case HTTPClientAuthBearer:
+		if auth.Token == nil || auth.Token.Name == "" {
+			v.errors = append(v.errors, fmt.Errorf("%s.token must be set when %s.type is Bearer", path, path))
+		}
+	case HTTPClientAuthNone:
+	}
+	seen := map[string]bool{}
+	for i := range auth.Headers {
+		name := http.CanonicalHeaderKey(auth.Headers[i].Name)
+		if name == "Authorization" {
+			v.errors = append(v.errors, fmt.Errorf("%s.headers cannot define the Authorization header; use %s.type instead", path, path))
+		} else if seen[name] {
+			v.errors = append(v.errors, fmt.Errorf("%s.headers: header %s is defined more than once", path, auth.Headers[i].Name))
+		}
+		seen[name] = true
+	}
+}
+
 func (v *validator) validateDeploymentModel() {
 	if CurrentClusterInfo != nil {
 		n, err := CurrentClusterInfo.GetNbNodes()
@@ -145,6 +272,9 @@ func (v *validator) validateAgent() {
 		}
 	}
 
+	v.validateAgentKernel()
+	v.validateAgentAttachment()
+
 	if slices.Contains(v.fc.Agent.EBPF.Features, PacketDrop) &&
 		!v.fc.Agent.EBPF.Privileged &&
 		!slices.Contains(v.fc.Agent.EBPF.Features, EbpfManager) {
@@ -166,6 +296,70 @@ func (v *validator) validateAgent() {
 	}
 }
 
+func (v *validator) validateAgentKernel() {
+	if CurrentClusterInfo == nil {
+		return
+	}
+	nodes, err := CurrentClusterInfo.GetNodesKernelInfo()
+	if err != nil {
+		// Not blocking; cluster info might not be collected yet
+		return
+	}
+	for _, incompat := range v.fc.Agent.EBPF.GetKernelIncompatibilities(nodes) {
+		v.warnings = append(
+			v.warnings,
+			fmt.Sprintf("The %s requires kernel %s, which is not met on %d node(s): %s", incompat.FeatureName(), incompat.Requirement, len(incompat.Nodes), incompat.NodesSummary()),
+		)
+	}
+}
+
+func (v *validator) validateAgentAttachment() {
+	attachment := &v.fc.Agent.EBPF.Attachment
+	discovery := &v.fc.Agent.EBPF.InterfaceDiscovery
+	hasAnchor := (attachment.TCXAnchorIngress != "" && attachment.TCXAnchorIngress != TCXAnchorNone) ||
+		(attachment.TCXAnchorEgress != "" && attachment.TCXAnchorEgress != TCXAnchorNone)
+	if hasAnchor && attachment.Mode == AttachModeTC {
+		v.errors = append(v.errors, errors.New("spec.agent.ebpf.attachment.tcxAnchorIngress and tcxAnchorEgress cannot be used with the TC attachment mode"))
+	}
+	if (attachment.Mode != "" || hasAnchor) && v.fc.Agent.EBPF.IsEbpfManagerEnabled() {
+		v.warnings = append(v.warnings, "The attachment mode and TCX anchors (in spec.agent.ebpf.attachment) are ignored when the EbpfManager feature is enabled")
+	}
+	if attachment.Mode == AttachModeTCX && CurrentClusterInfo != nil && CurrentClusterInfo.IsOpenShift() {
+		if ok, actual, err := CurrentClusterInfo.IsOpenShiftVersionAtLeast("4.16.0"); err == nil && !ok {
+			v.warnings = append(v.warnings, fmt.Sprintf("The TCX attachment mode requires OpenShift 4.16.0 or above (version detected: %s)", actual))
+		}
+	}
+	if discovery.PollPeriod != "" && discovery.Mode != InterfaceDiscoveryPoll {
+		v.warnings = append(v.warnings, "spec.agent.ebpf.interfaceDiscovery.pollPeriod is ignored when the interface discovery mode is not Poll")
+	}
+	prefixes := make(map[string]bool)
+	for _, pref := range discovery.PreferredInterfaces {
+		prefix := strings.ToLower(pref.MACPrefix)
+		if prefixes[prefix] {
+			v.errors = append(v.errors, fmt.Errorf("spec.agent.ebpf.interfaceDiscovery.preferredInterfaces: MAC prefix %s is defined more than once", pref.MACPrefix))
+			break
+		}
+		prefixes[prefix] = true
+	}
+	// Typed settings can still be overridden via advanced env; warn as this is likely unintended
+	if v.fc.Agent.EBPF.Advanced != nil {
+		overridden := map[string]bool{
+			"TC_ATTACH_MODE":                     attachment.Mode != "",
+			"TCX_ATTACH_ANCHOR_INGRESS":          attachment.TCXAnchorIngress != "",
+			"TCX_ATTACH_ANCHOR_EGRESS":           attachment.TCXAnchorEgress != "",
+			"DIRECTION":                          attachment.Direction != "",
+			"LISTEN_INTERFACES":                  discovery.Mode != "",
+			"LISTEN_POLL_PERIOD":                 discovery.PollPeriod != "",
+			"PREFERRED_INTERFACE_FOR_MAC_PREFIX": len(discovery.PreferredInterfaces) > 0,
+		}
+		for _, env := range slices.Sorted(maps.Keys(overridden)) {
+			if _, isSet := v.fc.Agent.EBPF.Advanced.Env[env]; isSet && overridden[env] {
+				v.warnings = append(v.warnings, fmt.Sprintf("The environment variable %s in spec.agent.ebpf.advanced.env overrides the equivalent setting from spec.agent.ebpf", env))
+			}
+		}
+	}
+}
+
 func (v *validator) validateAgentFilter(f *EBPFFlowFilterRule) {
 	if f.CIDR != "" {
 		if _, _, err := net.ParseCIDR(f.CIDR); err != nil {
@@ -256,10 +450,12 @@ func validatePortString(s string) (uint16, error) {
 
 func (v *validator) validateFLP() {
 	v.validateScheduling()
+	v.validateFLPAgentsTLS()
 	v.validateFLPLogTypes()
 	v.validateFLPFilters()
 	v.validateFLPAlerts()
 	v.validateFLPMetricsForAlerts()
+	v.validateFLPMaintenanceWindows()
 }
 
 func (v *validator) validateScheduling() {
@@ -278,6 +474,31 @@ func (v *validator) validateScheduling() {
 	}
 }
 
+func (v *validator) validateFLPAgentsTLS() {
+	agentsTLS := &v.fc.Processor.AgentsTLS
+	if agentsTLS.Mode != FLPAgentsTLSMutual {
+		if agentsTLS.Certificates == FLPAgentsCertificatesProvided || agentsTLS.Provided != nil {
+			v.warnings = append(v.warnings, "The certificates configured in spec.processor.agentsTLS are ignored since mode is not Mutual")
+		}
+		return
+	}
+	if v.fc.UseKafka() {
+		v.warnings = append(v.warnings, "Mutual TLS configured in spec.processor.agentsTLS is ignored when Kafka is used; configure spec.kafka.tls instead")
+		return
+	}
+	if v.fc.Processor.Advanced != nil && IsEnvEnabled(v.fc.Processor.Advanced.Env, "SERVER_NOTLS") {
+		v.warnings = append(v.warnings, "The SERVER_NOTLS environment variable in spec.processor.advanced.env is ignored when spec.processor.agentsTLS.mode is Mutual")
+	}
+	if agentsTLS.Certificates == FLPAgentsCertificatesProvided {
+		p := agentsTLS.Provided
+		if p == nil || p.CACert.Name == "" || p.ServerCert.Name == "" || p.ClientCert.Name == "" {
+			v.errors = append(v.errors, errors.New("spec.processor.agentsTLS.provided must reference the CA, server and client certificates when spec.processor.agentsTLS.certificates is Provided"))
+		} else if p.ServerCert.CertKey == "" || p.ClientCert.CertKey == "" {
+			v.errors = append(v.errors, errors.New("spec.processor.agentsTLS.provided.serverCert and clientCert must define certKey"))
+		}
+	}
+}
+
 func (v *validator) validateFLPLogTypes() {
 	if v.fc.Processor.HasConntrack() {
 		if *v.fc.Processor.LogTypes == LogTypeAll {
@@ -374,12 +595,51 @@ func (v *validator) validateFLPAlerts() {
 	}
 }
 
+func (v *validator) validateFLPMaintenanceWindows() {
+	names := map[string]bool{}
+	for i := range v.fc.Processor.Metrics.MaintenanceWindows {
+		w := &v.fc.Processor.Metrics.MaintenanceWindows[i]
+		path := fmt.Sprintf("spec.processor.metrics.maintenanceWindows[%d]", i)
+		if names[w.Name] {
+			v.errors = append(v.errors, fmt.Errorf("maintenance window name %s is used more than once, in %s", w.Name, path))
+		}
+		names[w.Name] = true
+		if w.Schedule != "" {
+			if w.Start != nil || w.End != nil {
+				v.errors = append(v.errors, fmt.Errorf("schedule cannot be used together with start and end, in %s", path))
+			}
+			if _, err := cron.ParseStandard(w.Schedule); err != nil {
+				v.errors = append(v.errors, fmt.Errorf("%w, in %s.schedule", err, path))
+			}
+			if w.Duration == nil || w.Duration.Duration <= 0 {
+				v.errors = append(v.errors, fmt.Errorf("a positive duration is required with schedule, in %s", path))
+			}
+		} else {
+			if w.Start == nil || w.End == nil {
+				v.errors = append(v.errors, fmt.Errorf("either schedule and duration, or start and end must be provided, in %s", path))
+			} else if !w.End.After(w.Start.Time) {
+				v.errors = append(v.errors, fmt.Errorf("end must be after start, in %s", path))
+			} else if w.End.Time.Before(time.Now()) {
+				v.warnings = append(v.warnings, fmt.Sprintf("maintenance window %s has already ended, in %s", w.Name, path))
+			}
+			if w.Duration != nil {
+				v.errors = append(v.errors, fmt.Errorf("duration can only be used with schedule, in %s", path))
+			}
+		}
+		for _, t := range w.Templates {
+			if t.IsOperationalAlert() {
+				v.errors = append(v.errors, fmt.Errorf("operational alert %s is not affected by maintenance windows, in %s.templates", t, path))
+			}
+		}
+	}
+}
+
 func (v *validator) validateVariantMode(template HealthRuleTemplate, variant *HealthRuleVariant, ruleIndex, variantIndex int) {
 	// Validate that variant mode (if specified) is not Recording for alert-only templates
-	// Note: AlertNoFlows and AlertLokiError are handled separately and not part of healthRules,
+	// Note: operational alerts such as AlertNoFlows and AlertLokiError are handled separately and not part of healthRules,
 	// but we keep this check for defensive programming in case that changes
 	if variant.Mode != nil && *variant.Mode == ModeRecording {
-		if template == AlertNoFlows || template == AlertLokiError {
+		if template.IsOperationalAlert() {
 			v.errors = append(
 				v.errors,
 				fmt.Errorf(
@@ -399,9 +659,10 @@ func (v *validator) isFLPHealthRuleGroupBySupported(template HealthRuleTemplate,
 		return variant.GroupBy != GroupByWorkload && variant.GroupBy != GroupByNamespace
 	case HealthRuleIngress5xxErrors, HealthRuleIngressHTTPLatencyTrend:
 		return variant.GroupBy != GroupByNode && variant.GroupBy != GroupByWorkload
-	case HealthRulePacketDropsByKernel, HealthRuleDNSErrors, HealthRuleDNSNxDomain, HealthRuleExternalEgressHighTrend, HealthRuleExternalIngressHighTrend, HealthRuleLatencyHighTrend, HealthRuleNetpolDenied:
+	case HealthRulePacketDropsByKernel, HealthRuleDNSErrors, HealthRuleDNSNxDomain, HealthRuleExternalEgressHighTrend, HealthRuleExternalIngressHighTrend, HealthRuleLatencyHighTrend, HealthRuleNetpolDenied,
+		HealthRuleTCPResetsHigh, HealthRuleSYNWithoutACK:
 		return true
-	case AlertLokiError, AlertNoFlows: // not applicable
+	case AlertLokiError, AlertNoFlows, AlertKafkaConsumerLag, AlertFLPMemoryHigh, AlertEncodeErrors, AlertExporterErrors: // not applicable
 		return false
 	}
 	return true
@@ -422,7 +683,8 @@ func (v *validator) validateFLPMetricsForAlerts() {
 					)
 				}
 			}
-			if len(reqMetrics2) > 0 {
+			// Ratios of the same metric, such as TCP flags, require it once
+			if len(reqMetrics2) > 0 && !slices.Equal(reqMetrics1, reqMetrics2) {
 				if GetFirstRequiredMetrics(reqMetrics2, metrics) == "" {
 					v.warnings = append(
 						v.warnings,
@@ -466,7 +728,13 @@ func GetElligibleMetricsForAlert(template HealthRuleTemplate, alertDef *HealthRu
 	case HealthRuleNetpolDenied:
 		metricPatterns = []string{`%s_network_policy_events_total`}
 		totalMetricPatterns = []string{"%s_flows_total"}
-	case AlertNoFlows, AlertLokiError, HealthRulePacketDropsByDevice, HealthRuleIngress5xxErrors, HealthRuleIngressHTTPLatencyTrend:
+	case HealthRuleTCPResetsHigh:
+		metricPatterns = []string{`%s_tcp_flags_total`}
+		totalMetricPatterns = []string{`%s_tcp_flags_total`}
+	case HealthRuleSYNWithoutACK:
+		metricPatterns = []string{`%s_tcp_flags_total`}
+		totalMetricPatterns = []string{`%s_tcp_flags_total`}
+	case AlertNoFlows, AlertLokiError, AlertKafkaConsumerLag, AlertFLPMemoryHigh, AlertEncodeErrors, AlertExporterErrors, HealthRulePacketDropsByDevice, HealthRuleIngress5xxErrors, HealthRuleIngressHTTPLatencyTrend:
 		// nothing - these rules don't use NetObserv metrics
 		return nil, nil
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/coreos/go-semver/semver"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,7 @@ import (
 type clusterInfoMock struct {
 	cni     NetworkType
 	version string
+	nodes   []NodeKernelInfo
 }
 
 func (m *clusterInfoMock) IsOpenShift() bool {
@@ -48,6 +50,10 @@ func (m *clusterInfoMock) GetCNI() (NetworkType, error) {
 	return m.cni, nil
 }
 
+func (m *clusterInfoMock) GetNodesKernelInfo() ([]NodeKernelInfo, error) {
+	return m.nodes, nil
+}
+
 func TestValidateAgent(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -486,6 +492,464 @@ func TestValidateAgent(t *testing.T) {
 	}
 }
 
+func TestValidateAgentKernel(t *testing.T) {
+	nodes := []NodeKernelInfo{
+		{Name: "rhcos-old", KernelVersion: "5.14.0-284.66.1.el9_2.x86_64", OSImage: "Red Hat Enterprise Linux CoreOS 413.92"},
+		{Name: "rhcos-new", KernelVersion: "5.14.0-570.12.1.el9_6.x86_64", OSImage: "Red Hat Enterprise Linux CoreOS 9.6"},
+		{Name: "ubuntu", KernelVersion: "6.8.0-1015-aws", OSImage: "Ubuntu 24.04 LTS", Labels: map[string]string{"pool": "ubuntu"}},
+		{Name: "debian", KernelVersion: "5.10.0-28-cloud-amd64", OSImage: "Debian GNU/Linux 11 (bullseye)"},
+		{Name: "unknown", KernelVersion: "custom"},
+	}
+	tests := []struct {
+		name             string
+		ebpf             FlowCollectorEBPF
+		ocpVersion       string
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name: "TCX not supported on some nodes",
+			expectedWarnings: admission.Warnings{
+				"The TCX attachment mode requires kernel 6.6.0 (or 5.14.0-427 on RHEL), which is not met on 2 node(s): debian, rhcos-old",
+			},
+		},
+		{
+			name: "TC attach mode is not checked",
+			ebpf: FlowCollectorEBPF{Advanced: &AdvancedAgentConfig{Env: map[string]string{"TC_ATTACH_MODE": "tc"}}},
+		},
+		{
+			name: "Any attach mode falls back on TC",
+			ebpf: FlowCollectorEBPF{Attachment: EBPFAttachment{Mode: AttachModeAny}},
+		},
+		{
+			name:       "TCX not used on older OpenShift",
+			ebpf:       FlowCollectorEBPF{Features: []AgentFeature{PacketTranslation}},
+			ocpVersion: "4.15.0",
+			expectedWarnings: admission.Warnings{
+				"The PacketTranslation feature requires kernel 5.18.0 (or 5.14.0-284 on RHEL), which is not met on 1 node(s): debian",
+			},
+		},
+		{
+			name: "Features are checked against nodes matching the node selector",
+			ebpf: FlowCollectorEBPF{
+				Features: []AgentFeature{NetworkEvents, IPSec, FlowRTT},
+				Advanced: &AdvancedAgentConfig{Scheduling: &SchedulingConfig{NodeSelector: map[string]string{"pool": "ubuntu"}}},
+			},
+			expectedWarnings: admission.Warnings{
+				"The NetworkEvents feature requires kernel 6.11.0 (or 5.14.0-570 on RHEL), which is not met on 1 node(s): ubuntu",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		CurrentClusterInfo = &clusterInfoMock{version: test.ocpVersion, nodes: nodes}
+		v := validator{fc: &FlowCollectorSpec{Agent: FlowCollectorAgent{EBPF: test.ebpf}}}
+		v.validateAgentKernel()
+		assert.Equal(t, test.expectedWarnings, v.warnings, test.name)
+	}
+}
+
+func TestValidateAgentAttachment(t *testing.T) {
+	tests := []struct {
+		name             string
+		ebpf             FlowCollectorEBPF
+		ocpVersion       string
+		expectedError    string
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name: "Valid attachment",
+			ebpf: FlowCollectorEBPF{
+				Attachment: EBPFAttachment{Mode: AttachModeTCX, TCXAnchorIngress: TCXAnchorHead, Direction: DirectionIngress},
+			},
+			ocpVersion: "4.18.0",
+		},
+		{
+			name: "TCX anchors with TC mode",
+			ebpf: FlowCollectorEBPF{
+				Attachment: EBPFAttachment{Mode: AttachModeTC, TCXAnchorEgress: TCXAnchorTail},
+			},
+			expectedError: "cannot be used with the TC attachment mode",
+		},
+		{
+			name: "TCX on old OpenShift",
+			ebpf: FlowCollectorEBPF{
+				Attachment: EBPFAttachment{Mode: AttachModeTCX},
+			},
+			ocpVersion:       "4.15.0",
+			expectedWarnings: admission.Warnings{"The TCX attachment mode requires OpenShift 4.16.0 or above (version detected: 4.15.0)"},
+		},
+		{
+			name: "Attachment with eBPF Manager",
+			ebpf: FlowCollectorEBPF{
+				Features:   []AgentFeature{EbpfManager},
+				Attachment: EBPFAttachment{Mode: AttachModeTC},
+			},
+			expectedWarnings: admission.Warnings{"The attachment mode and TCX anchors (in spec.agent.ebpf.attachment) are ignored when the EbpfManager feature is enabled"},
+		},
+		{
+			name: "Poll period without poll mode",
+			ebpf: FlowCollectorEBPF{
+				InterfaceDiscovery: EBPFInterfaceDiscovery{PollPeriod: "5s"},
+			},
+			expectedWarnings: admission.Warnings{"spec.agent.ebpf.interfaceDiscovery.pollPeriod is ignored when the interface discovery mode is not Poll"},
+		},
+		{
+			name: "Duplicate MAC prefix",
+			ebpf: FlowCollectorEBPF{
+				InterfaceDiscovery: EBPFInterfaceDiscovery{PreferredInterfaces: []EBPFPreferredInterface{
+					{MACPrefix: "0a:58", Interface: "eth0"},
+					{MACPrefix: "0A:58", Interface: "eth1"},
+				}},
+			},
+			expectedError: "MAC prefix 0A:58 is defined more than once",
+		},
+		{
+			name: "Overridden by env",
+			ebpf: FlowCollectorEBPF{
+				Attachment: EBPFAttachment{Direction: DirectionIngress},
+				Advanced:   &AdvancedAgentConfig{Env: map[string]string{"DIRECTION": "both", "TC_ATTACH_MODE": "tc"}},
+			},
+			expectedWarnings: admission.Warnings{"The environment variable DIRECTION in spec.agent.ebpf.advanced.env overrides the equivalent setting from spec.agent.ebpf"},
+		},
+	}
+
+	for _, test := range tests {
+		CurrentClusterInfo = &clusterInfoMock{version: test.ocpVersion}
+		v := validator{fc: &FlowCollectorSpec{Agent: FlowCollectorAgent{EBPF: test.ebpf}}}
+		v.validateAgentAttachment()
+		if test.expectedError == "" {
+			assert.Empty(t, v.errors, test.name)
+		} else {
+			assert.Len(t, v.errors, 1, test.name)
+			assert.ErrorContains(t, v.errors[0], test.expectedError, test.name)
+		}
+		assert.Equal(t, test.expectedWarnings, v.warnings, test.name)
+	}
+}
+
+func TestValidateFLPAgentsTLS(t *testing.T) {
+	secretRef := func(name string) CertificateReference {
+		return CertificateReference{Type: RefTypeSecret, Name: name, CertFile: "tls.crt", CertKey: "tls.key"}
+	}
+	tests := []struct {
+		name             string
+		spec             FlowCollectorSpec
+		expectedError    string
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name: "Mutual TLS with auto certificates",
+			spec: FlowCollectorSpec{Processor: FlowCollectorFLP{AgentsTLS: FLPAgentsTLS{Mode: FLPAgentsTLSMutual, Certificates: FLPAgentsCertificatesAuto}}},
+		},
+		{
+			name: "Mutual TLS with provided certificates",
+			spec: FlowCollectorSpec{Processor: FlowCollectorFLP{AgentsTLS: FLPAgentsTLS{
+				Mode:         FLPAgentsTLSMutual,
+				Certificates: FLPAgentsCertificatesProvided,
+				Provided:     &FLPAgentsProvidedCertificates{CACert: secretRef("ca"), ServerCert: secretRef("server"), ClientCert: secretRef("client")},
+			}}},
+		},
+		{
+			name: "Missing provided certificates",
+			spec: FlowCollectorSpec{Processor: FlowCollectorFLP{AgentsTLS: FLPAgentsTLS{
+				Mode:         FLPAgentsTLSMutual,
+				Certificates: FLPAgentsCertificatesProvided,
+				Provided:     &FLPAgentsProvidedCertificates{CACert: secretRef("ca"), ServerCert: secretRef("server")},
+			}}},
+			expectedError: "must reference the CA, server and client certificates",
+		},
+		{
+			name: "Provided certificates without Mutual mode",
+			spec: FlowCollectorSpec{Processor: FlowCollectorFLP{AgentsTLS: FLPAgentsTLS{
+				Certificates: FLPAgentsCertificatesProvided,
+			}}},
+			expectedWarnings: admission.Warnings{"The certificates configured in spec.processor.agentsTLS are ignored since mode is not Mutual"},
+		},
+		{
+			name: "Mutual TLS with Kafka",
+			spec: FlowCollectorSpec{
+				DeploymentModel: DeploymentModelKafka,
+				Processor:       FlowCollectorFLP{AgentsTLS: FLPAgentsTLS{Mode: FLPAgentsTLSMutual}},
+			},
+			expectedWarnings: admission.Warnings{"Mutual TLS configured in spec.processor.agentsTLS is ignored when Kafka is used; configure spec.kafka.tls instead"},
+		},
+		{
+			name: "Mutual TLS with SERVER_NOTLS",
+			spec: FlowCollectorSpec{Processor: FlowCollectorFLP{
+				AgentsTLS: FLPAgentsTLS{Mode: FLPAgentsTLSMutual},
+				Advanced:  &AdvancedProcessorConfig{Env: map[string]string{"SERVER_NOTLS": "true"}},
+			}},
+			expectedWarnings: admission.Warnings{"The SERVER_NOTLS environment variable in spec.processor.advanced.env is ignored when spec.processor.agentsTLS.mode is Mutual"},
+		},
+	}
+
+	for _, test := range tests {
+		v := validator{fc: &test.spec}
+		v.validateFLPAgentsTLS()
+		if test.expectedError == "" {
+			assert.Empty(t, v.errors, test.name)
+		} else {
+			assert.Len(t, v.errors, 1, test.name)
+			assert.ErrorContains(t, v.errors[0], test.expectedError, test.name)
+		}
+		assert.Equal(t, test.expectedWarnings, v.warnings, test.name)
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	ref := func(name string) *FileReference {
+		return &FileReference{Type: RefTypeSecret, Name: name, File: "value"}
+	}
+	tests := []struct {
+		name             string
+		spec             FlowCollectorSpec
+		expectedError    string
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name: "Loki basic auth with tenant header",
+			spec: FlowCollectorSpec{Loki: FlowCollectorLoki{Mode: LokiModeManual, Manual: LokiManualParams{Auth: HTTPClientAuth{
+				Type:     HTTPClientAuthBasic,
+				Username: ref("user"),
+				Password: ref("password"),
+				Headers:  []HTTPHeaderReference{{Name: "X-Scope-OrgID", ValueFrom: *ref("tenant")}},
+			}}}},
+			expectedWarnings: admission.Warnings{"The headers configured in spec.loki.manual.auth.headers are only used for querying Loki; flowlogs-pipeline only sends the tenant header from spec.loki.manual.tenantID"},
+		},
+		{
+			name: "Loki basic auth without password",
+			spec: FlowCollectorSpec{Loki: FlowCollectorLoki{Mode: LokiModeManual, Manual: LokiManualParams{Auth: HTTPClientAuth{
+				Type:     HTTPClientAuthBasic,
+				Username: ref("user"),
+			}}}},
+			expectedError: "spec.loki.manual.auth.username and spec.loki.manual.auth.password must be set",
+		},
+		{
+			name: "Loki auth with forwarded token",
+			spec: FlowCollectorSpec{Loki: FlowCollectorLoki{Mode: LokiModeManual, Manual: LokiManualParams{
+				AuthToken: LokiAuthForwardUserToken,
+				Auth:      HTTPClientAuth{Type: HTTPClientAuthBearer, Token: ref("token")},
+			}}},
+			expectedError: "spec.loki.manual.auth cannot be used together with spec.loki.manual.authToken",
+		},
+		{
+			name: "Loki auth ignored in LokiStack mode",
+			spec: FlowCollectorSpec{Loki: FlowCollectorLoki{Mode: LokiModeLokiStack, Manual: LokiManualParams{
+				Auth: HTTPClientAuth{Type: HTTPClientAuthBearer, Token: ref("token")},
+			}}},
+			expectedWarnings: admission.Warnings{"The credentials configured in spec.loki.manual.auth are ignored since spec.loki.mode is not Manual"},
+		},
+		{
+			name: "Prometheus bearer without token",
+			spec: FlowCollectorSpec{Prometheus: FlowCollectorPrometheus{Querier: PrometheusQuerier{Mode: PromModeManual, Manual: PrometheusQuerierManual{
+				Auth: HTTPClientAuth{Type: HTTPClientAuthBearer},
+			}}}},
+			expectedError: "spec.prometheus.querier.manual.auth.token must be set",
+		},
+		{
+			name: "Prometheus auth with forwarded token",
+			spec: FlowCollectorSpec{Prometheus: FlowCollectorPrometheus{Querier: PrometheusQuerier{Mode: PromModeManual, Manual: PrometheusQuerierManual{
+				ForwardUserToken: true,
+				Auth:             HTTPClientAuth{Type: HTTPClientAuthBearer, Token: ref("token")},
+			}}}},
+			expectedError: "cannot be used together with spec.prometheus.querier.manual.forwardUserToken",
+		},
+		{
+			name: "AlertManager Authorization header",
+			spec: FlowCollectorSpec{Prometheus: FlowCollectorPrometheus{Querier: PrometheusQuerier{Mode: PromModeManual, Manual: PrometheusQuerierManual{
+				AlertManager: &AlertManagerQuerierManual{Auth: HTTPClientAuth{Headers: []HTTPHeaderReference{{Name: "authorization", ValueFrom: *ref("token")}}}},
+			}}}},
+			expectedError: "spec.prometheus.querier.manual.alertManager.auth.headers cannot define the Authorization header",
+		},
+		{
+			name: "Prometheus auth ignored in Auto mode",
+			spec: FlowCollectorSpec{Prometheus: FlowCollectorPrometheus{Querier: PrometheusQuerier{Mode: PromModeAuto, Manual: PrometheusQuerierManual{
+				Auth:         HTTPClientAuth{Type: HTTPClientAuthBearer},
+				AlertManager: &AlertManagerQuerierManual{Auth: HTTPClientAuth{Headers: []HTTPHeaderReference{{Name: "authorization", ValueFrom: *ref("token")}}}},
+			}}}},
+			expectedWarnings: admission.Warnings{
+				"The credentials configured in spec.prometheus.querier.manual.auth are ignored since spec.prometheus.querier.mode is not Manual",
+				"The credentials configured in spec.prometheus.querier.manual.alertManager.auth are ignored since spec.prometheus.querier.mode is not Manual",
+			},
+		},
+		{
+			name: "Duplicate header",
+			spec: FlowCollectorSpec{Prometheus: FlowCollectorPrometheus{Querier: PrometheusQuerier{Mode: PromModeManual, Manual: PrometheusQuerierManual{
+				Auth: HTTPClientAuth{Headers: []HTTPHeaderReference{
+					{Name: "X-Scope-OrgID", ValueFrom: *ref("a")},
+					{Name: "x-scope-orgid", ValueFrom: *ref("b")},
+				}},
+			}}}},
+			expectedError: "header x-scope-orgid is defined more than once",
+		},
+	}
+
+	// On OpenShift, the manual Prometheus configuration is not used in Auto mode
+	CurrentClusterInfo = &clusterInfoMock{version: "4.20.0"}
+	for _, test := range tests {
+		v := validator{fc: &test.spec}
+		v.validateCredentials()
+		if test.expectedError == "" {
+			assert.Empty(t, v.errors, test.name)
+		} else {
+			assert.Len(t, v.errors, 1, test.name)
+			assert.ErrorContains(t, v.errors[0], test.expectedError, test.name)
+		}
+		assert.Equal(t, test.expectedWarnings, v.warnings, test.name)
+	}
+}
+
+func TestValidateKafkaProvisioning(t *testing.T) {
+	prov := &KafkaProvisioning{Enable: true, Cluster: "my-cluster", Namespace: "kafka"}
+	tests := []struct {
+		name             string
+		spec             FlowCollectorSpec
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name: "Provisioning with Kafka deployment model",
+			spec: FlowCollectorSpec{DeploymentModel: DeploymentModelKafka, Kafka: FlowCollectorKafka{Provisioning: prov}},
+		},
+		{
+			name:             "Provisioning without Kafka deployment model",
+			spec:             FlowCollectorSpec{DeploymentModel: DeploymentModelService, Kafka: FlowCollectorKafka{Provisioning: prov}},
+			expectedWarnings: admission.Warnings{"spec.kafka.provisioning is ignored since spec.deploymentModel is not Kafka"},
+		},
+		{
+			name:             "Provisioning overrides TLS",
+			spec:             FlowCollectorSpec{DeploymentModel: DeploymentModelKafka, Kafka: FlowCollectorKafka{Provisioning: prov, TLS: ClientTLS{Enable: true}}},
+			expectedWarnings: admission.Warnings{"spec.kafka.tls is overridden by the certificates of the provisioned KafkaUser"},
+		},
+		{
+			name:             "Provisioning on exporter",
+			spec:             FlowCollectorSpec{Exporters: []*FlowCollectorExporter{{Type: KafkaExporter, Kafka: FlowCollectorKafka{Provisioning: prov}}}},
+			expectedWarnings: admission.Warnings{"spec.exporters[0].kafka.provisioning is ignored: provisioning is only available for spec.kafka"},
+		},
+	}
+
+	for _, test := range tests {
+		v := validator{fc: &test.spec}
+		v.validateKafkaProvisioning()
+		assert.Empty(t, v.errors, test.name)
+		assert.Equal(t, test.expectedWarnings, v.warnings, test.name)
+	}
+}
+
+func TestValidateLokiStackProvisioning(t *testing.T) {
+	lokiStack := func(mode LokiMode, secret string) FlowCollectorLoki {
+		return FlowCollectorLoki{
+			Mode: mode,
+			LokiStack: LokiStackRef{
+				Name: "loki",
+				Provisioning: &LokiStackProvisioning{
+					Enable:  true,
+					Storage: LokiStackStorage{SecretName: secret, SecretType: LokiStackStorageS3},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name             string
+		spec             FlowCollectorSpec
+		ocpVersion       string
+		expectedError    string
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name:       "Provisioning in LokiStack mode",
+			spec:       FlowCollectorSpec{Loki: lokiStack(LokiModeLokiStack, "loki-s3")},
+			ocpVersion: "4.18.0",
+		},
+		{
+			name:             "Provisioning outside of OpenShift",
+			spec:             FlowCollectorSpec{Loki: lokiStack(LokiModeLokiStack, "loki-s3")},
+			expectedWarnings: admission.Warnings{"The provisioned LokiStack uses the openshift-network tenant mode, which is only supported on OpenShift"},
+		},
+		{
+			name:          "Provisioning without storage secret",
+			spec:          FlowCollectorSpec{Loki: lokiStack(LokiModeLokiStack, "")},
+			ocpVersion:    "4.18.0",
+			expectedError: "spec.loki.lokiStack.provisioning.storage.secretName is required to provision a LokiStack",
+		},
+		{
+			name:             "Provisioning in Monolithic mode",
+			spec:             FlowCollectorSpec{Loki: lokiStack(LokiModeMonolithic, "")},
+			expectedWarnings: admission.Warnings{"spec.loki.lokiStack.provisioning is ignored since Loki is not enabled in LokiStack mode"},
+		},
+	}
+
+	for _, test := range tests {
+		CurrentClusterInfo = &clusterInfoMock{version: test.ocpVersion}
+		v := validator{fc: &test.spec}
+		v.validateLokiStackProvisioning()
+		if test.expectedError == "" {
+			assert.Empty(t, v.errors, test.name)
+		} else {
+			assert.Len(t, v.errors, 1, test.name)
+			assert.ErrorContains(t, v.errors[0], test.expectedError, test.name)
+		}
+		assert.Equal(t, test.expectedWarnings, v.warnings, test.name)
+	}
+}
+
+func TestValidateExporters(t *testing.T) {
+	tests := []struct {
+		name             string
+		exporter         FlowCollectorExporter
+		expectedError    string
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name:     "Kafka with ECS",
+			exporter: FlowCollectorExporter{Type: KafkaExporter, Schema: ExporterSchemaECS},
+		},
+		{
+			name: "Kafka with schema and fields mapping",
+			exporter: FlowCollectorExporter{
+				Type:   KafkaExporter,
+				Schema: ExporterSchemaECS,
+				Kafka:  FlowCollectorKafka{FieldsMapping: &[]GenericTransformRule{{Input: "SrcAddr", Output: "src"}}},
+			},
+		},
+		{
+			name:          "IPFIX with schema",
+			exporter:      FlowCollectorExporter{Type: IpfixExporter, Schema: ExporterSchemaOCSF},
+			expectedError: "spec.exporters[0].schema cannot be used with the IPFIX exporter",
+		},
+		{
+			name: "OpenTelemetry with schema and fields mapping",
+			exporter: FlowCollectorExporter{
+				Type:          OpenTelemetryExporter,
+				Schema:        ExporterSchemaOCSF,
+				OpenTelemetry: FlowCollectorOpenTelemetry{FieldsMapping: &[]GenericTransformRule{{Input: "SrcAddr", Output: "src"}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		v := validator{fc: &FlowCollectorSpec{Exporters: []*FlowCollectorExporter{&test.exporter}}}
+		v.validateExporters()
+		if test.expectedError == "" {
+			assert.Empty(t, v.errors, test.name)
+		} else {
+			assert.Len(t, v.errors, 1, test.name)
+			assert.ErrorContains(t, v.errors[0], test.expectedError, test.name)
+		}
+		assert.Equal(t, test.expectedWarnings, v.warnings, test.name)
+	}
+
+	// fieldsMapping in spec.kafka, rather than in an exporter, is ignored
+	v := validator{fc: &FlowCollectorSpec{Kafka: FlowCollectorKafka{FieldsMapping: &[]GenericTransformRule{{Input: "SrcAddr", Output: "src"}}}}}
+	v.validateExporters()
+	assert.Empty(t, v.errors)
+	assert.Equal(t, admission.Warnings{"spec.kafka.fieldsMapping is ignored: it only applies to Kafka exporters, in spec.exporters"}, v.warnings)
+}
+
+func TestNodesSummary(t *testing.T) {
+	incompat := KernelIncompatibility{Nodes: []string{"a", "b", "c", "d", "e", "f", "g"}}
+	assert.Equal(t, "a, b, c, d, e and 2 more", incompat.NodesSummary())
+}
+
 func TestValidateConntrack(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -701,6 +1165,36 @@ func TestValidateFLP(t *testing.T) {
 				"HealthRule PacketDropsByKernel/Node requires enabling at least one metric from this list: node_ingress_packets_total, node_egress_packets_total",
 			},
 		},
+		{
+			name:       "Missing metrics for TCP resets",
+			ocpVersion: "4.18.0",
+			fc: &FlowCollector{
+				Spec: FlowCollectorSpec{
+					Processor: FlowCollectorFLP{
+						Metrics: FLPMetrics{
+							HealthRules: &[]FLPHealthRule{
+								{
+									Template: HealthRuleTCPResetsHigh,
+									Variants: []HealthRuleVariant{
+										{
+											GroupBy: GroupByWorkload,
+											Thresholds: HealthRuleThresholds{
+												Warning: "10",
+											},
+										},
+									},
+								},
+							},
+							DisableAlerts: []AlertTemplate{HealthRuleExternalEgressHighTrend, HealthRuleExternalIngressHighTrend},
+							IncludeList:   &[]FLPMetric{"workload_flows_total"},
+						},
+					},
+				},
+			},
+			expectedWarnings: admission.Warnings{
+				"HealthRule TCPResetsHigh/Workload requires enabling at least one metric from this list: workload_tcp_flags_total",
+			},
+		},
 		{
 			name:       "Invalid alert threshold",
 			ocpVersion: "4.18.0",
@@ -1066,6 +1560,82 @@ func TestValidateScheduling(t *testing.T) {
 	}
 }
 
+func TestValidateFLPMaintenanceWindows(t *testing.T) {
+	start := metav1.NewTime(time.Date(2030, 11, 7, 22, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(4 * time.Hour))
+	past := metav1.NewTime(time.Date(2020, 11, 7, 22, 0, 0, 0, time.UTC))
+	pastEnd := metav1.NewTime(past.Add(time.Hour))
+	tests := []struct {
+		name             string
+		windows          []MaintenanceWindow
+		expectedError    string
+		expectedWarnings admission.Warnings
+	}{
+		{
+			name: "Valid windows",
+			windows: []MaintenanceWindow{
+				{Name: "weekly", Schedule: "0 2 * * 6", Duration: &metav1.Duration{Duration: 2 * time.Hour}},
+				{Name: "upgrade", Start: &start, End: &end, Namespaces: []string{"app"}, Templates: []HealthRuleTemplate{HealthRuleDNSErrors}},
+			},
+		},
+		{
+			name: "Duplicated name",
+			windows: []MaintenanceWindow{
+				{Name: "upgrade", Start: &start, End: &end},
+				{Name: "upgrade", Schedule: "0 2 * * 6", Duration: &metav1.Duration{Duration: time.Hour}},
+			},
+			expectedError: "maintenance window name upgrade is used more than once, in spec.processor.metrics.maintenanceWindows[1]",
+		},
+		{
+			name:          "Invalid cron",
+			windows:       []MaintenanceWindow{{Name: "weekly", Schedule: "0 25 * * 6", Duration: &metav1.Duration{Duration: time.Hour}}},
+			expectedError: `end of range (25) above maximum (23): 25, in spec.processor.metrics.maintenanceWindows[0].schedule`,
+		},
+		{
+			name:          "Missing duration",
+			windows:       []MaintenanceWindow{{Name: "weekly", Schedule: "0 2 * * 6"}},
+			expectedError: "a positive duration is required with schedule",
+		},
+		{
+			name:          "Schedule with start",
+			windows:       []MaintenanceWindow{{Name: "weekly", Schedule: "0 2 * * 6", Duration: &metav1.Duration{Duration: time.Hour}, Start: &start, End: &end}},
+			expectedError: "schedule cannot be used together with start and end",
+		},
+		{
+			name:          "Missing end",
+			windows:       []MaintenanceWindow{{Name: "upgrade", Start: &start}},
+			expectedError: "either schedule and duration, or start and end must be provided",
+		},
+		{
+			name:          "End before start",
+			windows:       []MaintenanceWindow{{Name: "upgrade", Start: &end, End: &start}},
+			expectedError: "end must be after start",
+		},
+		{
+			name:          "Operational alert",
+			windows:       []MaintenanceWindow{{Name: "upgrade", Start: &start, End: &end, Templates: []HealthRuleTemplate{AlertNoFlows}}},
+			expectedError: "operational alert NetObservNoFlows is not affected by maintenance windows",
+		},
+		{
+			name:             "Ended window",
+			windows:          []MaintenanceWindow{{Name: "upgrade", Start: &past, End: &pastEnd}},
+			expectedWarnings: admission.Warnings{"maintenance window upgrade has already ended, in spec.processor.metrics.maintenanceWindows[0]"},
+		},
+	}
+
+	for _, test := range tests {
+		v := validator{fc: &FlowCollectorSpec{Processor: FlowCollectorFLP{Metrics: FLPMetrics{MaintenanceWindows: test.windows}}}}
+		v.validateFLPMaintenanceWindows()
+		if test.expectedError == "" {
+			assert.Empty(t, v.errors, test.name)
+		} else {
+			assert.Len(t, v.errors, 1, test.name)
+			assert.ErrorContains(t, v.errors[0], test.expectedError, test.name)
+		}
+		assert.Equal(t, test.expectedWarnings, v.warnings, test.name)
+	}
+}
+
 func TestElligibleMetrics(t *testing.T) {
 	met, tot := GetElligibleMetricsForHealthRule(HealthRulePacketDropsByKernel, &HealthRuleVariant{
 		GroupBy: GroupByNamespace,
@@ -24,6 +24,11 @@ func (spec *FlowCollectorSpec) UseKafka() bool {
 	return spec.DeploymentModel == DeploymentModelKafka
 }
 
+// UseKafkaProvisioning returns true when the Kafka topic and user used by the Kafka deployment model are managed with Strimzi
+func (spec *FlowCollectorSpec) UseKafkaProvisioning() bool {
+	return spec.UseKafka() && spec.Kafka.Provisioning != nil && spec.Kafka.Provisioning.Enable
+}
+
 func (spec *FlowCollectorSpec) HasKafkaExporter() bool {
 	for _, ex := range spec.Exporters {
 		if ex.Type == KafkaExporter {
@@ -33,10 +38,49 @@ func (spec *FlowCollectorSpec) HasKafkaExporter() bool {
 	return false
 }
 
+// GetSchema returns the schema of exported flows, defaulting to the native format of the exporter type
+func (exp *FlowCollectorExporter) GetSchema() ExporterSchema {
+	if exp.Schema != "" {
+		return exp.Schema
+	}
+	if exp.Type == OpenTelemetryExporter {
+		return ExporterSchemaOTel
+	}
+	return ExporterSchemaNetObserv
+}
+
 func (cfg *SASLConfig) UseSASL() bool {
 	return cfg.Type == SASLPlain || cfg.Type == SASLScramSHA512
 }
 
+// UseCredentials returns true when credentials are sent in the Authorization header
+func (a *HTTPClientAuth) UseCredentials() bool {
+	return a.Type == HTTPClientAuthBasic || a.Type == HTTPClientAuthBearer
+}
+
+// References returns the file references used for the configured authentication type and headers
+func (a *HTTPClientAuth) References() []FileReference {
+	var refs []FileReference
+	switch a.Type {
+	case HTTPClientAuthBasic:
+		if a.Username != nil {
+			refs = append(refs, *a.Username)
+		}
+		if a.Password != nil {
+			refs = append(refs, *a.Password)
+		}
+	case HTTPClientAuthBearer:
+		if a.Token != nil {
+			refs = append(refs, *a.Token)
+		}
+	case HTTPClientAuthNone:
+	}
+	for i := range a.Headers {
+		refs = append(refs, a.Headers[i].ValueFrom)
+	}
+	return refs
+}
+
 func (spec *FlowCollectorSpec) UseLoki() bool {
 	// nil should fallback to default value, which is "true"
 	return spec.Loki.Enable == nil || *spec.Loki.Enable
@@ -46,11 +90,54 @@ func (spec *FlowCollectorSpec) UseLokiDev() bool {
 	return spec.UseLoki() && spec.Loki.Mode == LokiModeMonolithic && spec.Loki.Monolithic.InstallDemoLoki != nil && *spec.Loki.Monolithic.InstallDemoLoki
 }
 
+func (spec *FlowCollectorSpec) UseLokiStackProvisioning() bool {
+	return spec.UseLoki() && spec.Loki.Mode == LokiModeLokiStack && spec.Loki.LokiStack.Provisioning != nil && spec.Loki.LokiStack.Provisioning.Enable
+}
+
 func (spec *FlowCollectorSpec) UsePrometheus() bool {
 	// nil should fallback to default value, which is "true"
 	return spec.Prometheus.Querier.Enable == nil || *spec.Prometheus.Querier.Enable
 }
 
+// GetGrafanaDashboards returns the Grafana dashboards configuration, with defaults applied
+func (spec *FlowCollectorSpec) GetGrafanaDashboards() GrafanaDashboards {
+	cfg := GrafanaDashboards{}
+	if spec.Processor.Metrics.Dashboards != nil {
+		cfg = spec.Processor.Metrics.Dashboards.Grafana
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = GrafanaDashboardsAuto
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = spec.GetNamespace()
+	}
+	if cfg.Datasource == "" {
+		cfg.Datasource = "prometheus"
+	}
+	if cfg.Folder == "" {
+		cfg.Folder = "NetObserv"
+	}
+	if len(cfg.InstanceSelector) == 0 {
+		cfg.InstanceSelector = map[string]string{"dashboards": "grafana"}
+	}
+	if cfg.SidecarLabel == "" {
+		cfg.SidecarLabel = "grafana_dashboard"
+	}
+	return cfg
+}
+
+// UseDashboardsRecordingRules returns true when the dashboards must query series recorded by the operator-generated recording rules
+func (spec *FlowCollectorSpec) UseDashboardsRecordingRules() bool {
+	d := spec.Processor.Metrics.Dashboards
+	return d != nil && d.RecordingRules.Enable != nil && *d.RecordingRules.Enable
+}
+
+// UseAlertManagerSilences returns true when maintenance windows must be applied as Alertmanager silences, rather than inhibited in the generated rules
+func (spec *FlowCollectorSpec) UseAlertManagerSilences() bool {
+	q := &spec.Prometheus.Querier
+	return q.Mode == PromModeManual && q.Manual.AlertManager != nil && q.Manual.AlertManager.URL != ""
+}
+
 func (spec *FlowCollectorSpec) UseConsolePlugin() bool {
 	return (spec.UseLoki() || spec.UsePrometheus()) &&
 		// nil should fallback to default value, which is "true"
@@ -61,6 +148,16 @@ func (spec *FlowCollectorSpec) UseHostNetwork() bool {
 	return spec.DeploymentModel == DeploymentModelDirect
 }
 
+// UseAgentsMutualTLS returns true when the eBPF agents and the flow processor must use mutual TLS.
+func (spec *FlowCollectorSpec) UseAgentsMutualTLS() bool {
+	return !spec.UseKafka() && spec.Processor.AgentsTLS.Mode == FLPAgentsTLSMutual
+}
+
+// UseProvidedAgentsCertificates returns true when mutual TLS between eBPF agents and the flow processor uses user-provided certificates.
+func (spec *FlowCollectorSpec) UseProvidedAgentsCertificates() bool {
+	return spec.UseAgentsMutualTLS() && spec.Processor.AgentsTLS.Certificates == FLPAgentsCertificatesProvided
+}
+
 func (spec *FlowCollectorEBPF) IsAgentFeatureEnabled(feature AgentFeature) bool {
 	for _, f := range spec.Features {
 		if f == feature {
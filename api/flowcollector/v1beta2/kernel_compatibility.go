@@ -0,0 +1,235 @@
+package v1beta2
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TCXAttach is a pseudo-feature used in kernel compatibility checks for the TCX hook attachment mode,
+// which is used by default by the eBPF agent, unless configured otherwise in `spec.agent.ebpf.attachment.mode`.
+const TCXAttach AgentFeature = "TCXAttach"
+
+// NodeKernelInfo holds the kernel-related information of a node, as reported in `Node.Status.NodeInfo`.
+// +kubebuilder:object:generate=false
+type NodeKernelInfo struct {
+	Name          string
+	KernelVersion string
+	OSImage       string
+	Labels        map[string]string
+}
+
+// KernelIncompatibility lists the nodes where an agent feature cannot run due to their kernel version.
+// +kubebuilder:object:generate=false
+type KernelIncompatibility struct {
+	Feature     AgentFeature
+	Requirement string
+	Nodes       []string
+}
+
+// kernelRequirement defines the minimal kernel versions for a feature: `upstream` applies to vanilla kernels,
+// whereas `rhel` applies to RHEL-based kernels (such as used in RHCOS), which have many backports from newer kernels.
+type kernelRequirement struct {
+	upstream string
+	rhel     string
+}
+
+var (
+	kernelRequirements = map[AgentFeature]kernelRequirement{
+		NetworkEvents:     {upstream: "6.11.0", rhel: "5.14.0-570"},
+		PacketTranslation: {upstream: "5.18.0", rhel: "5.14.0-284"},
+		IPSec:             {upstream: "6.1.0", rhel: "5.14.0-427"},
+		TCXAttach:         {upstream: "6.6.0", rhel: "5.14.0-427"},
+	}
+	rhelKernelRegexp  = regexp.MustCompile(`\.el\d+`)
+	rhelOSImageRegexp = regexp.MustCompile(`(?i)(red hat|centos stream|rocky linux|almalinux)`)
+)
+
+func (r *kernelRequirement) String() string {
+	return fmt.Sprintf("%s (or %s on RHEL)", r.upstream, r.rhel)
+}
+
+// kernelVersion is a parsed kernel version, such as `5.14.0-427.13.1.el9_4.x86_64`, where the release part (after the dash)
+// is only relevant for RHEL kernels.
+type kernelVersion struct {
+	version []int
+	release []int
+	rhel    bool
+}
+
+func parseKernelVersion(s string) (*kernelVersion, error) {
+	kv := kernelVersion{rhel: rhelKernelRegexp.MatchString(s)}
+	version, release, _ := strings.Cut(s, "-")
+	for _, part := range strings.SplitN(version, ".", 3) {
+		n, err := strconv.Atoi(leadingDigits(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid kernel version '%s'", s)
+		}
+		kv.version = append(kv.version, n)
+	}
+	for _, part := range strings.Split(release, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			// stop at the first non-numeric part, such as "el9_4"
+			break
+		}
+		kv.release = append(kv.release, n)
+	}
+	return &kv, nil
+}
+
+func leadingDigits(s string) string {
+	for i, c := range s {
+		if c < '0' || c > '9' {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func compareInts(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
+
+// isAtLeast returns true when the kernel version is greater than or equal to `min`, provided with the same format.
+func (kv *kernelVersion) isAtLeast(minVersion string) bool {
+	other, err := parseKernelVersion(minVersion)
+	if err != nil {
+		return false
+	}
+	if cmp := compareInts(kv.version, other.version); cmp != 0 || !kv.rhel {
+		return cmp >= 0
+	}
+	return compareInts(kv.release, other.release) >= 0
+}
+
+// IsKernelCompatible returns whether the given feature can run on a node, based on its kernel version and OS image.
+// When the version cannot be parsed, or when the feature has no known requirement, it is considered compatible.
+func (n *NodeKernelInfo) IsKernelCompatible(feature AgentFeature) bool {
+	req, ok := kernelRequirements[feature]
+	if !ok {
+		return true
+	}
+	kv, err := parseKernelVersion(n.KernelVersion)
+	if err != nil {
+		return true
+	}
+	if kv.rhel || rhelOSImageRegexp.MatchString(n.OSImage) {
+		kv.rhel = true
+		return kv.isAtLeast(req.rhel)
+	}
+	return kv.isAtLeast(req.upstream)
+}
+
+// UsesTCX returns true when the agent is expected to attach its hooks with TCX.
+func (spec *FlowCollectorEBPF) UsesTCX() bool {
+	if spec.IsEbpfManagerEnabled() {
+		// attachment is then delegated to eBPF Manager
+		return false
+	}
+	if spec.Advanced != nil {
+		if mode, ok := spec.Advanced.Env["TC_ATTACH_MODE"]; ok {
+			return mode == "tcx"
+		}
+	}
+	if spec.Attachment.Mode != "" {
+		// "Any" falls back on TC when TCX is not available
+		return spec.Attachment.Mode == AttachModeTCX
+	}
+	// default mode is "tcx", except on OpenShift < 4.16 where "tc" is used
+	if CurrentClusterInfo != nil && CurrentClusterInfo.IsOpenShift() {
+		ok, _, err := CurrentClusterInfo.IsOpenShiftVersionAtLeast("4.16.0")
+		return err == nil && ok
+	}
+	return true
+}
+
+// GetKernelDependentFeatures returns the enabled features (including TCX attachment) that have a kernel version requirement.
+func (spec *FlowCollectorEBPF) GetKernelDependentFeatures() []AgentFeature {
+	var features []AgentFeature
+	for _, feat := range spec.Features {
+		if _, ok := kernelRequirements[feat]; ok && !slices.Contains(features, feat) {
+			features = append(features, feat)
+		}
+	}
+	if spec.UsesTCX() {
+		features = append(features, TCXAttach)
+	}
+	slices.Sort(features)
+	return features
+}
+
+// GetKernelIncompatibilities checks the enabled features against the kernel version of the nodes where the agent is scheduled,
+// and returns the features that cannot run on some of them, along with the node names.
+func (spec *FlowCollectorEBPF) GetKernelIncompatibilities(nodes []NodeKernelInfo) []KernelIncompatibility {
+	features := spec.GetKernelDependentFeatures()
+	if len(features) == 0 {
+		return nil
+	}
+	var nodeSelector map[string]string
+	if spec.Advanced != nil && spec.Advanced.Scheduling != nil {
+		nodeSelector = spec.Advanced.Scheduling.NodeSelector
+	}
+	var result []KernelIncompatibility
+	for _, feat := range features {
+		var incompatible []string
+		for i := range nodes {
+			if !matchesNodeSelector(nodeSelector, nodes[i].Labels) {
+				continue
+			}
+			if !nodes[i].IsKernelCompatible(feat) {
+				incompatible = append(incompatible, nodes[i].Name)
+			}
+		}
+		if len(incompatible) > 0 {
+			req := kernelRequirements[feat]
+			slices.Sort(incompatible)
+			result = append(result, KernelIncompatibility{
+				Feature:     feat,
+				Requirement: req.String(),
+				Nodes:       incompatible,
+			})
+		}
+	}
+	return result
+}
+
+func matchesNodeSelector(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// FeatureName returns a printable name for the feature.
+func (i *KernelIncompatibility) FeatureName() string {
+	if i.Feature == TCXAttach {
+		return "TCX attachment mode"
+	}
+	return fmt.Sprintf("%s feature", i.Feature)
+}
+
+// NodesSummary returns a printable list of the incompatible nodes, truncated when too long.
+func (i *KernelIncompatibility) NodesSummary() string {
+	const maxNodes = 5
+	if len(i.Nodes) <= maxNodes {
+		return strings.Join(i.Nodes, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(i.Nodes[:maxNodes], ", "), len(i.Nodes)-maxNodes)
+}
@@ -221,6 +221,7 @@ func (in *AdvancedProcessorConfig) DeepCopy() *AdvancedProcessorConfig {
 func (in *AlertManagerQuerierManual) DeepCopyInto(out *AlertManagerQuerierManual) {
 	*out = *in
 	out.TLS = in.TLS
+	in.Auth.DeepCopyInto(&out.Auth)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertManagerQuerierManual.
@@ -307,6 +308,41 @@ func (in *ConsolePluginPortConfig) DeepCopy() *ConsolePluginPortConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardsRecordingRules) DeepCopyInto(out *DashboardsRecordingRules) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardsRecordingRules.
+func (in *DashboardsRecordingRules) DeepCopy() *DashboardsRecordingRules {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardsRecordingRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EBPFAttachment) DeepCopyInto(out *EBPFAttachment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EBPFAttachment.
+func (in *EBPFAttachment) DeepCopy() *EBPFAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(EBPFAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EBPFFlowFilter) DeepCopyInto(out *EBPFFlowFilter) {
 	*out = *in
@@ -373,6 +409,26 @@ func (in *EBPFFlowFilterRule) DeepCopy() *EBPFFlowFilterRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EBPFInterfaceDiscovery) DeepCopyInto(out *EBPFInterfaceDiscovery) {
+	*out = *in
+	if in.PreferredInterfaces != nil {
+		in, out := &in.PreferredInterfaces, &out.PreferredInterfaces
+		*out = make([]EBPFPreferredInterface, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EBPFInterfaceDiscovery.
+func (in *EBPFInterfaceDiscovery) DeepCopy() *EBPFInterfaceDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(EBPFInterfaceDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EBPFMetrics) DeepCopyInto(out *EBPFMetrics) {
 	*out = *in
@@ -399,6 +455,76 @@ func (in *EBPFMetrics) DeepCopy() *EBPFMetrics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EBPFPreferredInterface) DeepCopyInto(out *EBPFPreferredInterface) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EBPFPreferredInterface.
+func (in *EBPFPreferredInterface) DeepCopy() *EBPFPreferredInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(EBPFPreferredInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FLPAgentsProvidedCertificates) DeepCopyInto(out *FLPAgentsProvidedCertificates) {
+	*out = *in
+	out.CACert = in.CACert
+	out.ServerCert = in.ServerCert
+	out.ClientCert = in.ClientCert
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FLPAgentsProvidedCertificates.
+func (in *FLPAgentsProvidedCertificates) DeepCopy() *FLPAgentsProvidedCertificates {
+	if in == nil {
+		return nil
+	}
+	out := new(FLPAgentsProvidedCertificates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FLPAgentsTLS) DeepCopyInto(out *FLPAgentsTLS) {
+	*out = *in
+	if in.Provided != nil {
+		in, out := &in.Provided, &out.Provided
+		*out = new(FLPAgentsProvidedCertificates)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FLPAgentsTLS.
+func (in *FLPAgentsTLS) DeepCopy() *FLPAgentsTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(FLPAgentsTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FLPDashboards) DeepCopyInto(out *FLPDashboards) {
+	*out = *in
+	in.Grafana.DeepCopyInto(&out.Grafana)
+	in.RecordingRules.DeepCopyInto(&out.RecordingRules)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FLPDashboards.
+func (in *FLPDashboards) DeepCopy() *FLPDashboards {
+	if in == nil {
+		return nil
+	}
+	out := new(FLPDashboards)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FLPDeduper) DeepCopyInto(out *FLPDeduper) {
 	*out = *in
@@ -480,6 +606,18 @@ func (in *FLPMetrics) DeepCopyInto(out *FLPMetrics) {
 			}
 		}
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Dashboards != nil {
+		in, out := &in.Dashboards, &out.Dashboards
+		*out = new(FLPDashboards)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FLPMetrics.
@@ -551,6 +689,49 @@ func (in *FlowCollectorAgent) DeepCopy() *FlowCollectorAgent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowCollectorAgentNodeStatus) DeepCopyInto(out *FlowCollectorAgentNodeStatus) {
+	*out = *in
+	if in.Issues != nil {
+		in, out := &in.Issues, &out.Issues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowCollectorAgentNodeStatus.
+func (in *FlowCollectorAgentNodeStatus) DeepCopy() *FlowCollectorAgentNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowCollectorAgentNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowCollectorAgentStatus) DeepCopyInto(out *FlowCollectorAgentStatus) {
+	*out = *in
+	if in.NodesWithIssues != nil {
+		in, out := &in.NodesWithIssues, &out.NodesWithIssues
+		*out = make([]FlowCollectorAgentNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowCollectorAgentStatus.
+func (in *FlowCollectorAgentStatus) DeepCopy() *FlowCollectorAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowCollectorAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlowCollectorConsolePlugin) DeepCopyInto(out *FlowCollectorConsolePlugin) {
 	*out = *in
@@ -626,6 +807,8 @@ func (in *FlowCollectorEBPF) DeepCopyInto(out *FlowCollectorEBPF) {
 		*out = new(EBPFFlowFilter)
 		(*in).DeepCopyInto(*out)
 	}
+	out.Attachment = in.Attachment
+	in.InterfaceDiscovery.DeepCopyInto(&out.InterfaceDiscovery)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowCollectorEBPF.
@@ -641,7 +824,7 @@ func (in *FlowCollectorEBPF) DeepCopy() *FlowCollectorEBPF {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlowCollectorExporter) DeepCopyInto(out *FlowCollectorExporter) {
 	*out = *in
-	out.Kafka = in.Kafka
+	in.Kafka.DeepCopyInto(&out.Kafka)
 	out.IPFIX = in.IPFIX
 	in.OpenTelemetry.DeepCopyInto(&out.OpenTelemetry)
 }
@@ -703,6 +886,7 @@ func (in *FlowCollectorFLP) DeepCopyInto(out *FlowCollectorFLP) {
 		*out = new(SlicesConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	in.AgentsTLS.DeepCopyInto(&out.AgentsTLS)
 	if in.Advanced != nil {
 		in, out := &in.Advanced, &out.Advanced
 		*out = new(AdvancedProcessorConfig)
@@ -784,6 +968,20 @@ func (in *FlowCollectorKafka) DeepCopyInto(out *FlowCollectorKafka) {
 	*out = *in
 	out.TLS = in.TLS
 	out.SASL = in.SASL
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(KafkaProvisioning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FieldsMapping != nil {
+		in, out := &in.FieldsMapping, &out.FieldsMapping
+		*out = new([]GenericTransformRule)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]GenericTransformRule, len(*in))
+			copy(*out, *in)
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowCollectorKafka.
@@ -836,10 +1034,10 @@ func (in *FlowCollectorLoki) DeepCopyInto(out *FlowCollectorLoki) {
 		*out = new(bool)
 		**out = **in
 	}
-	out.Manual = in.Manual
+	in.Manual.DeepCopyInto(&out.Manual)
 	out.Microservices = in.Microservices
 	in.Monolithic.DeepCopyInto(&out.Monolithic)
-	out.LokiStack = in.LokiStack
+	in.LokiStack.DeepCopyInto(&out.LokiStack)
 	if in.ReadTimeout != nil {
 		in, out := &in.ReadTimeout, &out.ReadTimeout
 		*out = new(v1.Duration)
@@ -975,7 +1173,7 @@ func (in *FlowCollectorSpec) DeepCopyInto(out *FlowCollectorSpec) {
 	in.Loki.DeepCopyInto(&out.Loki)
 	in.Prometheus.DeepCopyInto(&out.Prometheus)
 	in.ConsolePlugin.DeepCopyInto(&out.ConsolePlugin)
-	out.Kafka = in.Kafka
+	in.Kafka.DeepCopyInto(&out.Kafka)
 	if in.Exporters != nil {
 		in, out := &in.Exporters, &out.Exporters
 		*out = make([]*FlowCollectorExporter, len(*in))
@@ -1010,6 +1208,11 @@ func (in *FlowCollectorStatus) DeepCopyInto(out *FlowCollectorStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(FlowCollectorAgentStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowCollectorStatus.
@@ -1056,6 +1259,79 @@ func (in *GenericTransformRule) DeepCopy() *GenericTransformRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaDashboards) DeepCopyInto(out *GrafanaDashboards) {
+	*out = *in
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboards.
+func (in *GrafanaDashboards) DeepCopy() *GrafanaDashboards {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboards)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPClientAuth) DeepCopyInto(out *HTTPClientAuth) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(FileReference)
+		**out = **in
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(FileReference)
+		**out = **in
+	}
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(FileReference)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeaderReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPClientAuth.
+func (in *HTTPClientAuth) DeepCopy() *HTTPClientAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPClientAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeaderReference) DeepCopyInto(out *HTTPHeaderReference) {
+	*out = *in
+	out.ValueFrom = in.ValueFrom
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderReference.
+func (in *HTTPHeaderReference) DeepCopy() *HTTPHeaderReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeaderReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthRuleThresholds) DeepCopyInto(out *HealthRuleThresholds) {
 	*out = *in
@@ -1102,9 +1378,40 @@ func (in *HealthRuleVariant) DeepCopy() *HealthRuleVariant {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaProvisioning) DeepCopyInto(out *KafkaProvisioning) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaProvisioning.
+func (in *KafkaProvisioning) DeepCopy() *KafkaProvisioning {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaProvisioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LokiManualParams) DeepCopyInto(out *LokiManualParams) {
 	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
 	out.TLS = in.TLS
 	out.StatusTLS = in.StatusTLS
 }
@@ -1156,9 +1463,35 @@ func (in *LokiMonolithParams) DeepCopy() *LokiMonolithParams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LokiStackProvisioning) DeepCopyInto(out *LokiStackProvisioning) {
+	*out = *in
+	out.Storage = in.Storage
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(LokiStackRetention)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiStackProvisioning.
+func (in *LokiStackProvisioning) DeepCopy() *LokiStackProvisioning {
+	if in == nil {
+		return nil
+	}
+	out := new(LokiStackProvisioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LokiStackRef) DeepCopyInto(out *LokiStackRef) {
 	*out = *in
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(LokiStackProvisioning)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiStackRef.
@@ -1171,6 +1504,94 @@ func (in *LokiStackRef) DeepCopy() *LokiStackRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LokiStackRetention) DeepCopyInto(out *LokiStackRetention) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Flows != nil {
+		in, out := &in.Flows, &out.Flows
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Conversations != nil {
+		in, out := &in.Conversations, &out.Conversations
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiStackRetention.
+func (in *LokiStackRetention) DeepCopy() *LokiStackRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(LokiStackRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LokiStackStorage) DeepCopyInto(out *LokiStackStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiStackStorage.
+func (in *LokiStackStorage) DeepCopy() *LokiStackStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(LokiStackStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]HealthRuleTemplate, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsServerConfig) DeepCopyInto(out *MetricsServerConfig) {
 	*out = *in
@@ -1262,10 +1683,11 @@ func (in *PrometheusQuerier) DeepCopy() *PrometheusQuerier {
 func (in *PrometheusQuerierManual) DeepCopyInto(out *PrometheusQuerierManual) {
 	*out = *in
 	out.TLS = in.TLS
+	in.Auth.DeepCopyInto(&out.Auth)
 	if in.AlertManager != nil {
 		in, out := &in.AlertManager, &out.AlertManager
 		*out = new(AlertManagerQuerierManual)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
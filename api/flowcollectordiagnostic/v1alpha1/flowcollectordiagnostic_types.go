@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FlowCollectorDiagnosticSpec defines the desired state of FlowCollectorDiagnostic
+type FlowCollectorDiagnosticSpec struct {
+	// `clientNode` is the name of the node where the client pod, which generates traffic, is scheduled, using a node affinity.
+	// When omitted, the node is chosen by the scheduler.
+	// +optional
+	ClientNode string `json:"clientNode,omitempty"`
+
+	// `serverNode` is the name of the node where the server pod, which receives traffic, is scheduled, using a node affinity.
+	// When omitted, the node is chosen by the scheduler.
+	// +optional
+	ServerNode string `json:"serverNode,omitempty"`
+
+	// `timeout` is the maximum time to wait for flows to be observed in every output, after the traffic has started.
+	//+kubebuilder:default:="5m"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type DiagnosticPhase string
+
+const (
+	DiagnosticRunning   DiagnosticPhase = "Running"
+	DiagnosticSucceeded DiagnosticPhase = "Succeeded"
+	DiagnosticFailed    DiagnosticPhase = "Failed"
+)
+
+type DiagnosticStageStatus string
+
+const (
+	StagePending DiagnosticStageStatus = "Pending"
+	StagePassed  DiagnosticStageStatus = "Passed"
+	StageFailed  DiagnosticStageStatus = "Failed"
+	StageSkipped DiagnosticStageStatus = "Skipped"
+)
+
+// DiagnosticStage is the result of the verification of one stage of the pipeline
+type DiagnosticStage struct {
+	// `name` of the verified stage, such as `Traffic`, `Loki`, `Prometheus` or `Exporter/0`.
+	Name string `json:"name"`
+
+	// `status` of the verification: `Pending`, `Passed`, `Failed` or `Skipped`.
+	Status DiagnosticStageStatus `json:"status"`
+
+	// `latency` is the time it took to observe the generated traffic in this stage.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+
+	// `message` gives details about the verification.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// `hint` suggests what to check when the verification failed.
+	// +optional
+	Hint string `json:"hint,omitempty"`
+}
+
+// FlowCollectorDiagnosticStatus defines the observed state of FlowCollectorDiagnostic
+type FlowCollectorDiagnosticStatus struct {
+	// `phase` of the diagnostic: `Running`, `Succeeded` or `Failed`.
+	// +optional
+	Phase DiagnosticPhase `json:"phase,omitempty"`
+
+	// `startTime` is the time when the diagnostic started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// `trafficStartTime` is the time when the client pod started to generate traffic.
+	// +optional
+	TrafficStartTime *metav1.Time `json:"trafficStartTime,omitempty"`
+
+	// `completionTime` is the time when the diagnostic completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// `stages` are the per-stage verification results.
+	// +optional
+	Stages []DiagnosticStage `json:"stages,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// FlowCollectorDiagnostic is the API allowing to run an end-to-end verification of the flows pipeline.
+// When created, it deploys short-lived client and server pods in the operator namespace, generating known traffic,
+// then verifies that the matching flows are observed in each enabled output of the FlowCollector.
+// The pods are created by the operator, with the image configured in the operator: creating this resource must be
+// restricted to cluster administrators.
+type FlowCollectorDiagnostic struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlowCollectorDiagnosticSpec   `json:"spec,omitempty"`
+	Status FlowCollectorDiagnosticStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// FlowCollectorDiagnosticList contains a list of FlowCollectorDiagnostic
+type FlowCollectorDiagnosticList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FlowCollectorDiagnostic `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FlowCollectorDiagnostic{}, &FlowCollectorDiagnosticList{})
+}
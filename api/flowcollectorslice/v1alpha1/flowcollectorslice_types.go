@@ -18,6 +18,13 @@ type FlowCollectorSliceSpec struct {
 	//+kubebuilder:validation:Minimum=0
 	// +optional
 	Sampling int32 `json:"sampling,omitempty"`
+
+	// `healthRules` overrides, for this namespace only, the health rules configured in the FlowCollector `spec.processor.metrics.healthRules`.
+	// Overrides apply to the health rule variants grouped by `Namespace` or `Workload`: rules grouped by `Node`, or not grouped, remain cluster-wide.
+	// The health rules effectively applied to this namespace are reported in the status.
+	// More information on health rules: https://github.com/netobserv/network-observability-operator/blob/main/docs/HealthRules.md
+	// +optional
+	HealthRules []HealthRuleOverride `json:"healthRules,omitempty"`
 }
 
 // SubnetLabel allows to label subnets and IPs, such as to identify cluster-external workloads or web services.
@@ -32,6 +39,60 @@ type SubnetLabel struct {
 	Name string `json:"name,omitempty"`
 }
 
+// HealthRuleOverride customizes a health rule template of the FlowCollector for the namespace of a FlowCollectorSlice.
+type HealthRuleOverride struct {
+	// Health rule template name, as configured in the FlowCollector.
+	// Possible values are: `PacketDropsByKernel`, `PacketDropsByDevice`, `IPsecErrors`, `NetpolDenied`,
+	// `LatencyHighTrend`, `DNSErrors`, `DNSNxDomain`, `ExternalEgressHighTrend`, `ExternalIngressHighTrend`, `Ingress5xxErrors`, `IngressHTTPLatencyTrend`,
+	// `TCPResetsHigh`, `SYNWithoutACK`.
+	// +kubebuilder:validation:Enum:="PacketDropsByKernel";"PacketDropsByDevice";"IPsecErrors";"NetpolDenied";"LatencyHighTrend";"DNSErrors";"DNSNxDomain";"ExternalEgressHighTrend";"ExternalIngressHighTrend";"Ingress5xxErrors";"IngressHTTPLatencyTrend";"TCPResetsHigh";"SYNWithoutACK"
+	// +required
+	Template string `json:"template"`
+
+	// Set `disable` to `true` to not generate this health rule for the namespace.
+	// +optional
+	Disable bool `json:"disable,omitempty"`
+
+	// `mode` overrides whether this health rule is generated as an alert or a recording rule for the namespace.
+	// Possible values are: `Alert`, `Recording`. When not set, the FlowCollector mode is used.
+	// +kubebuilder:validation:Enum:="Alert";"Recording"
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// `thresholds` overrides the thresholds per severity for the namespace. When set, they replace all the FlowCollector thresholds of this template:
+	// a severity left empty is not generated. When not set, the FlowCollector thresholds are used.
+	// +optional
+	Thresholds *HealthRuleThresholds `json:"thresholds,omitempty"`
+}
+
+type HealthRuleThresholds struct {
+	// Threshold for severity `info`. Leave empty to not generate an Info alert. It must be parsable as a float.
+	// +optional
+	Info string `json:"info,omitempty"`
+
+	// Threshold for severity `warning`. Leave empty to not generate a Warning alert. It must be parsable as a float.
+	// +optional
+	Warning string `json:"warning,omitempty"`
+
+	// Threshold for severity `critical`. Leave empty to not generate a Critical alert. It must be parsable as a float.
+	// +optional
+	Critical string `json:"critical,omitempty"`
+}
+
+// EffectiveHealthRule is a health rule applied to the namespace of a FlowCollectorSlice.
+type EffectiveHealthRule struct {
+	// Health rule template name
+	Template string `json:"template"`
+	// Grouping of the health rule: `Namespace` or `Workload`
+	GroupBy string `json:"groupBy"`
+	// Mode of the health rule: `Alert` or `Recording`
+	Mode string `json:"mode"`
+	// Thresholds of the health rule per severity
+	Thresholds HealthRuleThresholds `json:"thresholds"`
+	// `overridden` is `true` when the health rule is customized by this FlowCollectorSlice, `false` when it is inherited from the FlowCollector
+	Overridden bool `json:"overridden"`
+}
+
 // FlowCollectorSliceStatus defines the observed state of FlowCollectorSlice
 type FlowCollectorSliceStatus struct {
 	// `conditions` represent the latest available observations of an object's state
@@ -42,6 +103,9 @@ type FlowCollectorSliceStatus struct {
 	// Number of subnet labels configured
 	// +optional
 	SubnetLabelsConfigured int `json:"subnetLabelsConfigured"`
+	// Health rules applied to this namespace, from the FlowCollector and the overrides of this slice
+	// +optional
+	HealthRules []EffectiveHealthRule `json:"healthRules,omitempty"`
 }
 
 // +kubebuilder:object:root=true
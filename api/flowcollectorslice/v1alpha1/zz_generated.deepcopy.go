@@ -25,6 +25,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveHealthRule) DeepCopyInto(out *EffectiveHealthRule) {
+	*out = *in
+	out.Thresholds = in.Thresholds
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveHealthRule.
+func (in *EffectiveHealthRule) DeepCopy() *EffectiveHealthRule {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveHealthRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlowCollectorSlice) DeepCopyInto(out *FlowCollectorSlice) {
 	*out = *in
@@ -94,6 +110,13 @@ func (in *FlowCollectorSliceSpec) DeepCopyInto(out *FlowCollectorSliceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HealthRules != nil {
+		in, out := &in.HealthRules, &out.HealthRules
+		*out = make([]HealthRuleOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowCollectorSliceSpec.
@@ -116,6 +139,11 @@ func (in *FlowCollectorSliceStatus) DeepCopyInto(out *FlowCollectorSliceStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HealthRules != nil {
+		in, out := &in.HealthRules, &out.HealthRules
+		*out = make([]EffectiveHealthRule, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowCollectorSliceStatus.
@@ -128,6 +156,41 @@ func (in *FlowCollectorSliceStatus) DeepCopy() *FlowCollectorSliceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthRuleOverride) DeepCopyInto(out *HealthRuleOverride) {
+	*out = *in
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = new(HealthRuleThresholds)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthRuleOverride.
+func (in *HealthRuleOverride) DeepCopy() *HealthRuleOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthRuleOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthRuleThresholds) DeepCopyInto(out *HealthRuleThresholds) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthRuleThresholds.
+func (in *HealthRuleThresholds) DeepCopy() *HealthRuleThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthRuleThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetLabel) DeepCopyInto(out *SubnetLabel) {
 	*out = *in
@@ -0,0 +1,134 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FlowDashboardSpec defines the desired state of FlowDashboard
+type FlowDashboardSpec struct {
+	// Title of the dashboard. When omitted, the resource name is used.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// List of template variables that can be used in the panels queries. Each variable is added to the dashboard as a dropdown list,
+	// populated from the values of a metric label. Queries refer to a variable with the `$` prefix, such as `$namespace`.
+	// When all values are selected, the variable matches any non-empty value, so it should be used with a regular expression matcher.
+	// +optional
+	Variables []DashboardVariable `json:"variables,omitempty"`
+
+	// List of rows, or sections, of the dashboard.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Rows []DashboardRow `json:"rows"`
+}
+
+// Configures a dashboard template variable, populated from the values of a metric label
+type DashboardVariable struct {
+	ChartVariable `json:",inline"`
+
+	// Name of the metric providing the label values. It refers either to the `metricName` of a FlowMetric,
+	// or to a predefined metric name such as `namespace_flows_total`.
+	// +required
+	Metric string `json:"metric"`
+}
+
+// Configures a dashboard row
+type DashboardRow struct {
+	// Title of the row. If omitted or empty, panels are displayed without a section header.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Set `collapse` to `true` to have this row collapsed by default.
+	// +optional
+	Collapse bool `json:"collapse,omitempty"`
+
+	// List of panels displayed in this row.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Panels []DashboardPanel `json:"panels"`
+}
+
+// Configures a dashboard panel
+type DashboardPanel struct {
+	// Title of the panel.
+	// +required
+	Title string `json:"title"`
+
+	// Unit of this panel. Only a few units are currently supported. Leave empty to use generic number.
+	// +kubebuilder:validation:Enum:="bytes";"seconds";"Bps";"pps";"percent";""
+	// +optional
+	Unit Unit `json:"unit,omitempty"`
+
+	// Type of the panel.
+	// +kubebuilder:validation:Enum:="SingleStat";"Line";"StackArea"
+	// +required
+	Type ChartType `json:"type"`
+
+	// Width of the panel, out of 12 columns. When omitted, it is 3 for `SingleStat` panels and 4 for other types.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=12
+	// +optional
+	Span int `json:"span,omitempty"`
+
+	// List of queries displayed on this panel. Queries may refer to different metrics, allowing to compare them side by side.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Queries []DashboardQuery `json:"queries"`
+}
+
+// Configures a PromQL query on a metric
+type DashboardQuery struct {
+	// Name of the metric used in this query. It refers either to the `metricName` of a FlowMetric,
+	// or to a predefined metric name such as `namespace_flows_total`. The metric must be enabled:
+	// predefined metrics must be listed in the FlowCollector `spec.processor.metrics.includeList`.
+	// +required
+	Metric string `json:"metric"`
+
+	// The `promQL` query to be run against Prometheus. Use `$METRIC` to refer to the metric defined in `metric`.
+	// For example: `sum(rate($METRIC[2m]))`.
+	// +required
+	PromQL string `json:"promQL"`
+
+	// The query legend that applies to each timeseries represented in this panel, with the format `{{ Label }}`.
+	// +optional
+	Legend string `json:"legend,omitempty"`
+
+	// Top N series to display per timestamp. Does not apply to `SingleStat` panel type.
+	// +kubebuilder:default:=7
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Top int `json:"top,omitempty"`
+}
+
+// FlowDashboardStatus defines the observed state of FlowDashboard
+type FlowDashboardStatus struct {
+	// `conditions` represent the latest available observations of an object's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Title",type="string",JSONPath=`.spec.title`
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// FlowDashboard is the API allowing to compose dashboards from several metrics, generated from flow logs.
+// Panels refer to FlowMetrics or predefined metrics by name. FlowDashboards must be created in the FlowCollector namespace.
+type FlowDashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlowDashboardSpec   `json:"spec,omitempty"`
+	Status FlowDashboardStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// FlowDashboardList contains a list of FlowDashboard
+type FlowDashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FlowDashboard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FlowDashboard{}, &FlowDashboardList{})
+}
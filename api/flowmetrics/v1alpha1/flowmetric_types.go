@@ -173,6 +173,25 @@ type Chart struct {
 	// this chart is automatically expanded in several panels (one per query).
 	// +required
 	Queries []Query `json:"queries"`
+
+	// List of template variables that can be used in this chart queries. Each variable is added to the dashboard as a dropdown list,
+	// populated from the values of a label of this metric. Queries refer to a variable with the `$` prefix, such as `$namespace`.
+	// When all values are selected, the variable matches any non-empty value, so it should be used with a regular expression matcher.
+	// For example: `sum(rate($METRIC{SrcK8S_Namespace=~"$namespace"}[2m]))`.
+	// +optional
+	Variables []ChartVariable `json:"variables,omitempty"`
+}
+
+// Configures a dashboard template variable
+type ChartVariable struct {
+	// Name of the variable, used in queries with the `$` prefix. For example: `namespace`.
+	// +kubebuilder:validation:Pattern:=^[a-zA-Z_][a-zA-Z0-9_]*$
+	// +required
+	Name string `json:"name"`
+
+	// Label of this metric, as it appears in Prometheus, whose values populate the variable. For example: `SrcK8S_Namespace`.
+	// +required
+	Label string `json:"label"`
 }
 
 // Configures PromQL queries
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper/cardinality"
@@ -129,6 +130,8 @@ func validateFlowMetric(_ context.Context, fMetric *FlowMetric) (admission.Warni
 		}
 	}
 
+	allErrs = append(allErrs, validateChartVariables(fMetric)...)
+
 	if len(allErrs) != 0 {
 		return nil, apierrors.NewInvalid(
 			schema.GroupKind{Group: GroupVersion.Group, Kind: FlowMetric{}.Kind},
@@ -137,3 +140,28 @@ func validateFlowMetric(_ context.Context, fMetric *FlowMetric) (admission.Warni
 	w := checkFlowMetricCardinality(fMetric)
 	return w, nil
 }
+
+func validateChartVariables(fMetric *FlowMetric) field.ErrorList {
+	var allErrs field.ErrorList
+	// Variables are populated from the metric labels, as they appear in Prometheus
+	promLabels := make(map[string]any, len(fMetric.Spec.Labels))
+	for _, label := range fMetric.Spec.Labels {
+		if remapped, ok := fMetric.Spec.Remap[label]; ok {
+			promLabels[string(remapped)] = nil
+		} else {
+			promLabels[label] = nil
+		}
+	}
+	for i := range fMetric.Spec.Charts {
+		for j, v := range fMetric.Spec.Charts[i].Variables {
+			path := field.NewPath("spec", "charts").Index(i).Child("variables").Index(j)
+			if strings.HasPrefix(v.Name, "METRIC") || v.Name == "__all" {
+				allErrs = append(allErrs, field.Invalid(path.Child("name"), v.Name, "this variable name is reserved"))
+			}
+			if _, ok := promLabels[v.Label]; !ok {
+				allErrs = append(allErrs, field.Invalid(path.Child("label"), v.Label, "the variable label must be one of the metric labels"))
+			}
+		}
+	}
+	return allErrs
+}
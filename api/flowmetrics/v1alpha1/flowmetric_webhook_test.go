@@ -102,6 +102,55 @@ func TestFlowMetric(t *testing.T) {
 			},
 			expectedError: `spec.buckets: Invalid value: ["a",""]: cannot be parsed as a float: "a"`,
 		},
+		{
+			desc: "Valid chart variables",
+			m: &FlowMetric{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test1",
+					Namespace: "test-namespace",
+				},
+				Spec: FlowMetricSpec{
+					Labels: []string{"SrcK8S_Namespace", "DstK8S_Namespace"},
+					Remap:  map[string]Label{"DstK8S_Namespace": "dst_namespace"},
+					Charts: []Chart{{
+						Variables: []ChartVariable{{Name: "namespace", Label: "SrcK8S_Namespace"}, {Name: "dst", Label: "dst_namespace"}},
+					}},
+				},
+			},
+			expectedError: "",
+		},
+		{
+			desc: "Invalid chart variable label",
+			m: &FlowMetric{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test1",
+					Namespace: "test-namespace",
+				},
+				Spec: FlowMetricSpec{
+					Labels: []string{"SrcK8S_Namespace"},
+					Charts: []Chart{{
+						Variables: []ChartVariable{{Name: "node", Label: "SrcK8S_HostName"}},
+					}},
+				},
+			},
+			expectedError: `spec.charts[0].variables[0].label: Invalid value: "SrcK8S_HostName": the variable label must be one of the metric labels`,
+		},
+		{
+			desc: "Reserved chart variable name",
+			m: &FlowMetric{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test1",
+					Namespace: "test-namespace",
+				},
+				Spec: FlowMetricSpec{
+					Labels: []string{"SrcK8S_Namespace"},
+					Charts: []Chart{{
+						Variables: []ChartVariable{{Name: "METRIC", Label: "SrcK8S_Namespace"}},
+					}},
+				},
+			},
+			expectedError: `spec.charts[0].variables[0].name: Invalid value: "METRIC": this variable name is reserved`,
+		},
 	}
 
 	for _, test := range tests {
@@ -33,6 +33,11 @@ func (in *Chart) DeepCopyInto(out *Chart) {
 		*out = make([]Query, len(*in))
 		copy(*out, *in)
 	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]ChartVariable, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Chart.
@@ -45,6 +50,202 @@ func (in *Chart) DeepCopy() *Chart {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChartVariable) DeepCopyInto(out *ChartVariable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartVariable.
+func (in *ChartVariable) DeepCopy() *ChartVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(ChartVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardPanel) DeepCopyInto(out *DashboardPanel) {
+	*out = *in
+	if in.Queries != nil {
+		in, out := &in.Queries, &out.Queries
+		*out = make([]DashboardQuery, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardPanel.
+func (in *DashboardPanel) DeepCopy() *DashboardPanel {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardPanel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardQuery) DeepCopyInto(out *DashboardQuery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardQuery.
+func (in *DashboardQuery) DeepCopy() *DashboardQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardRow) DeepCopyInto(out *DashboardRow) {
+	*out = *in
+	if in.Panels != nil {
+		in, out := &in.Panels, &out.Panels
+		*out = make([]DashboardPanel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardRow.
+func (in *DashboardRow) DeepCopy() *DashboardRow {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardRow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardVariable) DeepCopyInto(out *DashboardVariable) {
+	*out = *in
+	out.ChartVariable = in.ChartVariable
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardVariable.
+func (in *DashboardVariable) DeepCopy() *DashboardVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowDashboard) DeepCopyInto(out *FlowDashboard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowDashboard.
+func (in *FlowDashboard) DeepCopy() *FlowDashboard {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowDashboard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlowDashboard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowDashboardList) DeepCopyInto(out *FlowDashboardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FlowDashboard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowDashboardList.
+func (in *FlowDashboardList) DeepCopy() *FlowDashboardList {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowDashboardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlowDashboardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowDashboardSpec) DeepCopyInto(out *FlowDashboardSpec) {
+	*out = *in
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]DashboardVariable, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rows != nil {
+		in, out := &in.Rows, &out.Rows
+		*out = make([]DashboardRow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowDashboardSpec.
+func (in *FlowDashboardSpec) DeepCopy() *FlowDashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowDashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowDashboardStatus) DeepCopyInto(out *FlowDashboardStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowDashboardStatus.
+func (in *FlowDashboardStatus) DeepCopy() *FlowDashboardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowDashboardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlowMetric) DeepCopyInto(out *FlowMetric) {
 	*out = *in
@@ -37,6 +37,7 @@ type LokiConfig struct {
 	StatusUserKeyPath  string       `yaml:"statusUserKeyPath,omitempty" json:"statusUserKeyPath,omitempty"`
 	UseMocks           bool         `yaml:"useMocks,omitempty" json:"useMocks,omitempty"`
 	ForwardUserToken   bool         `yaml:"forwardUserToken,omitempty" json:"forwardUserToken,omitempty"`
+	HTTPAuthConfig     `yaml:",inline" json:",inline"`
 }
 
 type PrometheusConfig struct {
@@ -49,12 +50,22 @@ type PrometheusConfig struct {
 	CAPath           string             `yaml:"caPath,omitempty" json:"caPath,omitempty"`
 	ForwardUserToken bool               `yaml:"forwardUserToken,omitempty" json:"forwardUserToken,omitempty"`
 	Metrics          []MetricInfo       `yaml:"metrics,omitempty" json:"metrics,omitempty"`
+	HTTPAuthConfig   `yaml:",inline" json:",inline"`
 }
 
 type AlertManagerConfig struct {
-	URL     string `yaml:"url" json:"url"`
-	SkipTLS bool   `yaml:"skipTls,omitempty" json:"skipTls,omitempty"`
-	CAPath  string `yaml:"caPath,omitempty" json:"caPath,omitempty"`
+	URL            string `yaml:"url" json:"url"`
+	SkipTLS        bool   `yaml:"skipTls,omitempty" json:"skipTls,omitempty"`
+	CAPath         string `yaml:"caPath,omitempty" json:"caPath,omitempty"`
+	TokenPath      string `yaml:"tokenPath,omitempty" json:"tokenPath,omitempty"`
+	HTTPAuthConfig `yaml:",inline" json:",inline"`
+}
+
+// HTTPAuthConfig holds the paths of the files containing basic auth credentials and header values
+type HTTPAuthConfig struct {
+	UsernamePath string            `yaml:"usernamePath,omitempty" json:"usernamePath,omitempty"`
+	PasswordPath string            `yaml:"passwordPath,omitempty" json:"passwordPath,omitempty"`
+	HeaderPaths  map[string]string `yaml:"headerPaths,omitempty" json:"headerPaths,omitempty"`
 }
 
 type MetricInfo struct {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/netobserv/flowlogs-pipeline/pkg/api"
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
 	cfg "github.com/netobserv/network-observability-operator/internal/controller/consoleplugin/config"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
@@ -31,6 +32,7 @@ import (
 	"github.com/netobserv/network-observability-operator/internal/pkg/metrics"
 	"github.com/netobserv/network-observability-operator/internal/pkg/metrics/alerts"
 	"github.com/netobserv/network-observability-operator/internal/pkg/volumes"
+	"github.com/netobserv/network-observability-operator/internal/pkg/watchers"
 )
 
 const proxyAlias = "backend"
@@ -49,11 +51,14 @@ type builder struct {
 	labels   map[string]string
 	selector map[string]string
 	desired  *flowslatest.FlowCollectorSpec
+	fcSlices []sliceslatest.FlowCollectorSlice
 	advanced *flowslatest.AdvancedPluginConfig
 	volumes  volumes.Builder
+	// credentialsDigest is used to restart pods when Loki or Prometheus credentials change
+	credentialsDigest string
 }
 
-func newBuilder(info *reconcilers.Instance, desired *flowslatest.FlowCollectorSpec, name string) builder {
+func newBuilder(info *reconcilers.Instance, desired *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice, name string) builder {
 	imageToUse := reconcilers.MainImage
 	needsPF4, _, err := info.ClusterInfo.IsOpenShiftVersionLessThan("4.15.0")
 	if err == nil && needsPF4 {
@@ -74,6 +79,7 @@ func newBuilder(info *reconcilers.Instance, desired *flowslatest.FlowCollectorSp
 			"app": name,
 		},
 		desired:  desired,
+		fcSlices: fcSlices,
 		advanced: &advanced,
 	}
 }
@@ -198,6 +204,9 @@ func (b *builder) podTemplate(name, cmDigest string) *corev1.PodTemplateSpec {
 	if cmDigest != "" {
 		sa = name
 		annotations[constants.PodConfigurationDigest] = cmDigest
+		if b.credentialsDigest != "" {
+			annotations[watchers.Annotation("credentials")] = b.credentialsDigest
+		}
 
 		args = append(args, "-config", filepath.Join(configPath, configFile))
 
@@ -377,9 +386,27 @@ func (b *builder) getLokiConfig() (cfg.LokiConfig, error) {
 	if lk.UseHostToken() {
 		lconf.TokenPath = b.volumes.AddToken(constants.PluginName)
 	}
+	authPaths := b.volumes.AddHTTPClientAuth(&lk.Auth, "loki-auth")
+	lconf.HTTPAuthConfig = httpAuthConfig(&authPaths)
+	if authPaths.TokenPath != "" {
+		lconf.TokenPath = authPaths.TokenPath
+	}
 	return lconf, nil
 }
 
+func httpAuthConfig(paths *volumes.HTTPClientAuthPaths) cfg.HTTPAuthConfig {
+	return cfg.HTTPAuthConfig{
+		UsernamePath: paths.UsernamePath,
+		PasswordPath: paths.PasswordPath,
+		HeaderPaths:  paths.HeaderPaths,
+	}
+}
+
+// usePromManualConfig returns true when the Prometheus querier is configured from spec.prometheus.querier.manual
+func usePromManualConfig(desired *flowslatest.FlowCollectorSpec, info *reconcilers.Instance) bool {
+	return desired.Prometheus.Querier.Mode == flowslatest.PromModeManual || !info.ClusterInfo.IsOpenShift()
+}
+
 func (b *builder) getPromConfig(ctx context.Context) cfg.PrometheusConfig {
 	if !b.desired.UsePrometheus() {
 		return cfg.PrometheusConfig{}
@@ -420,6 +447,9 @@ func (b *builder) getPromConfig(ctx context.Context) cfg.PrometheusConfig {
 		if b.desired.Prometheus.Querier.Manual.AlertManager.TLS.Enable {
 			config.AlertManager.CAPath = b.volumes.AddCACertificate(&tls, "prom-am-certs")
 		}
+		amAuthPaths := b.volumes.AddHTTPClientAuth(&b.desired.Prometheus.Querier.Manual.AlertManager.Auth, "prom-am-auth")
+		config.AlertManager.HTTPAuthConfig = httpAuthConfig(&amAuthPaths)
+		config.AlertManager.TokenPath = amAuthPaths.TokenPath
 	}
 
 	if tls.Enable {
@@ -434,6 +464,18 @@ func (b *builder) getPromConfig(ctx context.Context) cfg.PrometheusConfig {
 	}
 
 	config.TokenPath = b.volumes.AddToken(constants.PluginName)
+	if usePromManualConfig(b.desired, b.info) {
+		authPaths := b.volumes.AddHTTPClientAuth(&b.desired.Prometheus.Querier.Manual.Auth, "prom-auth")
+		config.HTTPAuthConfig = httpAuthConfig(&authPaths)
+		switch b.desired.Prometheus.Querier.Manual.Auth.Type {
+		case flowslatest.HTTPClientAuthBearer:
+			config.TokenPath = authPaths.TokenPath
+		case flowslatest.HTTPClientAuthBasic:
+			// Basic auth replaces the service account token
+			config.TokenPath = ""
+		case flowslatest.HTTPClientAuthNone:
+		}
+	}
 
 	includeList := b.desired.GetIncludeList()
 	allMetrics := metrics.GetDefinitions(b.desired, true)
@@ -502,25 +544,11 @@ func (b *builder) setFrontendConfig(fconf *cfg.FrontendConfig) error {
 	}
 
 	// Add health rules metadata for frontend
-	fconf.RecordingAnnotations = b.getHealthRecordingAnnotations()
+	fconf.RecordingAnnotations = alerts.BuildHealthRecordingAnnotations(b.desired, b.fcSlices)
 
 	return nil
 }
 
-func (b *builder) getHealthRecordingAnnotations() map[string]map[string]string {
-	annotsPerRecording := make(map[string]map[string]string)
-	healthRules, _ := alerts.BuildHealthRules(b.desired)
-	for _, r := range healthRules {
-		rname := r.RecordingName()
-		if rname != "" {
-			if a, _ := r.GetAnnotations(); len(a) > 0 {
-				annotsPerRecording[rname] = a
-			}
-		}
-	}
-	return annotsPerRecording
-}
-
 func getLokiStatus(lokiStack *lokiv1.LokiStack) string {
 	if lokiStack == nil {
 		// This case should not happen
@@ -3,6 +3,8 @@ package consoleplugin
 import (
 	"context"
 	"reflect"
+	"slices"
+	"strings"
 
 	osv1 "github.com/openshift/api/console/v1"
 	operatorsv1 "github.com/openshift/api/operator/v1"
@@ -15,6 +17,7 @@ import (
 
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
@@ -71,8 +74,16 @@ func (r *CPReconciler) Reconcile(ctx context.Context, desired *flowslatest.FlowC
 	}
 
 	if desired.Spec.UseConsolePlugin() && (r.ClusterInfo.HasConsolePlugin() || desired.Spec.ConsolePlugin.Standalone) {
+		fcSlices, err := r.listSlices(ctx, &desired.Spec)
+		if err != nil {
+			return err
+		}
+
 		// Create object builder
-		builder := newBuilder(r.Instance, &desired.Spec, constants.PluginName)
+		builder := newBuilder(r.Instance, &desired.Spec, fcSlices, constants.PluginName)
+		if builder.credentialsDigest, err = r.processCredentials(ctx, &desired.Spec); err != nil {
+			return err
+		}
 
 		if err := r.reconcilePermissions(ctx, &builder, constants.PluginName); err != nil {
 			return err
@@ -119,6 +130,45 @@ func (r *CPReconciler) Reconcile(ctx context.Context, desired *flowslatest.FlowC
 	return nil
 }
 
+// listSlices returns the FlowCollectorSlices, for their health rule overrides, in the same order as in the FLP controller
+func (r *CPReconciler) listSlices(ctx context.Context, desired *flowslatest.FlowCollectorSpec) ([]sliceslatest.FlowCollectorSlice, error) {
+	if !desired.IsSliceEnabled() {
+		return nil, nil
+	}
+	fcSlices := sliceslatest.FlowCollectorSliceList{}
+	if err := r.List(ctx, &fcSlices); err != nil {
+		return nil, err
+	}
+	// Sort alphabetically
+	slices.SortFunc(fcSlices.Items, func(a, b sliceslatest.FlowCollectorSlice) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return fcSlices.Items, nil
+}
+
+// processCredentials watches the references used for Loki and Prometheus credentials, and returns a digest covering all of them
+func (r *CPReconciler) processCredentials(ctx context.Context, desired *flowslatest.FlowCollectorSpec) (string, error) {
+	var auths []*flowslatest.HTTPClientAuth
+	if desired.UseLoki() {
+		auths = append(auths, &r.Loki.Auth)
+	}
+	if desired.UsePrometheus() && usePromManualConfig(desired, r.Instance) {
+		auths = append(auths, &desired.Prometheus.Querier.Manual.Auth)
+		if am := desired.Prometheus.Querier.Manual.AlertManager; am != nil && desired.Prometheus.Querier.Mode == flowslatest.PromModeManual {
+			auths = append(auths, &am.Auth)
+		}
+	}
+	var digest string
+	for _, auth := range auths {
+		d, err := r.Watcher.ProcessHTTPClientAuth(ctx, r.Client, auth, r.Namespace)
+		if err != nil {
+			return "", err
+		}
+		digest += d
+	}
+	return digest, nil
+}
+
 func (r *CPReconciler) checkAutoPatch(ctx context.Context, desired *flowslatest.FlowCollector, name string) error {
 	console := operatorsv1.Console{}
 	advancedConfig := helper.GetAdvancedPluginConfig(desired.Spec.ConsolePlugin.Advanced)
@@ -55,7 +55,7 @@ func (r *CPReconciler) reconcileStatic(ctx context.Context, desired *flowslatest
 
 	if r.ClusterInfo.HasConsolePlugin() {
 		// Create object builder
-		builder := newBuilder(r.Instance, &desired.Spec, constants.StaticPluginName)
+		builder := newBuilder(r.Instance, &desired.Spec, nil, constants.StaticPluginName)
 
 		if err = r.reconcilePlugin(ctx, &builder, &desired.Spec, constants.StaticPluginName, "NetObserv static plugin"); err != nil {
 			return err
@@ -15,6 +15,7 @@ import (
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	"github.com/netobserv/flowlogs-pipeline/pkg/api"
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
 	config "github.com/netobserv/network-observability-operator/internal/controller/consoleplugin/config"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
@@ -110,7 +111,7 @@ func getAutoScalerSpecs() (ascv2.HorizontalPodAutoscaler, flowslatest.FlowCollec
 
 func getBuilder(spec *flowslatest.FlowCollectorSpec, lk *helper.LokiConfig) builder {
 	info := reconcilers.Common{Namespace: testNamespace, Loki: lk, ClusterInfo: &cluster.Info{}}
-	b := newBuilder(info.NewInstance(map[reconcilers.ImageRef]string{reconcilers.MainImage: testImage}, status.Instance{}), spec, constants.PluginName)
+	b := newBuilder(info.NewInstance(map[reconcilers.ImageRef]string{reconcilers.MainImage: testImage}, status.Instance{}), spec, nil, constants.PluginName)
 	_, _, _ = b.configMap(context.Background(), nil) // build configmap to update builder's volumes
 	return b
 }
@@ -354,6 +355,97 @@ func TestConfigMapContent(t *testing.T) {
 	assert.Equal(config.Frontend.Sampling, 1)
 }
 
+func TestConfigMapHealthRuleOverrides(t *testing.T) {
+	assert := assert.New(t)
+
+	spec := flowslatest.FlowCollectorSpec{
+		Agent:         flowslatest.FlowCollectorAgent{EBPF: flowslatest.FlowCollectorEBPF{Features: []flowslatest.AgentFeature{flowslatest.DNSTracking}}},
+		ConsolePlugin: getPluginConfig(),
+		Processor: flowslatest.FlowCollectorFLP{
+			SlicesConfig: &flowslatest.SlicesConfig{Enable: true},
+			Metrics: flowslatest.FLPMetrics{
+				HealthRules: &[]flowslatest.FLPHealthRule{{
+					Template: flowslatest.HealthRuleDNSErrors,
+					Mode:     flowslatest.ModeRecording,
+					Variants: []flowslatest.HealthRuleVariant{
+						{Thresholds: flowslatest.HealthRuleThresholds{Warning: "10"}, GroupBy: flowslatest.GroupByNamespace},
+					},
+				}},
+			},
+		},
+	}
+	fcSlices := []sliceslatest.FlowCollectorSlice{{
+		ObjectMeta: metav1.ObjectMeta{Name: "loose", Namespace: "app-a"},
+		Spec: sliceslatest.FlowCollectorSliceSpec{
+			HealthRules: []sliceslatest.HealthRuleOverride{
+				{Template: "DNSErrors", Thresholds: &sliceslatest.HealthRuleThresholds{Warning: "40"}},
+			},
+		},
+	}}
+	info := reconcilers.Common{Namespace: testNamespace, Loki: &helper.LokiConfig{}, ClusterInfo: &cluster.Info{}}
+	builder := newBuilder(info.NewInstance(map[reconcilers.ImageRef]string{reconcilers.MainImage: testImage}, status.Instance{}), &spec, fcSlices, constants.PluginName)
+	cm, _, err := builder.configMap(context.Background(), nil)
+	assert.Nil(err)
+
+	var config config.PluginConfig
+	err = yaml.Unmarshal([]byte(cm.Data["config.yaml"]), &config)
+	assert.Nil(err)
+	annots := config.Frontend.RecordingAnnotations["netobserv:health:dns_errors:namespace:dst:rate2m"]
+	assert.Contains(annots["netobserv_io_network_health"], `"recordingThresholds":{"warning":"10"}`)
+	assert.Contains(annots["netobserv_io_network_health"], `"namespaceThresholds":{"app-a":{"warning":"40"}}`)
+}
+
+func TestConfigMapCredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	ref := func(name, file string) *flowslatest.FileReference {
+		return &flowslatest.FileReference{Type: flowslatest.RefTypeSecret, Name: name, File: file}
+	}
+	lokiSpec := flowslatest.FlowCollectorLoki{
+		Mode: flowslatest.LokiModeManual,
+		Manual: flowslatest.LokiManualParams{
+			QuerierURL: "https://loki.example.com",
+			Auth:       flowslatest.HTTPClientAuth{Type: flowslatest.HTTPClientAuthBearer, Token: ref("loki-creds", "token")},
+		},
+	}
+	loki := helper.NewLokiConfig(&lokiSpec, "any")
+	spec := flowslatest.FlowCollectorSpec{
+		ConsolePlugin: getPluginConfig(),
+		Loki:          lokiSpec,
+		Prometheus: flowslatest.FlowCollectorPrometheus{Querier: flowslatest.PrometheusQuerier{
+			Mode: flowslatest.PromModeManual,
+			Manual: flowslatest.PrometheusQuerierManual{
+				URL: "https://thanos.example.com",
+				Auth: flowslatest.HTTPClientAuth{
+					Type:     flowslatest.HTTPClientAuthBasic,
+					Username: ref("prom-creds", "user"),
+					Password: ref("prom-creds", "password"),
+					Headers:  []flowslatest.HTTPHeaderReference{{Name: "X-Scope-OrgID", ValueFrom: *ref("prom-creds", "tenant")}},
+				},
+				AlertManager: &flowslatest.AlertManagerQuerierManual{
+					URL:  "https://alertmanager.example.com",
+					Auth: flowslatest.HTTPClientAuth{Type: flowslatest.HTTPClientAuthBearer, Token: ref("am-creds", "token")},
+				},
+			},
+		}},
+		Processor: flowslatest.FlowCollectorFLP{SubnetLabels: flowslatest.SubnetLabels{OpenShiftAutoDetect: ptr.To(false)}},
+	}
+	builder := getBuilder(&spec, &loki)
+	cm, _, err := builder.configMap(context.Background(), nil)
+	assert.NoError(err)
+
+	var config config.PluginConfig
+	assert.NoError(yaml.Unmarshal([]byte(cm.Data["config.yaml"]), &config))
+
+	assert.Equal("/var/loki-auth-token/token", config.Loki.TokenPath)
+	assert.Empty(config.Loki.UsernamePath)
+	assert.Equal("/var/prom-auth-username/user", config.Prometheus.UsernamePath)
+	assert.Equal("/var/prom-auth-password/password", config.Prometheus.PasswordPath)
+	assert.Equal(map[string]string{"X-Scope-OrgID": "/var/prom-auth-header-0/tenant"}, config.Prometheus.HeaderPaths)
+	assert.Empty(config.Prometheus.TokenPath)
+	assert.Equal("/var/prom-am-auth-token/token", config.Prometheus.AlertManager.TokenPath)
+}
+
 func TestServiceUpdateCheck(t *testing.T) {
 	assert := assert.New(t)
 	old := getServiceSpecs()
@@ -29,6 +29,7 @@ const (
 
 	// EBPFAgentName and other constants for it
 	EBPFAgentName                     = "netobserv-ebpf-agent"
+	EBPFAgentCompatName               = EBPFAgentName + "-compat"
 	EBPFAgentMetricsSvcName           = "ebpf-agent-svc-prom"
 	EBPFAgentMetricsSvcMonitoringName = "ebpf-agent-svc-monitor"
 	EBPFAgentPromAlertRule            = "ebpf-agent-prom-alert"
@@ -39,6 +40,33 @@ const (
 
 	OpenShiftCertificateAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
 
+	// Secrets for mutual TLS between eBPF agents and flowlogs-pipeline, when certificates are managed by the operator
+	AgentsCASecretName     = "netobserv-agents-ca"
+	FLPAgentsTLSSecretName = FLPName + "-agents-tls"
+	EBPFAgentTLSSecretName = EBPFAgentName + "-tls"
+	// Same, when certificates are issued by cert-manager in the privileged namespace
+	FLPAgentsCertSecretName = FLPName + "-agents-cert"
+	EBPFAgentCertSecretName = EBPFAgentName + "-cert"
+
+	// cert-manager resources used to issue serving certificates in "Auto" mode, when the OpenShift service CA isn't available
+	CertManagerSelfSignedIssuerName = "netobserv-selfsigned"
+	CertManagerCAName               = "netobserv-serving-ca"
+	FLPCertSecretName               = FLPName + "-cert"
+	CertManagerCSIDriverName        = "csi.cert-manager.io"
+
+	// Label set on the pods deployed by FlowCollectorDiagnostic, with the diagnostic name as value
+	DiagnosticLabel = "netobserv.io/diagnostic"
+
+	// flowlogs-pipeline stage names of the exporters, formatted with the exporter index
+	FLPKafkaExportStage = "kafka-export-%d"
+	FLPIPFIXExportStage = "IPFIX-export-%d"
+	FLPOtelExportStage  = "Otel-export-%d"
+
+	// Strimzi resources created with Kafka provisioning
+	StrimziTopicName       = "netobserv-flows"
+	StrimziUserName        = "netobserv"
+	StrimziUserIDConfigMap = "netobserv-kafka-user"
+
 	// PodConfigurationDigest is an annotation name to facilitate pod restart after
 	// any external configuration change
 	AnnotationDomain        = "flows.netobserv.io"
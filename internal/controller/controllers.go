@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"github.com/netobserv/network-observability-operator/internal/controller/diagnostic"
 	"github.com/netobserv/network-observability-operator/internal/controller/flp"
 	"github.com/netobserv/network-observability-operator/internal/controller/monitoring"
 	"github.com/netobserv/network-observability-operator/internal/controller/networkpolicy"
@@ -8,4 +9,4 @@ import (
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager"
 )
 
-var Registerers = []manager.Registerer{Start, flp.Start, monitoring.Start, networkpolicy.Start, static.Start}
+var Registerers = []manager.Registerer{Start, flp.Start, monitoring.Start, networkpolicy.Start, static.Start, diagnostic.Start}
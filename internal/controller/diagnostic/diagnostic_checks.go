@@ -0,0 +1,321 @@
+package diagnostic
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+)
+
+const (
+	stageTraffic    = "Traffic"
+	stageLoki       = "Loki"
+	stagePrometheus = "Prometheus"
+	stageExporter   = "Exporter"
+
+	queryTimeout = 10 * time.Second
+	tokenPath    = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	hintTraffic    = "Check that the image can be pulled, that the nodes are schedulable and that network policies allow traffic between the client and server pods"
+	hintLoki       = "Check flowlogs-pipeline logs for Loki write errors, and that the operator namespace is not excluded by agent or processor filters"
+	hintPrometheus = "Check that flowlogs-pipeline metrics are scraped by Prometheus, that the operator is allowed to query Prometheus, and that the operator namespace is not excluded by filters"
+	hintExporter   = "Check that the exporter target is listening, and that network policies allow traffic from the operator namespace"
+)
+
+// Metrics that are labelled by source namespace, in order of preference
+var namespacedMetrics = []string{
+	"namespace_flows_total",
+	"workload_flows_total",
+	"namespace_egress_bytes_total",
+	"workload_egress_bytes_total",
+	"namespace_ingress_bytes_total",
+	"workload_ingress_bytes_total",
+}
+
+// stageCheck verifies that the generated traffic is observed in one stage of the pipeline.
+// `run` returns true once the traffic is observed. When `skip` is set, the stage is not verified.
+type stageCheck struct {
+	name string
+	hint string
+	skip string
+	run  func(ctx context.Context) (bool, error)
+}
+
+// querier abstracts the calls to the pipeline outputs
+type querier interface {
+	queryLoki(ctx context.Context, query string, since time.Time) (int, error)
+	queryPrometheus(ctx context.Context, query string) (float64, error)
+	dial(ctx context.Context, address string) error
+}
+
+// buildChecks returns the verifications to run for each enabled output of the FlowCollector
+func buildChecks(spec *flowslatest.FlowCollectorSpec, q querier, ns, clientPod string, since func() time.Time) []stageCheck {
+	var checks []stageCheck
+	if spec.UseLoki() {
+		check := stageCheck{name: stageLoki, hint: hintLoki}
+		if spec.Loki.Mode == flowslatest.LokiModeManual && spec.Loki.Manual.Auth.UseCredentials() {
+			check.skip = "Custom authentication is not supported by the diagnostic"
+		} else {
+			query := fmt.Sprintf(`{app="netobserv-flowcollector",SrcK8S_Namespace=%q} |= %q`, ns, clientPod)
+			check.run = func(ctx context.Context) (bool, error) {
+				n, err := q.queryLoki(ctx, query, since())
+				return n > 0, err
+			}
+		}
+		checks = append(checks, check)
+	}
+	if spec.UsePrometheus() {
+		check := stageCheck{name: stagePrometheus, hint: hintPrometheus}
+		metric := namespacedMetric(spec)
+		if metric == "" {
+			check.skip = "No metric labelled by namespace is enabled; add namespace_flows_total to spec.processor.metrics.includeList"
+		} else if spec.Prometheus.Querier.Mode == flowslatest.PromModeManual && spec.Prometheus.Querier.Manual.Auth.UseCredentials() {
+			check.skip = "Custom authentication is not supported by the diagnostic"
+		} else {
+			check.run = func(ctx context.Context) (bool, error) {
+				window := int(time.Since(since()).Seconds()) + 60
+				query := fmt.Sprintf(`sum(increase(netobserv_%s{SrcK8S_Namespace=%q}[%ds]))`, metric, ns, window)
+				v, err := q.queryPrometheus(ctx, query)
+				return v > 0, err
+			}
+		}
+		checks = append(checks, check)
+	}
+	for i, exp := range spec.Exporters {
+		if exp == nil {
+			continue
+		}
+		check := stageCheck{name: fmt.Sprintf("%s/%d", stageExporter, i), hint: hintExporter}
+		address := exporterAddress(exp)
+		if address == "" {
+			check.skip = fmt.Sprintf("Reachability cannot be verified for %s exporters over UDP", exp.Type)
+		} else {
+			check.run = func(ctx context.Context) (bool, error) {
+				if err := q.dial(ctx, address); err != nil {
+					return false, err
+				}
+				return true, nil
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func namespacedMetric(spec *flowslatest.FlowCollectorSpec) string {
+	included := spec.GetIncludeList()
+	for _, m := range namespacedMetrics {
+		if slices.Contains(included, m) {
+			return m
+		}
+	}
+	return ""
+}
+
+// exporterAddress returns the TCP address of the exporter target, or an empty string when it cannot be reached over TCP
+func exporterAddress(exp *flowslatest.FlowCollectorExporter) string {
+	switch exp.Type {
+	case flowslatest.KafkaExporter:
+		return strings.TrimSpace(strings.Split(exp.Kafka.Address, ",")[0])
+	case flowslatest.IpfixExporter:
+		if strings.EqualFold(exp.IPFIX.Transport, "UDP") {
+			return ""
+		}
+		return net.JoinHostPort(exp.IPFIX.TargetHost, strconv.Itoa(exp.IPFIX.TargetPort))
+	case flowslatest.OpenTelemetryExporter:
+		return net.JoinHostPort(exp.OpenTelemetry.TargetHost, strconv.Itoa(exp.OpenTelemetry.TargetPort))
+	}
+	return ""
+}
+
+// endpoint describes how to reach and authenticate to a query API
+type endpoint struct {
+	url      string
+	tenantID string
+	useToken bool
+	tls      flowslatest.ClientTLS
+}
+
+// httpQuerier queries Loki and Prometheus with the operator service account token
+type httpQuerier struct {
+	cl        client.Reader
+	namespace string
+	loki      *endpoint
+	prom      *endpoint
+	dialer    net.Dialer
+}
+
+func newHTTPQuerier(cl client.Reader, spec *flowslatest.FlowCollectorSpec, isOpenShift bool) *httpQuerier {
+	ns := spec.GetNamespace()
+	q := httpQuerier{cl: cl, namespace: ns, dialer: net.Dialer{Timeout: queryTimeout}}
+	if spec.UseLoki() {
+		loki := helper.NewLokiConfig(&spec.Loki, ns)
+		q.loki = &endpoint{
+			url:      loki.QuerierURL,
+			tenantID: loki.TenantID,
+			useToken: spec.Loki.Mode == flowslatest.LokiModeLokiStack || (loki.AuthToken != "" && loki.AuthToken != flowslatest.LokiAuthDisabled),
+			tls:      loki.TLS,
+		}
+	}
+	if spec.UsePrometheus() {
+		manual := &spec.Prometheus.Querier.Manual
+		q.prom = &endpoint{url: manual.URL, useToken: manual.ForwardUserToken, tls: manual.TLS}
+		if (spec.Prometheus.Querier.Mode == "" || spec.Prometheus.Querier.Mode == flowslatest.PromModeAuto) && isOpenShift {
+			q.prom = &endpoint{
+				// NB: trailing dot (...local.:9091) is a DNS optimization for exact name match without extra search
+				url:      "https://thanos-querier.openshift-monitoring.svc.cluster.local.:9091/",
+				useToken: true,
+				tls: flowslatest.ClientTLS{
+					Enable: true,
+					CACert: flowslatest.CertificateReference{
+						Type:     flowslatest.RefTypeConfigMap,
+						Name:     "openshift-service-ca.crt",
+						CertFile: "service-ca.crt",
+					},
+				},
+			}
+		}
+	}
+	return &q
+}
+
+func (q *httpQuerier) queryLoki(ctx context.Context, query string, since time.Time) (int, error) {
+	if q.loki == nil {
+		return 0, fmt.Errorf("loki is not configured")
+	}
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(since.UnixNano(), 10))
+	params.Set("limit", "1")
+	var resp struct {
+		Data struct {
+			Result []json.RawMessage `json:"result"`
+		} `json:"data"`
+	}
+	if err := q.get(ctx, q.loki, "loki/api/v1/query_range", params, &resp); err != nil {
+		return 0, err
+	}
+	return len(resp.Data.Result), nil
+}
+
+func (q *httpQuerier) queryPrometheus(ctx context.Context, query string) (float64, error) {
+	if q.prom == nil {
+		return 0, fmt.Errorf("prometheus is not configured")
+	}
+	params := url.Values{}
+	params.Set("query", query)
+	var resp struct {
+		Data struct {
+			Result []struct {
+				Value []interface{} `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := q.get(ctx, q.prom, "api/v1/query", params, &resp); err != nil {
+		return 0, err
+	}
+	if len(resp.Data.Result) == 0 || len(resp.Data.Result[0].Value) < 2 {
+		return 0, nil
+	}
+	str, ok := resp.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected Prometheus value: %v", resp.Data.Result[0].Value[1])
+	}
+	return strconv.ParseFloat(str, 64)
+}
+
+func (q *httpQuerier) dial(ctx context.Context, address string) error {
+	conn, err := q.dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (q *httpQuerier) get(ctx context.Context, ep *endpoint, path string, params url.Values, out interface{}) error {
+	httpClient, err := q.httpClient(ctx, ep)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(ep.url, "/")+"/"+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if ep.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", ep.tenantID)
+	}
+	if ep.useToken {
+		token, err := os.ReadFile(tokenPath)
+		if err != nil {
+			return fmt.Errorf("could not read service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+	return json.Unmarshal(body, out)
+}
+
+func (q *httpQuerier) httpClient(ctx context.Context, ep *endpoint) (*http.Client, error) {
+	transport := &http.Transport{}
+	if ep.tls.Enable {
+		tlsConfig := &tls.Config{InsecureSkipVerify: ep.tls.InsecureSkipVerify}
+		if !ep.tls.InsecureSkipVerify && ep.tls.CACert.Name != "" {
+			ca, err := q.readCertificate(ctx, &ep.tls.CACert)
+			if err != nil {
+				return nil, err
+			}
+			pool := x509.NewCertPool()
+			pool.AppendCertsFromPEM(ca)
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &http.Client{Timeout: queryTimeout, Transport: transport}, nil
+}
+
+func (q *httpQuerier) readCertificate(ctx context.Context, ref *flowslatest.CertificateReference) ([]byte, error) {
+	nsname := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if nsname.Namespace == "" {
+		nsname.Namespace = q.namespace
+	}
+	if ref.Type == flowslatest.RefTypeSecret {
+		secret := corev1.Secret{}
+		if err := q.cl.Get(ctx, nsname, &secret); err != nil {
+			return nil, err
+		}
+		return secret.Data[ref.CertFile], nil
+	}
+	cm := corev1.ConfigMap{}
+	if err := q.cl.Get(ctx, nsname, &cm); err != nil {
+		return nil, err
+	}
+	return []byte(cm.Data[ref.CertFile]), nil
+}
@@ -0,0 +1,274 @@
+package diagnostic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	diagv1alpha1 "github.com/netobserv/network-observability-operator/api/flowcollectordiagnostic/v1alpha1"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
+	"github.com/netobserv/network-observability-operator/internal/pkg/manager"
+)
+
+const (
+	// Loki, Prometheus and exporters don't notify the operator: their checks are polled
+	pollInterval   = 10 * time.Second
+	defaultTimeout = 5 * time.Minute
+)
+
+type Reconciler struct {
+	client.Client
+	mgr        *manager.Manager
+	newQuerier func(spec *flowslatest.FlowCollectorSpec) querier
+}
+
+func Start(ctx context.Context, mgr *manager.Manager) (manager.PostCreateHook, error) {
+	log := log.FromContext(ctx)
+	log.Info("Starting FlowCollectorDiagnostic controller")
+	r := Reconciler{
+		Client: mgr.Client,
+		mgr:    mgr,
+	}
+	r.newQuerier = func(spec *flowslatest.FlowCollectorSpec) querier {
+		return newHTTPQuerier(mgr.GetAPIReader(), spec, mgr.ClusterInfo.IsOpenShift())
+	}
+	return nil, ctrl.NewControllerManagedBy(mgr).
+		For(&diagv1alpha1.FlowCollectorDiagnostic{}, reconcilers.IgnoreStatusChange).
+		// Pods are only cached in the operator namespace, with the diagnostic label (see manager.NewManager)
+		Owns(&corev1.Pod{}).
+		Named("diagnostic").
+		Complete(&r)
+}
+
+// Reconcile runs the diagnostic: it deploys the client and server pods in the operator namespace, then polls each output
+// of the pipeline until the generated traffic is observed, or until the timeout is reached. Pods are deleted once the
+// diagnostic completes.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := log.Log.WithName("diagnostic").WithValues("name", req.Name) // clear context (too noisy)
+	ctx = log.IntoContext(ctx, l)
+
+	diag := diagv1alpha1.FlowCollectorDiagnostic{}
+	if err := r.Get(ctx, req.NamespacedName, &diag); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if diag.Status.Phase == diagv1alpha1.DiagnosticSucceeded || diag.Status.Phase == diagv1alpha1.DiagnosticFailed {
+		return ctrl.Result{}, r.cleanup(ctx, &diag)
+	}
+
+	now := time.Now()
+	running, err := r.run(ctx, &diag, now)
+	if statusErr := r.Status().Update(ctx, &diag); statusErr != nil {
+		return ctrl.Result{}, statusErr
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !running {
+		return ctrl.Result{}, r.cleanup(ctx, &diag)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter(&diag, now)}, nil
+}
+
+// requeueAfter returns when to run the diagnostic again. While the pods are starting, their events trigger reconciliations,
+// so it only needs to run again at the timeout; afterwards, outputs are polled.
+func requeueAfter(diag *diagv1alpha1.FlowCollectorDiagnostic, now time.Time) time.Duration {
+	if diag.Status.TrafficStartTime != nil {
+		return pollInterval
+	}
+	return max(diag.Status.StartTime.Add(timeout(diag)).Sub(now), time.Second)
+}
+
+// run advances the diagnostic and updates its status. It returns true while the diagnostic is still running.
+func (r *Reconciler) run(ctx context.Context, diag *diagv1alpha1.FlowCollectorDiagnostic, now time.Time) (bool, error) {
+	st := &diag.Status
+	if st.StartTime == nil {
+		st.StartTime = &metav1.Time{Time: now}
+		st.Phase = diagv1alpha1.DiagnosticRunning
+		st.Stages = []diagv1alpha1.DiagnosticStage{{Name: stageTraffic, Status: diagv1alpha1.StagePending}}
+	}
+	timedOut := now.Sub(st.StartTime.Time) > timeout(diag)
+
+	fc := flowslatest.FlowCollector{}
+	if err := r.Get(ctx, constants.FlowCollectorName, &fc); err != nil {
+		if kerr.IsNotFound(err) {
+			setStage(st, diagv1alpha1.DiagnosticStage{Name: stageTraffic, Status: diagv1alpha1.StageFailed, Message: "FlowCollector not found", Hint: "Create a FlowCollector before running a diagnostic"})
+			complete(st, now)
+			return false, nil
+		}
+		return true, err
+	}
+
+	if st.TrafficStartTime == nil {
+		started, err := r.startTraffic(ctx, diag, now)
+		if err != nil || started {
+			return true, err
+		}
+		if timedOut {
+			setStage(st, diagv1alpha1.DiagnosticStage{Name: stageTraffic, Status: diagv1alpha1.StageFailed, Message: "The client and server pods did not start in time", Hint: hintTraffic})
+			complete(st, now)
+			return false, nil
+		}
+		return true, nil
+	}
+
+	since := func() time.Time { return st.TrafficStartTime.Time }
+	checks := buildChecks(&fc.Spec, r.newQuerier(&fc.Spec), r.mgr.Config.Namespace, clientPodName(diag), since)
+	if runChecks(ctx, st, checks, now, timedOut) {
+		return true, nil
+	}
+	complete(st, now)
+	return false, nil
+}
+
+// startTraffic creates the server pod, then the client pod once the server has an IP.
+// It returns true when the client pod just started running.
+func (r *Reconciler) startTraffic(ctx context.Context, diag *diagv1alpha1.FlowCollectorDiagnostic, now time.Time) (bool, error) {
+	ns := r.mgr.Config.Namespace
+	server, err := r.getOrCreatePod(ctx, diag, serverPod(diag, ns, r.mgr.Config.DiagnosticImage))
+	if err != nil || server == nil || server.Status.PodIP == "" {
+		return false, err
+	}
+	cl, err := r.getOrCreatePod(ctx, diag, clientPod(diag, ns, r.mgr.Config.DiagnosticImage, server.Status.PodIP))
+	if err != nil || cl == nil || cl.Status.Phase != corev1.PodRunning || !isReady(server) {
+		return false, err
+	}
+	st := &diag.Status
+	st.TrafficStartTime = &metav1.Time{Time: now}
+	if cl.Status.StartTime != nil {
+		st.TrafficStartTime = cl.Status.StartTime
+	}
+	setStage(st, diagv1alpha1.DiagnosticStage{
+		Name:    stageTraffic,
+		Status:  diagv1alpha1.StagePassed,
+		Latency: &metav1.Duration{Duration: st.TrafficStartTime.Sub(st.StartTime.Time)},
+		Message: fmt.Sprintf("Traffic generated from %s to %s", cl.Name, server.Name),
+	})
+	return true, nil
+}
+
+// runChecks updates the status of every pending stage. It returns true while some stages are still pending.
+func runChecks(ctx context.Context, st *diagv1alpha1.FlowCollectorDiagnosticStatus, checks []stageCheck, now time.Time, timedOut bool) bool {
+	pending := false
+	for _, check := range checks {
+		current := getStage(st, check.name)
+		if current != nil && current.Status != diagv1alpha1.StagePending {
+			continue
+		}
+		stage := diagv1alpha1.DiagnosticStage{Name: check.name, Status: diagv1alpha1.StagePending}
+		if check.skip != "" {
+			stage.Status = diagv1alpha1.StageSkipped
+			stage.Message = check.skip
+			setStage(st, stage)
+			continue
+		}
+		found, err := check.run(ctx)
+		switch {
+		case found:
+			stage.Status = diagv1alpha1.StagePassed
+			stage.Latency = &metav1.Duration{Duration: now.Sub(st.TrafficStartTime.Time)}
+		case timedOut:
+			stage.Status = diagv1alpha1.StageFailed
+			stage.Message = "Generated traffic not observed before the timeout"
+			if err != nil {
+				stage.Message = fmt.Sprintf("%s: %v", stage.Message, err)
+			}
+			stage.Hint = check.hint
+		default:
+			pending = true
+			if err != nil {
+				stage.Message = err.Error()
+			}
+		}
+		setStage(st, stage)
+	}
+	return pending
+}
+
+func complete(st *diagv1alpha1.FlowCollectorDiagnosticStatus, now time.Time) {
+	st.CompletionTime = &metav1.Time{Time: now}
+	st.Phase = diagv1alpha1.DiagnosticSucceeded
+	for i := range st.Stages {
+		if st.Stages[i].Status == diagv1alpha1.StageFailed || st.Stages[i].Status == diagv1alpha1.StagePending {
+			st.Phase = diagv1alpha1.DiagnosticFailed
+		}
+	}
+}
+
+func getStage(st *diagv1alpha1.FlowCollectorDiagnosticStatus, name string) *diagv1alpha1.DiagnosticStage {
+	for i := range st.Stages {
+		if st.Stages[i].Name == name {
+			return &st.Stages[i]
+		}
+	}
+	return nil
+}
+
+func setStage(st *diagv1alpha1.FlowCollectorDiagnosticStatus, stage diagv1alpha1.DiagnosticStage) {
+	if current := getStage(st, stage.Name); current != nil {
+		*current = stage
+		return
+	}
+	st.Stages = append(st.Stages, stage)
+}
+
+func timeout(diag *diagv1alpha1.FlowCollectorDiagnostic) time.Duration {
+	if diag.Spec.Timeout == nil {
+		return defaultTimeout
+	}
+	return diag.Spec.Timeout.Duration
+}
+
+func isReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (r *Reconciler) getOrCreatePod(ctx context.Context, diag *diagv1alpha1.FlowCollectorDiagnostic, desired *corev1.Pod) (*corev1.Pod, error) {
+	current := corev1.Pod{}
+	if err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &current); err != nil {
+		if !kerr.IsNotFound(err) {
+			return nil, err
+		}
+		if err := controllerutil.SetControllerReference(diag, desired, r.Scheme()); err != nil {
+			return nil, err
+		}
+		log.FromContext(ctx).Info("CREATING diagnostic pod", "Name", desired.Name)
+		return nil, r.Create(ctx, desired)
+	}
+	return &current, nil
+}
+
+func (r *Reconciler) cleanup(ctx context.Context, diag *diagv1alpha1.FlowCollectorDiagnostic) error {
+	for _, name := range []string{clientPodName(diag), serverPodName(diag)} {
+		pod := corev1.Pod{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: r.mgr.Config.Namespace}, &pod); err != nil {
+			if kerr.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(&pod, diag) {
+			continue
+		}
+		log.FromContext(ctx).Info("DELETING diagnostic pod", "Name", name)
+		if err := r.Delete(ctx, &pod); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package diagnostic
+
+import (
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	diagv1alpha1 "github.com/netobserv/network-observability-operator/api/flowcollectordiagnostic/v1alpha1"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+)
+
+const (
+	serverPort = 8080
+	roleKey    = "netobserv.io/diagnostic-role"
+	roleServer = "server"
+	roleClient = "client"
+)
+
+// Pods are prefixed, as they share the operator namespace with the operator and its components
+func serverPodName(diag *diagv1alpha1.FlowCollectorDiagnostic) string {
+	return "netobserv-diagnostic-" + diag.Name + "-server"
+}
+
+func clientPodName(diag *diagv1alpha1.FlowCollectorDiagnostic) string {
+	return "netobserv-diagnostic-" + diag.Name + "-client"
+}
+
+func serverPod(diag *diagv1alpha1.FlowCollectorDiagnostic, ns, image string) *corev1.Pod {
+	return newPod(diag, ns, serverPodName(diag), roleServer, diag.Spec.ServerNode, corev1.Container{
+		Name:    roleServer,
+		Image:   image,
+		Command: []string{"python3", "-m", "http.server", fmt.Sprint(serverPort)},
+		Ports: []corev1.ContainerPort{{
+			Name:          "http",
+			ContainerPort: serverPort,
+			Protocol:      corev1.ProtocolTCP,
+		}},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(serverPort)},
+			},
+			PeriodSeconds: 2,
+		},
+		SecurityContext: helper.ContainerDefaultSecurityContext(),
+	})
+}
+
+func clientPod(diag *diagv1alpha1.FlowCollectorDiagnostic, ns, image, serverIP string) *corev1.Pod {
+	// Send a request every half second, so that flows are observed even with a high sampling ratio
+	script := fmt.Sprintf("while true; do curl -s -o /dev/null http://%s/; sleep 0.5; done", net.JoinHostPort(serverIP, fmt.Sprint(serverPort)))
+	return newPod(diag, ns, clientPodName(diag), roleClient, diag.Spec.ClientNode, corev1.Container{
+		Name:            roleClient,
+		Image:           image,
+		Command:         []string{"/bin/sh", "-c", script},
+		SecurityContext: helper.ContainerDefaultSecurityContext(),
+	})
+}
+
+func newPod(diag *diagv1alpha1.FlowCollectorDiagnostic, ns, name, role, node string, container corev1.Container) *corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels: map[string]string{
+				constants.DiagnosticLabel: diag.Name,
+				roleKey:                   role,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: ptr.To(int64(1)),
+			Containers:                    []corev1.Container{container},
+		},
+	}
+	if node != "" {
+		// Unlike nodeName, a node affinity goes through the scheduler, so that taints and resources are honored
+		pod.Spec.Affinity = &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchFields: []corev1.NodeSelectorRequirement{{
+							Key:      "metadata.name",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{node},
+						}},
+					}},
+				},
+			},
+		}
+	}
+	return &pod
+}
@@ -0,0 +1,202 @@
+package diagnostic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	diagv1alpha1 "github.com/netobserv/network-observability-operator/api/flowcollectordiagnostic/v1alpha1"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+)
+
+type fakeQuerier struct {
+	lokiResults int
+	promValue   float64
+	dialErr     error
+	lastQuery   string
+}
+
+func (q *fakeQuerier) queryLoki(_ context.Context, query string, _ time.Time) (int, error) {
+	q.lastQuery = query
+	return q.lokiResults, nil
+}
+
+func (q *fakeQuerier) queryPrometheus(_ context.Context, query string) (float64, error) {
+	q.lastQuery = query
+	return q.promValue, nil
+}
+
+func (q *fakeQuerier) dial(_ context.Context, _ string) error {
+	return q.dialErr
+}
+
+func newDiagnostic() *diagv1alpha1.FlowCollectorDiagnostic {
+	return &diagv1alpha1.FlowCollectorDiagnostic{
+		ObjectMeta: metav1.ObjectMeta{Name: "diag"},
+		Spec:       diagv1alpha1.FlowCollectorDiagnosticSpec{ClientNode: "node-a", ServerNode: "node-b"},
+	}
+}
+
+func TestPods(t *testing.T) {
+	diag := newDiagnostic()
+
+	server := serverPod(diag, "netobserv", "ubi:9.6")
+	assert.Equal(t, "netobserv-diagnostic-diag-server", server.Name)
+	assert.Equal(t, "netobserv", server.Namespace)
+	assert.Equal(t, "diag", server.Labels[constants.DiagnosticLabel])
+	assert.Empty(t, server.Spec.NodeName)
+	assert.Equal(t, []corev1.NodeSelectorTerm{{
+		MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-b"}}},
+	}}, server.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+	assert.Equal(t, "ubi:9.6", server.Spec.Containers[0].Image)
+	assert.Equal(t, "server", server.Labels[roleKey])
+
+	client := clientPod(diag, "netobserv", "ubi:9.6", "fd00::1")
+	assert.Equal(t, "netobserv-diagnostic-diag-client", client.Name)
+	assert.Equal(t, "netobserv", client.Namespace)
+	assert.Equal(t, []string{"node-a"}, client.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values)
+	assert.Contains(t, client.Spec.Containers[0].Command[2], "http://[fd00::1]:8080/")
+
+	// No node: left to the scheduler
+	diag.Spec.ClientNode = ""
+	client = clientPod(diag, "netobserv", "ubi:9.6", "fd00::1")
+	assert.Nil(t, client.Spec.Affinity)
+}
+
+func TestBuildChecks(t *testing.T) {
+	spec := flowslatest.FlowCollectorSpec{
+		Exporters: []*flowslatest.FlowCollectorExporter{
+			{Type: flowslatest.KafkaExporter, Kafka: flowslatest.FlowCollectorKafka{Address: "kafka:9092,kafka-2:9092"}},
+			{Type: flowslatest.IpfixExporter, IPFIX: flowslatest.FlowCollectorIPFIXReceiver{TargetHost: "ipfix", TargetPort: 4739, Transport: "UDP"}},
+		},
+	}
+	q := fakeQuerier{}
+	checks := buildChecks(&spec, &q, "test", "diag-client", time.Now)
+	assert.Len(t, checks, 4)
+	assert.Equal(t, []string{"Loki", "Prometheus", "Exporter/0", "Exporter/1"}, []string{checks[0].name, checks[1].name, checks[2].name, checks[3].name})
+	assert.Empty(t, checks[0].skip)
+	assert.Empty(t, checks[1].skip)
+	assert.Empty(t, checks[2].skip)
+	assert.Equal(t, "Reachability cannot be verified for IPFIX exporters over UDP", checks[3].skip)
+
+	_, _ = checks[0].run(context.Background())
+	assert.Equal(t, `{app="netobserv-flowcollector",SrcK8S_Namespace="test"} |= "diag-client"`, q.lastQuery)
+	_, _ = checks[1].run(context.Background())
+	assert.Contains(t, q.lastQuery, `sum(increase(netobserv_namespace_flows_total{SrcK8S_Namespace="test"}[`)
+
+	// Loki disabled, no namespaced metric
+	spec = flowslatest.FlowCollectorSpec{
+		Loki: flowslatest.FlowCollectorLoki{Enable: ptr.To(false)},
+		Processor: flowslatest.FlowCollectorFLP{
+			Metrics: flowslatest.FLPMetrics{IncludeList: &[]flowslatest.FLPMetric{"node_ingress_bytes_total"}},
+		},
+	}
+	checks = buildChecks(&spec, &q, "test", "diag-client", time.Now)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, "Prometheus", checks[0].name)
+	assert.Contains(t, checks[0].skip, "namespace_flows_total")
+}
+
+func TestRunChecks(t *testing.T) {
+	start := time.Now()
+	st := diagv1alpha1.FlowCollectorDiagnosticStatus{
+		StartTime:        &metav1.Time{Time: start},
+		TrafficStartTime: &metav1.Time{Time: start},
+		Stages:           []diagv1alpha1.DiagnosticStage{{Name: stageTraffic, Status: diagv1alpha1.StagePassed}},
+	}
+	spec := flowslatest.FlowCollectorSpec{
+		Exporters: []*flowslatest.FlowCollectorExporter{
+			{Type: flowslatest.OpenTelemetryExporter, OpenTelemetry: flowslatest.FlowCollectorOpenTelemetry{TargetHost: "otlp", TargetPort: 4317}},
+		},
+	}
+	q := fakeQuerier{dialErr: errors.New("connection refused")}
+
+	// Nothing observed yet
+	pending := runChecks(context.Background(), &st, buildChecks(&spec, &q, "test", "diag-client", time.Now), start.Add(10*time.Second), false)
+	assert.True(t, pending)
+	assert.Len(t, st.Stages, 4)
+	assert.Equal(t, diagv1alpha1.StagePending, st.Stages[1].Status)
+	assert.Equal(t, "connection refused", st.Stages[3].Message)
+
+	// Loki observed
+	q.lokiResults = 1
+	pending = runChecks(context.Background(), &st, buildChecks(&spec, &q, "test", "diag-client", time.Now), start.Add(20*time.Second), false)
+	assert.True(t, pending)
+	assert.Equal(t, diagv1alpha1.StagePassed, st.Stages[1].Status)
+	assert.Equal(t, 20*time.Second, st.Stages[1].Latency.Duration)
+	assert.Equal(t, diagv1alpha1.StagePending, st.Stages[2].Status)
+
+	// Prometheus observed, exporter times out; Loki result is kept
+	q.lokiResults = 0
+	q.promValue = 3
+	pending = runChecks(context.Background(), &st, buildChecks(&spec, &q, "test", "diag-client", time.Now), start.Add(5*time.Minute), true)
+	assert.False(t, pending)
+	assert.Equal(t, diagv1alpha1.StagePassed, st.Stages[1].Status)
+	assert.Equal(t, diagv1alpha1.StagePassed, st.Stages[2].Status)
+	assert.Equal(t, diagv1alpha1.StageFailed, st.Stages[3].Status)
+	assert.Equal(t, "Generated traffic not observed before the timeout: connection refused", st.Stages[3].Message)
+	assert.Equal(t, hintExporter, st.Stages[3].Hint)
+
+	complete(&st, start.Add(5*time.Minute))
+	assert.Equal(t, diagv1alpha1.DiagnosticFailed, st.Phase)
+	assert.NotNil(t, st.CompletionTime)
+
+	st.Stages[3].Status = diagv1alpha1.StageSkipped
+	complete(&st, start.Add(5*time.Minute))
+	assert.Equal(t, diagv1alpha1.DiagnosticSucceeded, st.Phase)
+}
+
+func TestHTTPQuerier(t *testing.T) {
+	var tenant, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("X-Scope-OrgID")
+		path = r.URL.Path
+		if r.URL.Path == "/api/v1/query" {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"12.5"]}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[{"stream":{},"values":[["1700000000000000000","{}"]]}]}}`))
+	}))
+	defer server.Close()
+
+	q := httpQuerier{
+		loki: &endpoint{url: server.URL + "/", tenantID: "netobserv"},
+		prom: &endpoint{url: server.URL},
+	}
+	n, err := q.queryLoki(context.Background(), `{app="netobserv-flowcollector"}`, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "netobserv", tenant)
+	assert.Equal(t, "/loki/api/v1/query_range", path)
+
+	v, err := q.queryPrometheus(context.Background(), "sum(netobserv_namespace_flows_total)")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, v)
+	assert.Equal(t, "", tenant)
+
+	assert.NoError(t, q.dial(context.Background(), server.Listener.Addr().String()))
+}
+
+func TestRequeueAfter(t *testing.T) {
+	start := time.Now()
+	diag := newDiagnostic()
+	diag.Spec.Timeout = &metav1.Duration{Duration: time.Minute}
+	diag.Status.StartTime = &metav1.Time{Time: start}
+
+	// Pods starting: their events trigger reconciliations, only wait for the timeout
+	assert.Equal(t, 40*time.Second, requeueAfter(diag, start.Add(20*time.Second)))
+	assert.Equal(t, time.Second, requeueAfter(diag, start.Add(2*time.Minute)))
+
+	// Traffic started: outputs are polled
+	diag.Status.TrafficStartTime = &metav1.Time{Time: start}
+	assert.Equal(t, pollInterval, requeueAfter(diag, start.Add(20*time.Second)))
+}
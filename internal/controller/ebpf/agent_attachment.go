@@ -0,0 +1,74 @@
+package ebpf
+
+import (
+	"fmt"
+	"strings"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/pkg/manager/status"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Agent defaults, used when the corresponding env is not set
+var attachmentEnvDefaults = []struct {
+	env   string
+	label string
+	def   string
+}{
+	{env: envAttachMode, label: "mode", def: "tcx"},
+	{env: envTCXAnchorIngress, label: "ingressAnchor", def: "none"},
+	{env: envTCXAnchorEgress, label: "egressAnchor", def: "none"},
+	{env: envDirection, label: "direction", def: "both"},
+	{env: envListenInterfaces, label: "interfaceDiscovery", def: "watch"},
+}
+
+// getAttachmentCondition summarizes how each agent DaemonSet is configured to attach to the network interfaces.
+// It reflects the deployed configuration, not what the agents actually attached to: attach errors reported by the agents
+// are part of the per-node agent status.
+func getAttachmentCondition(spec *flowslatest.FlowCollectorEBPF, main, compat *v1.DaemonSet, nbCompatNodes int) metav1.Condition {
+	if spec.IsEbpfManagerEnabled() {
+		return metav1.Condition{
+			Type:    status.AgentConfiguredAttachment,
+			Reason:  "EbpfManager",
+			Status:  metav1.ConditionTrue,
+			Message: "Attachment is delegated to eBPF Manager",
+		}
+	}
+	msg := fmt.Sprintf("%s: %s", main.Name, describeAttachment(main))
+	if compat != nil {
+		msg += fmt.Sprintf("; %s (%d node(s)): %s", compat.Name, nbCompatNodes, describeAttachment(compat))
+	}
+	return metav1.Condition{
+		Type:    status.AgentConfiguredAttachment,
+		Reason:  "Configured",
+		Status:  metav1.ConditionTrue,
+		Message: msg,
+	}
+}
+
+func describeAttachment(ds *v1.DaemonSet) string {
+	var env []corev1.EnvVar
+	if len(ds.Spec.Template.Spec.Containers) > 0 {
+		env = ds.Spec.Template.Spec.Containers[0].Env
+	}
+	values := make(map[string]string)
+	for _, e := range env {
+		values[e.Name] = e.Value
+	}
+	var parts []string
+	for _, d := range attachmentEnvDefaults {
+		v, ok := values[d.env]
+		if !ok {
+			v = d.def
+		}
+		if d.env == envListenInterfaces && v == "poll" {
+			if period, ok := values[envListenPollPeriod]; ok {
+				v += " every " + period
+			}
+		}
+		parts = append(parts, d.label+"="+v)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,117 @@
+package ebpf
+
+import (
+	"context"
+	"slices"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const compatLabel = "agent-variant"
+
+// getCompatConfig returns, when the DisablePerNode policy applies, a copy of the FlowCollector where
+// the features that are not supported on some nodes are disabled, along with the names of these nodes.
+// It returns nil when every node is compatible, or when the policy is not set.
+func getCompatConfig(coll *flowslatest.FlowCollector, nodes []flowslatest.NodeKernelInfo) (*flowslatest.FlowCollector, []string) {
+	if coll.Spec.Agent.EBPF.UnsupportedFeaturesPolicy != flowslatest.UnsupportedFeaturesDisablePerNode {
+		return nil, nil
+	}
+	incompats := coll.Spec.Agent.EBPF.GetKernelIncompatibilities(nodes)
+	if len(incompats) == 0 {
+		return nil, nil
+	}
+	compat := coll.DeepCopy()
+	var nodeNames []string
+	for i := range incompats {
+		if incompats[i].Feature == flowslatest.TCXAttach {
+			compat.Spec.Agent.EBPF.Attachment.Mode = flowslatest.AttachModeTC
+			compat.Spec.Agent.EBPF.Attachment.TCXAnchorIngress = ""
+			compat.Spec.Agent.EBPF.Attachment.TCXAnchorEgress = ""
+			if compat.Spec.Agent.EBPF.Advanced != nil {
+				delete(compat.Spec.Agent.EBPF.Advanced.Env, envAttachMode)
+			}
+		} else {
+			compat.Spec.Agent.EBPF.Features = slices.DeleteFunc(compat.Spec.Agent.EBPF.Features, func(f flowslatest.AgentFeature) bool {
+				return f == incompats[i].Feature
+			})
+		}
+		for _, n := range incompats[i].Nodes {
+			if !slices.Contains(nodeNames, n) {
+				nodeNames = append(nodeNames, n)
+			}
+		}
+	}
+	slices.Sort(nodeNames)
+	return compat, nodeNames
+}
+
+// withNodeNamesAffinity returns a copy of the provided affinity, with an additional required node affinity on node names
+func withNodeNamesAffinity(affinity *corev1.Affinity, op corev1.NodeSelectorOperator, nodeNames []string) *corev1.Affinity {
+	var result *corev1.Affinity
+	if affinity != nil {
+		result = affinity.DeepCopy()
+	} else {
+		result = &corev1.Affinity{}
+	}
+	if result.NodeAffinity == nil {
+		result.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	req := corev1.NodeSelectorRequirement{
+		Key:      "metadata.name",
+		Operator: op,
+		Values:   nodeNames,
+	}
+	terms := result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		terms = []corev1.NodeSelectorTerm{{}}
+	}
+	// Terms are ORed: the requirement must be added to each of them
+	for i := range terms {
+		terms[i].MatchFields = append(terms[i].MatchFields, req)
+	}
+	result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = terms
+	return result
+}
+
+// reconcileCompatAgent deploys the agent with reduced features on the nodes that are not compatible with the full configuration,
+// or deletes it when not needed.
+// It returns the desired compat DaemonSet, if any.
+func (c *AgentController) reconcileCompatAgent(ctx context.Context, compat *flowslatest.FlowCollector, nodeNames []string) (*v1.DaemonSet, error) {
+	rlog := log.FromContext(ctx)
+	if compat == nil {
+		c.Managed.TryDelete(ctx, c.compatDS)
+		return nil, nil
+	}
+	desired, err := c.desired(ctx, compat)
+	if err != nil {
+		return nil, err
+	}
+	desired.Name = constants.EBPFAgentCompatName
+	desired.Labels[compatLabel] = "compat"
+	desired.Spec.Selector.MatchLabels[compatLabel] = "compat"
+	desired.Spec.Template.Labels[compatLabel] = "compat"
+	desired.Spec.Template.Spec.Affinity = withNodeNamesAffinity(desired.Spec.Template.Spec.Affinity, corev1.NodeSelectorOpIn, nodeNames)
+
+	var current = c.compatDS
+	if !c.Managed.Exists(c.compatDS) {
+		current = nil
+	}
+	switch helper.DaemonSetChanged(current, desired) {
+	case helper.ActionCreate:
+		rlog.Info("action: create compat agent", "nodes", nodeNames)
+		err = c.CreateOwned(ctx, desired)
+	case helper.ActionUpdate:
+		rlog.Info("action: update compat agent", "nodes", nodeNames)
+		err = c.UpdateIfOwned(ctx, current, desired)
+	default:
+	}
+	return desired, err
+}
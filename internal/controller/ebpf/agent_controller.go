@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	ebpfconfig "github.com/netobserv/netobserv-ebpf-agent/pkg/config"
 	ebpfmaps "github.com/netobserv/netobserv-ebpf-agent/pkg/maps"
@@ -13,6 +15,7 @@ import (
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/controller/ebpf/internal/permissions"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
+	"github.com/netobserv/network-observability-operator/internal/pkg/certs"
 	"github.com/netobserv/network-observability-operator/internal/pkg/cluster"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
 	"github.com/netobserv/network-observability-operator/internal/pkg/volumes"
@@ -38,6 +41,8 @@ const (
 	envFlowsTargetHost            = "TARGET_HOST"
 	envFlowsTargetPort            = "TARGET_PORT"
 	envTargetTLSCACertPath        = "TARGET_TLS_CA_CERT_PATH"
+	envTargetTLSUserCertPath      = "TARGET_TLS_USER_CERT_PATH"
+	envTargetTLSUserKeyPath       = "TARGET_TLS_USER_KEY_PATH"
 	envGRPCReconnect              = "GRPC_RECONNECT_TIMER"
 	envGRPCReconnectRnd           = "GRPC_RECONNECT_TIMER_RANDOMIZATION"
 	envSampling                   = "SAMPLING"
@@ -74,6 +79,11 @@ const (
 	envDNSTrackingPort            = "DNS_TRACKING_PORT"
 	envPreferredInterface         = "PREFERRED_INTERFACE_FOR_MAC_PREFIX"
 	envAttachMode                 = "TC_ATTACH_MODE"
+	envTCXAnchorIngress           = "TCX_ATTACH_ANCHOR_INGRESS"
+	envTCXAnchorEgress            = "TCX_ATTACH_ANCHOR_EGRESS"
+	envDirection                  = "DIRECTION"
+	envListenInterfaces           = "LISTEN_INTERFACES"
+	envListenPollPeriod           = "LISTEN_POLL_PERIOD"
 	envOVNObservHostMountPath     = "OVN_OBSERV_HOST_MOUNT_PATH"
 	envListSeparator              = ","
 )
@@ -87,6 +97,7 @@ const (
 	bpfNetNSMountName               = "var-run-netns"
 	bpfNetNSMountPath               = "/var/run/netns"
 	droppedFlowsAlertThreshold      = 100
+	mapFullAlertRatio               = "0.1"
 	ovnObservMountName              = "var-run-ovn"
 	ovnObservMountPath              = "/var/run/ovn"
 	ovnObservHostMountPathOpenShift = "/var/run/ovn-ic"
@@ -110,6 +121,8 @@ type AgentController struct {
 	*reconcilers.Instance
 	permissions    permissions.Reconciler
 	volumes        volumes.Builder
+	compatDS       *v1.DaemonSet
+	tlsSecret      *corev1.Secret
 	promSvc        *corev1.Service
 	serviceMonitor *monitoringv1.ServiceMonitor
 	prometheusRule *monitoringv1.PrometheusRule
@@ -120,6 +133,8 @@ func NewAgentController(common *reconcilers.Instance) *AgentController {
 	agent := AgentController{
 		Instance:    common,
 		permissions: permissions.NewReconciler(common),
+		compatDS:    common.Managed.NewDaemonSet(constants.EBPFAgentCompatName),
+		tlsSecret:   common.Managed.NewSecret(constants.EBPFAgentTLSSecretName),
 		promSvc:     common.Managed.NewService(constants.EBPFAgentMetricsSvcName),
 	}
 	if common.ClusterInfo.HasSvcMonitor() {
@@ -149,16 +164,36 @@ func (c *AgentController) Reconcile(ctx context.Context, target *flowslatest.Flo
 		return fmt.Errorf("reconciling permissions: %w", err)
 	}
 
+	if err := c.reconcileCertManager(ctx, target); err != nil {
+		return fmt.Errorf("reconciling cert-manager certificates: %w", err)
+	}
+
 	err = c.reconcileMetricsService(ctx, &target.Spec.Agent.EBPF)
 	if err != nil {
 		return fmt.Errorf("reconciling prometheus service: %w", err)
 	}
 
+	if err := c.reconcileAgentsTLS(ctx, target); err != nil {
+		return fmt.Errorf("reconciling agents TLS: %w", err)
+	}
+
 	desired, err := c.desired(ctx, target)
 	if err != nil {
 		return err
 	}
 
+	var compat *flowslatest.FlowCollector
+	var compatNodes []string
+	if nodes, err := c.ClusterInfo.GetNodesKernelInfo(); err != nil {
+		rlog.Error(err, "could not check nodes kernel compatibility")
+	} else {
+		compat, compatNodes = getCompatConfig(target, nodes)
+	}
+	if compat != nil {
+		// Exclude incompatible nodes from the main agent: they are covered by the compat agent
+		desired.Spec.Template.Spec.Affinity = withNodeNamesAffinity(desired.Spec.Template.Spec.Affinity, corev1.NodeSelectorOpNotIn, compatNodes)
+	}
+
 	switch helper.DaemonSetChanged(current, desired) {
 	case helper.ActionCreate:
 		rlog.Info("action: create agent")
@@ -176,10 +211,21 @@ func (c *AgentController) Reconcile(ctx context.Context, target *flowslatest.Flo
 		return err
 	}
 
+	compatDesired, err := c.reconcileCompatAgent(ctx, compat, compatNodes)
+	if err != nil {
+		return fmt.Errorf("reconciling compat agent: %w", err)
+	}
+	c.Status.SetCondition(getAttachmentCondition(&target.Spec.Agent.EBPF, desired, compatDesired, len(compatNodes)))
+
 	if target.Spec.Agent.EBPF.IsAgentFeatureEnabled(flowslatest.EbpfManager) {
 		if err := c.bpfmanAttachNetobserv(ctx, target); err != nil {
 			return fmt.Errorf("failed to attach netobserv: %w", err)
 		}
+		if err := c.bpfmanCheckStatus(ctx); err != nil {
+			return fmt.Errorf("failed to check eBPF Manager status: %w", err)
+		}
+	} else if err := c.bpfmanCleanup(ctx); err != nil {
+		return fmt.Errorf("failed to clean up eBPF Manager application: %w", err)
 	}
 	return nil
 }
@@ -238,6 +284,14 @@ func (c *AgentController) desired(ctx context.Context, coll *flowslatest.FlowCol
 				CertFile: "tls.crt",
 				CertKey:  "tls.key",
 			}
+			if c.ClusterInfo.UseCertManager() {
+				// Certificate issued by cert-manager: restart pods on renewal
+				digest, err := c.Watcher.ProcessCertRef(ctx, c.Client, promTLS, c.PrivilegedNamespace())
+				if err != nil {
+					return nil, fmt.Errorf("reading certificate %s issued by cert-manager: %w", promTLS.Name, err)
+				}
+				annotations[watchers.Annotation("prom-certs")] = digest
+			}
 		case flowslatest.ServerTLSDisabled:
 			// show never happens added for linting purposes
 		}
@@ -473,9 +527,42 @@ func (c *AgentController) envConfig(ctx context.Context, coll *flowslatest.FlowC
 	} else {
 		config = append(config, corev1.EnvVar{Name: envExport, Value: exportGRPC})
 		advancedConfig := helper.GetAdvancedProcessorConfig(&coll.Spec)
-		if coll.Spec.UseHostNetwork() {
+		mtls := helper.GetAgentsTLSConfig(&coll.Spec, c.ClusterInfo.UseCertManager())
+		if mtls != nil {
+			// Mutual TLS with flowlogs-pipeline, in any deployment model
+			var caPath, userCertPath, userKeyPath string
+			if c.useCertManagerAgentsTLS(coll) && c.ClusterInfo.HasCertManagerCSIDriver() {
+				// Each agent pod gets its own client certificate from the cert-manager CSI driver, along with the CA.
+				// Agents load it at startup: restart them periodically so that they get a fresh one before it expires
+				dir := c.volumes.AddCSI(constants.CertManagerCSIDriverName, certs.CSIVolumeAttributes(), "agents-tls")
+				caPath, userCertPath, userKeyPath = path.Join(dir, certs.CAFile), path.Join(dir, certs.CertFile), path.Join(dir, certs.KeyFile)
+				annots[watchers.Annotation("agents-tls-rotation")] = certs.CSIRotationEpoch(time.Now())
+			} else {
+				// Annotate pod with certificate references so that it is reloaded if modified
+				caDigest, err := c.Watcher.ProcessCertRef(ctx, c.Client, &mtls.ClientCA, c.PrivilegedNamespace())
+				if err != nil {
+					return nil, err
+				}
+				userDigest, err := c.Watcher.ProcessCertRef(ctx, c.Client, &mtls.Client, c.PrivilegedNamespace())
+				if err != nil {
+					return nil, err
+				}
+				annots[watchers.Annotation("agents-tls-ca")] = caDigest
+				annots[watchers.Annotation("agents-tls")] = userDigest
+
+				caPath, _ = c.volumes.AddCertificate(&mtls.ClientCA, "agents-tls-ca")
+				userCertPath, userKeyPath = c.volumes.AddCertificate(&mtls.Client, "agents-tls")
+			}
+			config = append(config,
+				corev1.EnvVar{Name: envTargetTLSCACertPath, Value: caPath},
+				corev1.EnvVar{Name: envTargetTLSUserCertPath, Value: userCertPath},
+				corev1.EnvVar{Name: envTargetTLSUserKeyPath, Value: userKeyPath},
+			)
+		}
+		if coll.Spec.UseHostNetwork() && mtls == nil {
 			// When flowlogs-pipeline is deployed as a daemonset, each agent must send
-			// data to the pod that is deployed in the same host
+			// data to the pod that is deployed in the same host. With mutual TLS, the service is used instead,
+			// with a node-local traffic policy, so that the server certificate doesn't depend on the node IPs
 			config = append(config, corev1.EnvVar{
 				Name: envFlowsTargetHost,
 				ValueFrom: &corev1.EnvVarSource{
@@ -490,7 +577,7 @@ func (c *AgentController) envConfig(ctx context.Context, coll *flowslatest.FlowC
 			})
 		} else {
 			skipTLS := flowslatest.IsEnvEnabled(advancedConfig.Env, "SERVER_NOTLS")
-			if !skipTLS {
+			if mtls == nil && !skipTLS {
 				// Send to FLP service using TLS
 				caConfigMapName := "flowlogs-pipeline-ca"
 				if c.ClusterInfo.IsOpenShift() {
@@ -504,6 +591,20 @@ func (c *AgentController) envConfig(ctx context.Context, coll *flowslatest.FlowC
 						CertFile: "service-ca.crt",
 					},
 				}
+				if c.ClusterInfo.UseCertManager() {
+					// CA from the certificate issued by cert-manager for flowlogs-pipeline, copied to the privileged namespace
+					tlsCfg.CACert = flowslatest.CertificateReference{
+						Type:      flowslatest.RefTypeSecret,
+						Name:      constants.FLPCertSecretName,
+						Namespace: c.Namespace,
+						CertFile:  certs.CAFile,
+					}
+					caDigest, err := c.Watcher.ProcessCertRef(ctx, c.Client, &tlsCfg.CACert, c.PrivilegedNamespace())
+					if err != nil {
+						return nil, fmt.Errorf("reading certificate %s issued by cert-manager: %w", constants.FLPCertSecretName, err)
+					}
+					annots[watchers.Annotation("svc-certs-ca")] = caDigest
+				}
 				caPath := c.volumes.AddCACertificate(&tlsCfg, "svc-certs")
 				config = append(config, corev1.EnvVar{Name: envTargetTLSCACertPath, Value: caPath})
 			}
@@ -796,17 +897,46 @@ func getEnvConfig(coll *flowslatest.FlowCollector, cinfo *cluster.Info) []corev1
 		},
 	})
 
-	defaultAttach := "tcx"
-	if old, _, _ := cinfo.IsOpenShiftVersionLessThan("4.16.0"); old {
-		defaultAttach = "tc"
+	attach := "tcx"
+	if coll.Spec.Agent.EBPF.Attachment.Mode != "" {
+		attach = strings.ToLower(string(coll.Spec.Agent.EBPF.Attachment.Mode))
+	} else if old, _, _ := cinfo.IsOpenShiftVersionLessThan("4.16.0"); old {
+		attach = "tc"
+	}
+
+	preferredInterface := defaultPreferredInterface
+	if len(coll.Spec.Agent.EBPF.InterfaceDiscovery.PreferredInterfaces) > 0 {
+		var pairs []string
+		for _, pref := range coll.Spec.Agent.EBPF.InterfaceDiscovery.PreferredInterfaces {
+			pairs = append(pairs, pref.MACPrefix+"="+pref.Interface)
+		}
+		preferredInterface = strings.Join(pairs, envListSeparator)
 	}
 
 	// Other default config that can be overriden by env
 	defaults := map[string]string{
 		envDNSTrackingPort:      defaultDNSTrackingPort,
 		envNetworkEventsGroupID: defaultNetworkEventsGroupID,
-		envPreferredInterface:   defaultPreferredInterface,
-		envAttachMode:           defaultAttach,
+		envPreferredInterface:   preferredInterface,
+		envAttachMode:           attach,
+	}
+	// Optional settings, only set when configured, so that the agent defaults apply otherwise
+	attachment := &coll.Spec.Agent.EBPF.Attachment
+	if attachment.TCXAnchorIngress != "" {
+		defaults[envTCXAnchorIngress] = strings.ToLower(string(attachment.TCXAnchorIngress))
+	}
+	if attachment.TCXAnchorEgress != "" {
+		defaults[envTCXAnchorEgress] = strings.ToLower(string(attachment.TCXAnchorEgress))
+	}
+	if attachment.Direction != "" {
+		defaults[envDirection] = strings.ToLower(string(attachment.Direction))
+	}
+	discovery := &coll.Spec.Agent.EBPF.InterfaceDiscovery
+	if discovery.Mode != "" {
+		defaults[envListenInterfaces] = strings.ToLower(string(discovery.Mode))
+	}
+	if discovery.PollPeriod != "" {
+		defaults[envListenPollPeriod] = discovery.PollPeriod
 	}
 	advancedConfig := helper.GetAdvancedAgentConfig(coll.Spec.Agent.EBPF.Advanced)
 	moreConfig := helper.BuildEnvFromDefaults(advancedConfig.Env, defaults)
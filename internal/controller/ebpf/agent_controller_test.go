@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	bpfmaniov1alpha1 "github.com/bpfman/bpfman-operator/apis/v1alpha1"
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
 	"github.com/netobserv/network-observability-operator/internal/pkg/cluster"
@@ -197,6 +198,66 @@ func TestGetEnvConfig_OCP4_14(t *testing.T) {
 	}, env)
 }
 
+func TestGetEnvConfig_AttachmentAndDiscovery(t *testing.T) {
+	fc := flowslatest.FlowCollector{
+		Spec: flowslatest.FlowCollectorSpec{
+			Agent: flowslatest.FlowCollectorAgent{
+				EBPF: flowslatest.FlowCollectorEBPF{
+					Metrics: flowslatest.EBPFMetrics{
+						Enable: ptr.To(false),
+					},
+					Attachment: flowslatest.EBPFAttachment{
+						Mode:             flowslatest.AttachModeAny,
+						TCXAnchorIngress: flowslatest.TCXAnchorHead,
+						Direction:        flowslatest.DirectionEgress,
+					},
+					InterfaceDiscovery: flowslatest.EBPFInterfaceDiscovery{
+						Mode:       flowslatest.InterfaceDiscoveryPoll,
+						PollPeriod: "30s",
+						PreferredInterfaces: []flowslatest.EBPFPreferredInterface{
+							{MACPrefix: "0a:58", Interface: "eth0"},
+							{MACPrefix: "0a:59", Interface: "ens5"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	info := cluster.Info{}
+	info.Mock("4.14.5", "")
+	env := getEnvConfig(&fc, &info)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "AGENT_IP", Value: "",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "status.hostIP",
+				},
+			}},
+		{Name: "DIRECTION", Value: "egress"},
+		{Name: "DNS_TRACKING_PORT", Value: "53"},
+		{Name: "LISTEN_INTERFACES", Value: "poll"},
+		{Name: "LISTEN_POLL_PERIOD", Value: "30s"},
+		{Name: "NETWORK_EVENTS_MONITORING_GROUP_ID", Value: "10"},
+		{Name: "PREFERRED_INTERFACE_FOR_MAC_PREFIX", Value: "0a:58=eth0,0a:59=ens5"},
+		{Name: "TCX_ATTACH_ANCHOR_INGRESS", Value: "head"},
+		{Name: "TC_ATTACH_MODE", Value: "any"},
+	}, env)
+
+	ds := appsv1.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{Name: "netobserv-ebpf-agent"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Env: env}}},
+			},
+		},
+	}
+	cond := getAttachmentCondition(&fc.Spec.Agent.EBPF, &ds, nil, 0)
+	assert.Equal(t, "Configured", cond.Reason)
+	assert.Equal(t, "netobserv-ebpf-agent: mode=any, ingressAnchor=head, egressAnchor=none, direction=egress, interfaceDiscovery=poll every 30s", cond.Message)
+}
+
 func TestBpfmanConfig(t *testing.T) {
 	fc := flowslatest.FlowCollector{
 		Spec: flowslatest.FlowCollectorSpec{
@@ -268,3 +329,160 @@ func TestNetworkEventsOVNMount(t *testing.T) {
 	assert.Equal(t, "var-run-ovn", ds.Spec.Template.Spec.Volumes[2].Name)
 	assert.Equal(t, "/foo/bar", ds.Spec.Template.Spec.Volumes[2].HostPath.Path)
 }
+
+func TestCompatConfig(t *testing.T) {
+	nodes := []flowslatest.NodeKernelInfo{
+		{Name: "node-a", KernelVersion: "6.12.0"},
+		{Name: "node-b", KernelVersion: "6.1.0-18-cloud-amd64"},
+		{Name: "node-c", KernelVersion: "5.19.0-50-generic"},
+	}
+	fc := flowslatest.FlowCollector{
+		Spec: flowslatest.FlowCollectorSpec{
+			Agent: flowslatest.FlowCollectorAgent{
+				EBPF: flowslatest.FlowCollectorEBPF{
+					Features: []flowslatest.AgentFeature{flowslatest.NetworkEvents, flowslatest.PacketTranslation, flowslatest.DNSTracking},
+				},
+			},
+		},
+	}
+
+	// Default policy: report only
+	compat, compatNodes := getCompatConfig(&fc, nodes)
+	assert.Nil(t, compat)
+	assert.Nil(t, compatNodes)
+
+	fc.Spec.Agent.EBPF.UnsupportedFeaturesPolicy = flowslatest.UnsupportedFeaturesDisablePerNode
+	compat, compatNodes = getCompatConfig(&fc, nodes)
+	assert.Equal(t, []string{"node-b", "node-c"}, compatNodes)
+	assert.Equal(t, []flowslatest.AgentFeature{flowslatest.PacketTranslation, flowslatest.DNSTracking}, compat.Spec.Agent.EBPF.Features)
+	assert.Equal(t, flowslatest.AttachModeTC, compat.Spec.Agent.EBPF.Attachment.Mode)
+	// Original is unchanged
+	assert.Len(t, fc.Spec.Agent.EBPF.Features, 3)
+	assert.Empty(t, fc.Spec.Agent.EBPF.Attachment.Mode)
+
+	// All nodes compatible
+	compat, compatNodes = getCompatConfig(&fc, nodes[:1])
+	assert.Nil(t, compat)
+	assert.Nil(t, compatNodes)
+}
+
+func TestWithNodeNamesAffinity(t *testing.T) {
+	aff := withNodeNamesAffinity(nil, corev1.NodeSelectorOpNotIn, []string{"node-b"})
+	assert.Equal(t, []corev1.NodeSelectorTerm{{
+		MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"node-b"}}},
+	}}, aff.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	existing := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}}},
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"b"}}}},
+				},
+			},
+		},
+	}
+	aff = withNodeNamesAffinity(existing, corev1.NodeSelectorOpIn, []string{"node-b"})
+	terms := aff.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.Len(t, terms, 2)
+	assert.Len(t, terms[0].MatchFields, 1)
+	assert.Len(t, terms[1].MatchFields, 1)
+	// Original is unchanged
+	assert.Empty(t, existing.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields)
+}
+
+func TestBpfApplicationCondition(t *testing.T) {
+	app := bpfmaniov1alpha1.ClusterBpfApplication{}
+	states := []bpfmaniov1alpha1.ClusterBpfApplicationState{
+		{Status: bpfmaniov1alpha1.ClBpfApplicationStateStatus{Node: "node-a", AppLoadStatus: bpfmaniov1alpha1.AppLoadSuccess}},
+		{Status: bpfmaniov1alpha1.ClBpfApplicationStateStatus{Node: "node-b", AppLoadStatus: bpfmaniov1alpha1.AppLoadNotLoaded}},
+	}
+
+	// No global condition yet: pending
+	cond := getBpfApplicationCondition(&app, states)
+	assert.Equal(t, "Pending", cond.Reason)
+	assert.Equal(t, v1.ConditionUnknown, cond.Status)
+	assert.Equal(t, "1 node(s) loaded, 0 with errors, 1 pending, 0 not selected", cond.Message)
+
+	// Success
+	states[1].Status.AppLoadStatus = bpfmaniov1alpha1.NotSelected
+	app.Status.Conditions = []v1.Condition{bpfmaniov1alpha1.BpfAppCondSuccess.Condition("")}
+	cond = getBpfApplicationCondition(&app, states)
+	assert.Equal(t, "Ready", cond.Reason)
+	assert.Equal(t, v1.ConditionFalse, cond.Status)
+	assert.Equal(t, "1 node(s) loaded, 0 with errors, 0 pending, 1 not selected", cond.Message)
+
+	// Node error
+	states[1].Status.AppLoadStatus = bpfmaniov1alpha1.AppLoadError
+	states[1].Status.Conditions = []v1.Condition{{Type: "Error", Message: "failed to attach kprobe"}}
+	app.Status.Conditions = []v1.Condition{bpfmaniov1alpha1.BpfAppCondError.Condition("")}
+	cond = getBpfApplicationCondition(&app, states)
+	assert.Equal(t, "Error", cond.Reason)
+	assert.Equal(t, v1.ConditionTrue, cond.Status)
+	assert.Equal(t, "1 node(s) loaded, 1 with errors, 0 pending, 0 not selected; errors: node-b: failed to attach kprobe", cond.Message)
+}
+
+func TestAgentPrometheusRule(t *testing.T) {
+	info := reconcilers.Common{Namespace: "netobserv", ClusterInfo: &cluster.Info{}}
+	inst := info.NewInstance(map[reconcilers.ImageRef]string{reconcilers.MainImage: "ebpf-agent"}, status.Instance{})
+	agent := NewAgentController(inst)
+
+	ebpf := flowslatest.FlowCollectorEBPF{}
+	rule := agent.agentPrometheusRule(&ebpf)
+	assert.Equal(t, "netobserv-privileged", rule.Namespace)
+	rules := rule.Spec.Groups[0].Rules
+	var names []string
+	for _, r := range rules {
+		names = append(names, r.Alert)
+		assert.Equal(t, "warning", r.Labels["severity"])
+	}
+	assert.Equal(t, []string{"NetObservDroppedFlows", "NetObservAgentMapFull", "NetObservAgentExporterErrors", "NetObservAgentNoFlows"}, names)
+	assert.Equal(t,
+		`sum(rate(netobserv_agent_evicted_flows_total{source="accounter"}[5m])) by (pod) / sum(rate(netobserv_agent_evicted_flows_total{source="hashmap"}[5m])) by (pod) > 0.1`,
+		rules[1].Expr.StrVal,
+	)
+	assert.Equal(t, "https://github.com/netobserv/network-observability-operator/blob/main/docs/runbooks/NetObservAgentMapFull.md", rules[1].Annotations["runbook_url"])
+
+	ebpf.Metrics.DisableAlerts = []flowslatest.EBPFAgentAlert{flowslatest.AlertDroppedFlows, flowslatest.AlertAgentNoFlows}
+	rules = agent.agentPrometheusRule(&ebpf).Spec.Groups[0].Rules
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "NetObservAgentMapFull", rules[0].Alert)
+	assert.Equal(t, "NetObservAgentExporterErrors", rules[1].Alert)
+}
+
+func TestAgentsTLSCertManagerCSI(t *testing.T) {
+	fc := flowslatest.FlowCollector{
+		Spec: flowslatest.FlowCollectorSpec{
+			Processor: flowslatest.FlowCollectorFLP{
+				AgentsTLS: flowslatest.FLPAgentsTLS{Mode: flowslatest.FLPAgentsTLSMutual, Certificates: flowslatest.FLPAgentsCertificatesAuto},
+			},
+		},
+	}
+
+	info := reconcilers.Common{Namespace: "netobserv", ClusterInfo: &cluster.Info{}}
+	info.ClusterInfo.MockCertManager(true)
+	info.ClusterInfo.MockCertManagerCSIDriver(true)
+	inst := info.NewInstance(map[reconcilers.ImageRef]string{reconcilers.MainImage: "ebpf-agent"}, status.Instance{})
+	agent := NewAgentController(inst)
+	ds, err := agent.desired(context.Background(), &fc)
+	assert.NoError(t, err)
+	assert.NotNil(t, ds)
+
+	// Each pod gets its own client certificate from the CSI driver
+	var csi *corev1.CSIVolumeSource
+	for _, v := range ds.Spec.Template.Spec.Volumes {
+		if v.Name == "agents-tls" {
+			csi = v.CSI
+		}
+	}
+	assert.NotNil(t, csi)
+	assert.Equal(t, "csi.cert-manager.io", csi.Driver)
+	assert.Equal(t, "${POD_NAME}.${POD_NAMESPACE}", csi.VolumeAttributes["csi.cert-manager.io/common-name"])
+	assert.Equal(t, "netobserv-serving-ca", csi.VolumeAttributes["csi.cert-manager.io/issuer-name"])
+	env := ds.Spec.Template.Spec.Containers[0].Env
+	assert.Contains(t, env, corev1.EnvVar{Name: envTargetTLSCACertPath, Value: "/var/agents-tls/ca.crt"})
+	assert.Contains(t, env, corev1.EnvVar{Name: envTargetTLSUserCertPath, Value: "/var/agents-tls/tls.crt"})
+	assert.Contains(t, env, corev1.EnvVar{Name: envTargetTLSUserKeyPath, Value: "/var/agents-tls/tls.key"})
+	// Pods are periodically restarted to renew their certificate
+	assert.Contains(t, ds.Spec.Template.Annotations, "flows.netobserv.io/watched-agents-tls-rotation")
+}
@@ -0,0 +1,418 @@
+package ebpf
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+	"github.com/netobserv/network-observability-operator/internal/pkg/manager/status"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	healthCheckInterval  = time.Minute
+	healthScrapeTimeout  = 5 * time.Second
+	healthScrapeWorkers  = 10
+	healthMaxListedNodes = 10
+	recentRestartDelay   = 10 * time.Minute
+
+	metricEvictedFlows = "netobserv_agent_evicted_flows_total"
+	metricDroppedFlows = "netobserv_agent_dropped_flows_total"
+	metricErrors       = "netobserv_agent_errors_total"
+)
+
+// agentCounters holds the agent metrics relevant for health reporting, scraped at a given time.
+type agentCounters struct {
+	time         time.Time
+	evicted      float64
+	dropped      float64
+	attachErrors float64
+}
+
+// HealthMonitor periodically aggregates per-node information about the eBPF agents (pods readiness, restarts,
+// and metrics scraped from each agent such as flows per second and drop ratio), and reports it in FlowCollector status.
+type HealthMonitor struct {
+	client client.Client
+	reader client.Reader
+	status *status.Manager
+	// previous holds the counters from the previous check, keyed by pod UID
+	previous map[string]*agentCounters
+}
+
+// NewHealthMonitor creates a HealthMonitor. Pods, and the CA used to scrape the agents metrics, are read with `reader`,
+// which is expected to be non-cached to avoid watching every pod of the cluster.
+func NewHealthMonitor(cl client.Client, reader client.Reader, st *status.Manager) *HealthMonitor {
+	return &HealthMonitor{
+		client: cl,
+		reader: reader,
+		status: st,
+	}
+}
+
+// Run checks the agents health periodically, until the context is cancelled.
+func (m *HealthMonitor) Run(ctx context.Context) error {
+	m.check(ctx)
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+func (m *HealthMonitor) check(ctx context.Context) {
+	rlog := log.FromContext(ctx).WithName("agent-health")
+	fc := flowslatest.FlowCollector{}
+	if err := m.client.Get(ctx, constants.FlowCollectorName, &fc); err != nil {
+		if !kerr.IsNotFound(err) {
+			rlog.Error(err, "failed to get FlowCollector")
+		}
+		m.previous = nil
+		return
+	}
+	if fc.Spec.Agent.Type != flowslatest.AgentEBPF {
+		m.previous = nil
+		if fc.Status.Agent != nil {
+			m.status.SetAgentStatus(nil)
+			m.status.Sync(ctx, m.client)
+		}
+		return
+	}
+	pods := corev1.PodList{}
+	if err := m.reader.List(ctx, &pods,
+		client.InNamespace(fc.Spec.GetNamespace()+constants.EBPFPrivilegedNSSuffix),
+		client.MatchingLabels{"app": constants.EBPFAgentName},
+	); err != nil {
+		rlog.Error(err, "failed to list agent pods")
+		return
+	}
+	var current map[string]*agentCounters
+	var scrapeErrors map[string]error
+	if fc.Spec.Agent.EBPF.IsEBPFMetricsEnabled() {
+		if httpClient, err := m.newHTTPClient(ctx, &fc); err != nil {
+			rlog.Error(err, "can't scrape agent metrics")
+		} else {
+			current, scrapeErrors = m.scrapeAll(ctx, httpClient, &fc.Spec.Agent.EBPF, pods.Items)
+		}
+	}
+	m.status.SetAgentStatus(buildAgentStatus(pods.Items, current, m.previous, scrapeErrors, time.Now()))
+	m.previous = current
+	m.status.Sync(ctx, m.client)
+}
+
+// newHTTPClient returns the client used to scrape the agents metrics. When the metrics server uses TLS, it trusts the same CA
+// as the agent ServiceMonitor. Agents are reached via their pod IP, so the expected server name is the one of the metrics service.
+func (m *HealthMonitor) newHTTPClient(ctx context.Context, fc *flowslatest.FlowCollector) (*http.Client, error) {
+	httpClient := http.Client{Timeout: healthScrapeTimeout}
+	serverTLS := &fc.Spec.Agent.EBPF.Metrics.Server.TLS
+	if !useMetricsTLS(serverTLS) {
+		return &httpClient, nil
+	}
+	privilegedNamespace := fc.Spec.GetNamespace() + constants.EBPFPrivilegedNSSuffix
+	tlsConfig := tls.Config{
+		ServerName: fmt.Sprintf("%s.%s.svc", constants.EBPFAgentMetricsSvcName, privilegedNamespace),
+		MinVersion: tls.VersionTLS12,
+	}
+	var ca string
+	switch serverTLS.Type {
+	case flowslatest.ServerTLSAuto:
+		// Certificates issued by cert-manager come with their CA, otherwise they are issued by the OpenShift service CA
+		var err error
+		ca, err = m.readFile(ctx, &flowslatest.FileReference{Type: flowslatest.RefTypeSecret, Name: constants.EBPFAgentMetricsSvcName, File: "ca.crt"}, privilegedNamespace)
+		if err != nil && !kerr.IsNotFound(err) {
+			return nil, err
+		}
+		if ca == "" {
+			if ca, err = m.readFile(ctx, &flowslatest.FileReference{Type: flowslatest.RefTypeConfigMap, Name: "openshift-service-ca.crt", File: "service-ca.crt"}, privilegedNamespace); err != nil {
+				return nil, err
+			}
+		}
+	case flowslatest.ServerTLSProvided:
+		if serverTLS.InsecureSkipVerify {
+			// Explicitly requested, as for the ServiceMonitor
+			tlsConfig.InsecureSkipVerify = true
+		} else if serverTLS.ProvidedCaFile != nil && serverTLS.ProvidedCaFile.File != "" {
+			var err error
+			if ca, err = m.readFile(ctx, serverTLS.ProvidedCaFile, fc.Spec.GetNamespace()); err != nil {
+				return nil, err
+			}
+		}
+	case flowslatest.ServerTLSDisabled:
+		// unreachable, handled above
+	}
+	if ca != "" {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("no valid CA certificate found for agent metrics")
+		}
+	}
+	httpClient.Transport = &http.Transport{TLSClientConfig: &tlsConfig}
+	return &httpClient, nil
+}
+
+func useMetricsTLS(serverTLS *flowslatest.ServerTLS) bool {
+	return serverTLS.Type != flowslatest.ServerTLSDisabled && serverTLS.Type != ""
+}
+
+// readFile returns the content of a file from a ConfigMap or a Secret, looked up in defaultNamespace when the reference has no namespace.
+func (m *HealthMonitor) readFile(ctx context.Context, ref *flowslatest.FileReference, defaultNamespace string) (string, error) {
+	ns := ref.Namespace
+	if ns == "" {
+		ns = defaultNamespace
+	}
+	key := client.ObjectKey{Name: ref.Name, Namespace: ns}
+	if ref.Type == flowslatest.RefTypeConfigMap {
+		cm := corev1.ConfigMap{}
+		if err := m.reader.Get(ctx, key, &cm); err != nil {
+			return "", fmt.Errorf("can't read ConfigMap %s/%s: %w", ns, ref.Name, err)
+		}
+		return cm.Data[ref.File], nil
+	}
+	secret := corev1.Secret{}
+	if err := m.reader.Get(ctx, key, &secret); err != nil {
+		return "", fmt.Errorf("can't read Secret %s/%s: %w", ns, ref.Name, err)
+	}
+	return string(secret.Data[ref.File]), nil
+}
+
+// scrapeAll reads the metrics of every running agent pod, with bounded concurrency. Results and errors are keyed by pod UID.
+func (m *HealthMonitor) scrapeAll(ctx context.Context, httpClient *http.Client, spec *flowslatest.FlowCollectorEBPF, pods []corev1.Pod) (map[string]*agentCounters, map[string]error) {
+	scheme := "http"
+	if useMetricsTLS(&spec.Metrics.Server.TLS) {
+		scheme = "https"
+	}
+	port := strconv.Itoa(int(spec.GetMetricsPort()))
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]*agentCounters{}
+	errs := map[string]error{}
+	sem := make(chan struct{}, healthScrapeWorkers)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		url := fmt.Sprintf("%s://%s/metrics", scheme, net.JoinHostPort(pod.Status.PodIP, port))
+		uid := string(pod.UID)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			counters, err := scrape(ctx, httpClient, url)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs[uid] = err
+			} else {
+				results[uid] = counters
+			}
+		}()
+	}
+	wg.Wait()
+	return results, errs
+}
+
+func scrape(ctx context.Context, httpClient *http.Client, url string) (*agentCounters, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return countersFromFamilies(families, time.Now()), nil
+}
+
+func countersFromFamilies(families map[string]*dto.MetricFamily, now time.Time) *agentCounters {
+	counters := agentCounters{time: now}
+	if f, ok := families[metricEvictedFlows]; ok {
+		counters.evicted = sumCounters(f, nil)
+	}
+	if f, ok := families[metricDroppedFlows]; ok {
+		counters.dropped = sumCounters(f, nil)
+	}
+	if f, ok := families[metricErrors]; ok {
+		counters.attachErrors = sumCounters(f, func(m *dto.Metric) bool {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "error" && strings.Contains(strings.ToLower(l.GetValue()), "attach") {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return &counters
+}
+
+func sumCounters(f *dto.MetricFamily, filter func(*dto.Metric) bool) float64 {
+	var sum float64
+	for _, m := range f.GetMetric() {
+		if filter == nil || filter(m) {
+			sum += m.GetCounter().GetValue()
+		}
+	}
+	return sum
+}
+
+// counterDelta returns the increase of a counter, taking counter resets into account.
+func counterDelta(current, previous float64) float64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
+
+// selectAgentPods returns, for each node, the agent pod to consider: pods being deleted during a rollout are ignored
+// when a newer pod exists on the same node.
+func selectAgentPods(pods []corev1.Pod) []*corev1.Pod {
+	byNode := map[string]*corev1.Pod{}
+	var unscheduled []*corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" {
+			unscheduled = append(unscheduled, pod)
+			continue
+		}
+		if existing, ok := byNode[pod.Spec.NodeName]; ok {
+			if pod.DeletionTimestamp != nil || (existing.DeletionTimestamp == nil && existing.CreationTimestamp.After(pod.CreationTimestamp.Time)) {
+				continue
+			}
+		}
+		byNode[pod.Spec.NodeName] = pod
+	}
+	result := unscheduled
+	for _, pod := range byNode {
+		result = append(result, pod)
+	}
+	return result
+}
+
+func getPodHealth(pod *corev1.Pod, now time.Time) (ready bool, restarts int32, issues []string) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			ready = c.Status == corev1.ConditionTrue
+		}
+	}
+	if pod.Spec.NodeName == "" {
+		issues = append(issues, "pod not scheduled")
+	} else if !ready {
+		issues = append(issues, "pod not ready")
+	}
+	for i := range pod.Status.ContainerStatuses {
+		cs := &pod.Status.ContainerStatuses[i]
+		restarts += cs.RestartCount
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" && cs.State.Waiting.Reason != "ContainerCreating" {
+			issues = append(issues, fmt.Sprintf("%s: %s", cs.Name, cs.State.Waiting.Reason))
+		}
+		if t := cs.LastTerminationState.Terminated; t != nil && now.Sub(t.FinishedAt.Time) < recentRestartDelay {
+			issues = append(issues, fmt.Sprintf("%s restarted recently (%s)", cs.Name, t.Reason))
+		}
+	}
+	return ready, restarts, issues
+}
+
+// buildAgentStatus aggregates the pods information and the scraped metrics into the FlowCollector status section.
+// Rates are computed from the difference with the previous counters of the same pod, so they are not available on the first check.
+func buildAgentStatus(
+	pods []corev1.Pod,
+	current, previous map[string]*agentCounters,
+	scrapeErrors map[string]error,
+	now time.Time,
+) *flowslatest.FlowCollectorAgentStatus {
+	st := flowslatest.FlowCollectorAgentStatus{LastUpdateTime: metav1.NewTime(now)}
+	var withIssues []flowslatest.FlowCollectorAgentNodeStatus
+	for _, pod := range selectAgentPods(pods) {
+		ready, restarts, issues := getPodHealth(pod, now)
+		node := flowslatest.FlowCollectorAgentNodeStatus{
+			Node:     pod.Spec.NodeName,
+			Pod:      pod.Name,
+			Ready:    ready,
+			Restarts: restarts,
+		}
+		st.DesiredNodes++
+		if ready {
+			st.ReadyNodes++
+		}
+		uid := string(pod.UID)
+		if err, ok := scrapeErrors[uid]; ok && ready {
+			issues = append(issues, fmt.Sprintf("metrics unavailable: %s", err.Error()))
+		}
+		if cur, ok := current[uid]; ok {
+			if prev, ok := previous[uid]; ok && cur.time.After(prev.time) {
+				evicted := counterDelta(cur.evicted, prev.evicted)
+				dropped := counterDelta(cur.dropped, prev.dropped)
+				node.FlowsPerSecond = strconv.FormatFloat(evicted/cur.time.Sub(prev.time).Seconds(), 'f', 1, 64)
+				if evicted+dropped > 0 {
+					node.DropRatio = strconv.FormatFloat(100*dropped/(evicted+dropped), 'f', 2, 64) + "%"
+				}
+				if counterDelta(cur.attachErrors, prev.attachErrors) > 0 {
+					issues = append(issues, "eBPF attach errors")
+				}
+				if evicted == 0 && ready {
+					st.NodesWithoutFlows++
+					issues = append(issues, "no flows exported")
+				}
+			} else if cur.attachErrors > 0 {
+				issues = append(issues, "eBPF attach errors")
+			}
+		}
+		if len(issues) > 0 {
+			node.Issues = issues
+			withIssues = append(withIssues, node)
+		}
+	}
+	// Not ready first, then by node name
+	sort.Slice(withIssues, func(i, j int) bool {
+		if withIssues[i].Ready != withIssues[j].Ready {
+			return !withIssues[i].Ready
+		}
+		return withIssues[i].Node+withIssues[i].Pod < withIssues[j].Node+withIssues[j].Pod
+	})
+	nbWithIssues := len(withIssues)
+	if len(withIssues) > healthMaxListedNodes {
+		withIssues = withIssues[:healthMaxListedNodes]
+	}
+	st.NodesWithIssues = withIssues
+
+	summary := []string{fmt.Sprintf("%d/%d ready", st.ReadyNodes, st.DesiredNodes)}
+	if st.NodesWithoutFlows > 0 {
+		summary = append(summary, fmt.Sprintf("%d without flows", st.NodesWithoutFlows))
+	}
+	if nbWithIssues > 0 {
+		summary = append(summary, fmt.Sprintf("%d with issues", nbWithIssues))
+	}
+	st.Summary = strings.Join(summary, ", ")
+	return &st
+}
@@ -0,0 +1,186 @@
+package ebpf
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/pkg/certs"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// secretsReader is a client.Reader serving the provided secrets
+type secretsReader struct {
+	client.Reader
+	secrets map[client.ObjectKey]*corev1.Secret
+}
+
+func (r *secretsReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	secret, ok := r.secrets[key]
+	if !ok {
+		return kerr.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+	}
+	secret.DeepCopyInto(obj.(*corev1.Secret))
+	return nil
+}
+
+func agentPod(name, node string, ready bool, restarts int32) corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name)},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "netobserv-ebpf-agent", RestartCount: restarts}},
+		},
+	}
+}
+
+func TestCountersFromMetrics(t *testing.T) {
+	text := `# TYPE netobserv_agent_evicted_flows_total counter
+netobserv_agent_evicted_flows_total{reason="timer",source="hashmap"} 100
+netobserv_agent_evicted_flows_total{reason="full",source="accounter"} 20
+# TYPE netobserv_agent_dropped_flows_total counter
+netobserv_agent_dropped_flows_total{reason="CannotUpdateMap",source="hashmap"} 5
+# TYPE netobserv_agent_errors_total counter
+netobserv_agent_errors_total{component="ebpf",error="CannotAttachTCX",severity="high"} 2
+netobserv_agent_errors_total{component="exporter",error="CannotWriteMessage",severity="medium"} 7
+`
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(strings.NewReader(text))
+	require.NoError(t, err)
+
+	now := time.Now()
+	counters := countersFromFamilies(families, now)
+	assert.Equal(t, agentCounters{time: now, evicted: 120, dropped: 5, attachErrors: 2}, *counters)
+}
+
+func TestBuildAgentStatus(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Minute)
+	crashing := agentPod("agent-c", "node-c", false, 4)
+	crashing.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+	crashing.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{
+		Reason:     "Error",
+		FinishedAt: metav1.NewTime(now.Add(-time.Minute)),
+	}
+	pods := []corev1.Pod{
+		agentPod("agent-a", "node-a", true, 0),
+		agentPod("agent-b", "node-b", true, 1),
+		crashing,
+		agentPod("agent-d", "node-d", true, 0),
+	}
+	previous := map[string]*agentCounters{
+		"agent-a": {time: before, evicted: 1000, dropped: 0},
+		"agent-b": {time: before, evicted: 500, dropped: 0},
+	}
+	current := map[string]*agentCounters{
+		"agent-a": {time: now, evicted: 1600, dropped: 6},
+		"agent-b": {time: now, evicted: 500, dropped: 0},
+		"agent-d": {time: now, evicted: 10, attachErrors: 1},
+	}
+
+	// First check: no previous counters, so no rate
+	st := buildAgentStatus(pods, current, nil, nil, now)
+	assert.Equal(t, "3/4 ready, 2 with issues", st.Summary)
+	assert.Equal(t, int32(4), st.DesiredNodes)
+	assert.Equal(t, int32(3), st.ReadyNodes)
+	assert.Equal(t, int32(0), st.NodesWithoutFlows)
+	require.Len(t, st.NodesWithIssues, 2)
+	assert.Equal(t, "node-c", st.NodesWithIssues[0].Node)
+	assert.False(t, st.NodesWithIssues[0].Ready)
+	assert.Equal(t, int32(4), st.NodesWithIssues[0].Restarts)
+	assert.Equal(t, []string{"pod not ready", "netobserv-ebpf-agent: CrashLoopBackOff", "netobserv-ebpf-agent restarted recently (Error)"}, st.NodesWithIssues[0].Issues)
+	assert.Equal(t, "node-d", st.NodesWithIssues[1].Node)
+	assert.Equal(t, []string{"eBPF attach errors"}, st.NodesWithIssues[1].Issues)
+
+	// Second check: node-b exports nothing
+	st = buildAgentStatus(pods, current, previous, nil, now)
+	assert.Equal(t, "3/4 ready, 1 without flows, 3 with issues", st.Summary)
+	assert.Equal(t, int32(1), st.NodesWithoutFlows)
+	require.Len(t, st.NodesWithIssues, 3)
+	assert.Equal(t, "node-b", st.NodesWithIssues[1].Node)
+	assert.Equal(t, "0.0", st.NodesWithIssues[1].FlowsPerSecond)
+	assert.Equal(t, []string{"no flows exported"}, st.NodesWithIssues[1].Issues)
+}
+
+func TestBuildAgentStatus_Rollout(t *testing.T) {
+	now := time.Now()
+	old := agentPod("agent-old", "node-a", false, 0)
+	old.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+	old.DeletionTimestamp = &old.CreationTimestamp
+	newer := agentPod("agent-new", "node-a", true, 0)
+	newer.CreationTimestamp = metav1.NewTime(now)
+
+	st := buildAgentStatus([]corev1.Pod{old, newer}, nil, nil, nil, now)
+	assert.Equal(t, "1/1 ready", st.Summary)
+	assert.Empty(t, st.NodesWithIssues)
+}
+
+func TestScrapeWithAgentCA(t *testing.T) {
+	now := time.Now()
+	caCert, caKey, err := certs.GenerateCA("ca", now)
+	require.NoError(t, err)
+	cert, key, err := certs.Issue(caCert, caKey, &certs.Request{
+		CommonName: "ebpf-agent-svc-prom.netobserv-privileged.svc",
+		DNSNames:   []string{"ebpf-agent-svc-prom.netobserv-privileged.svc"},
+	}, now)
+	require.NoError(t, err)
+	keyPair, err := tls.X509KeyPair(cert, key)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("# TYPE netobserv_agent_evicted_flows_total counter\nnetobserv_agent_evicted_flows_total 10\n"))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{keyPair}}
+	server.StartTLS()
+	defer server.Close()
+
+	fc := flowslatest.FlowCollector{Spec: flowslatest.FlowCollectorSpec{Namespace: "netobserv"}}
+	fc.Spec.Agent.EBPF.Metrics.Server.TLS.Type = flowslatest.ServerTLSAuto
+	reader := secretsReader{secrets: map[client.ObjectKey]*corev1.Secret{
+		{Name: "ebpf-agent-svc-prom", Namespace: "netobserv-privileged"}: {Data: map[string][]byte{"ca.crt": caCert}},
+	}}
+	m := NewHealthMonitor(nil, &reader, nil)
+
+	// Trusting the CA of the issued certificate
+	httpClient, err := m.newHTTPClient(context.Background(), &fc)
+	require.NoError(t, err)
+	counters, err := scrape(context.Background(), httpClient, server.URL+"/metrics")
+	require.NoError(t, err)
+	assert.Equal(t, float64(10), counters.evicted)
+
+	// Any other CA is rejected
+	otherCA, _, err := certs.GenerateCA("other", now)
+	require.NoError(t, err)
+	reader.secrets[client.ObjectKey{Name: "ebpf-agent-svc-prom", Namespace: "netobserv-privileged"}].Data["ca.crt"] = otherCA
+	httpClient, err = m.newHTTPClient(context.Background(), &fc)
+	require.NoError(t, err)
+	_, err = scrape(context.Background(), httpClient, server.URL+"/metrics")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate signed by unknown authority")
+
+	// Plain HTTP when TLS is disabled
+	fc.Spec.Agent.EBPF.Metrics.Server.TLS.Type = flowslatest.ServerTLSDisabled
+	httpClient, err = m.newHTTPClient(context.Background(), &fc)
+	require.NoError(t, err)
+	assert.Nil(t, httpClient.Transport)
+}
@@ -7,7 +7,9 @@ import (
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
+	"github.com/netobserv/network-observability-operator/internal/pkg/certs"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics/alerts"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -51,6 +53,14 @@ func (c *AgentController) reconcileMetricsService(ctx context.Context, target *f
 	return nil
 }
 
+// metricsCertificates returns the metrics serving certificate to request to cert-manager when the OpenShift service CA isn't available
+func (c *AgentController) metricsCertificates(target *flowslatest.FlowCollectorEBPF) (wanted []certs.ServingCertificate, unused []string) {
+	if c.ClusterInfo.UseCertManager() && target.IsEBPFMetricsEnabled() && target.Metrics.Server.TLS.Type == flowslatest.ServerTLSAuto {
+		return []certs.ServingCertificate{{SecretName: constants.EBPFAgentMetricsSvcName, ServiceName: constants.EBPFAgentMetricsSvcName}}, nil
+	}
+	return nil, []string{constants.EBPFAgentMetricsSvcName}
+}
+
 func (c *AgentController) promService(target *flowslatest.FlowCollectorEBPF) *corev1.Service {
 	port := target.GetMetricsPort()
 	svc := corev1.Service{
@@ -84,7 +94,11 @@ func (c *AgentController) promService(target *flowslatest.FlowCollectorEBPF) *co
 
 func (c *AgentController) promServiceMonitoring(target *flowslatest.FlowCollectorEBPF, useEndpointSlices bool) *monitoringv1.ServiceMonitor {
 	serverName := fmt.Sprintf("%s.%s.svc", constants.EBPFAgentMetricsSvcName, c.PrivilegedNamespace())
-	scheme, smTLS := helper.GetServiceMonitorTLSConfig(&target.Metrics.Server.TLS, serverName, c.IsDownstream)
+	var certManagerSecret string
+	if c.ClusterInfo.UseCertManager() {
+		certManagerSecret = constants.EBPFAgentMetricsSvcName
+	}
+	scheme, smTLS := helper.GetServiceMonitorTLSConfig(&target.Metrics.Server.TLS, serverName, certManagerSecret, c.IsDownstream)
 	var sdRole *monitoringv1.ServiceDiscoveryRole
 	if useEndpointSlices {
 		sdRole = ptr.To(monitoringv1.EndpointSliceRole)
@@ -125,6 +139,10 @@ func (c *AgentController) promServiceMonitoring(target *flowslatest.FlowCollecto
 func (c *AgentController) agentPrometheusRule(target *flowslatest.FlowCollectorEBPF) *monitoringv1.PrometheusRule {
 	rules := []monitoringv1.Rule{}
 	d := monitoringv1.Duration("10m")
+	labels := map[string]string{
+		"severity": "warning",
+		"app":      "netobserv",
+	}
 
 	// EBPF hashmap table is full Not receiving any new flows
 	if shouldAddAlert(flowslatest.AlertDroppedFlows, target.Metrics.DisableAlerts) {
@@ -135,12 +153,57 @@ func (c *AgentController) agentPrometheusRule(target *flowslatest.FlowCollectorE
 				"description": "NetObserv eBPF agent is missing packets or flows. The metric netobserv_agent_dropped_flows_total provides more information on the cause. Possible reasons are the BPF hashmap being busy or full, or the capacity limiter being triggered. This may be worked around by increasing cacheMaxFlows value in Flowcollector resource.",
 				"summary":     "NetObserv eBPF agent is missing packets or flows",
 			},
-			Expr: intstr.FromString(fmt.Sprintf("sum(rate(netobserv_agent_dropped_flows_total[1m])) > %d", droppedFlowsAlertThreshold)),
-			For:  &d,
-			Labels: map[string]string{
-				"severity": "warning",
-				"app":      "netobserv",
+			Expr:   intstr.FromString(fmt.Sprintf("sum(rate(netobserv_agent_dropped_flows_total[1m])) > %d", droppedFlowsAlertThreshold)),
+			For:    &d,
+			Labels: labels,
+		})
+	}
+
+	// When the hashmap is full, flows are evicted through the ring buffer
+	if shouldAddAlert(flowslatest.AlertAgentMapFull, target.Metrics.DisableAlerts) {
+		rules = append(rules, monitoringv1.Rule{
+			Alert: string(flowslatest.AlertAgentMapFull),
+			Annotations: map[string]string{
+				"description": "NetObserv eBPF agent {{ $labels.pod }} BPF hashmap is often full, so flows are sent through the ring buffer, which is less efficient and can overflow. This may be worked around by increasing cacheMaxFlows value in Flowcollector resource.",
+				"summary":     "NetObserv eBPF agent hashmap is full",
+				"runbook_url": alerts.BuildNetObservRunbookURL(string(flowslatest.AlertAgentMapFull)),
+			},
+			Expr: intstr.FromString(fmt.Sprintf(
+				`sum(rate(netobserv_agent_evicted_flows_total{source="accounter"}[5m])) by (pod) / sum(rate(netobserv_agent_evicted_flows_total{source="hashmap"}[5m])) by (pod) > %s`,
+				mapFullAlertRatio,
+			)),
+			For:    &d,
+			Labels: labels,
+		})
+	}
+
+	if shouldAddAlert(flowslatest.AlertAgentExporterErrors, target.Metrics.DisableAlerts) {
+		rules = append(rules, monitoringv1.Rule{
+			Alert: string(flowslatest.AlertAgentExporterErrors),
+			Annotations: map[string]string{
+				"description": "NetObserv eBPF agent {{ $labels.pod }} fails to write flows to its exporter, such as flowlogs-pipeline or Kafka. The metric netobserv_agent_errors_total provides more information on the cause.",
+				"summary":     "NetObserv eBPF agent fails to export flows",
+				"runbook_url": alerts.BuildNetObservRunbookURL(string(flowslatest.AlertAgentExporterErrors)),
+			},
+			Expr:   intstr.FromString(`sum(rate(netobserv_agent_errors_total{component="exporter"}[5m])) by (pod) > 0`),
+			For:    &d,
+			Labels: labels,
+		})
+	}
+
+	// Each agent runs on a node: an agent not evicting any flow means that flows from this node are missing
+	if shouldAddAlert(flowslatest.AlertAgentNoFlows, target.Metrics.DisableAlerts) {
+		noFlowsDuration := monitoringv1.Duration("15m")
+		rules = append(rules, monitoringv1.Rule{
+			Alert: string(flowslatest.AlertAgentNoFlows),
+			Annotations: map[string]string{
+				"description": "NetObserv eBPF agent {{ $labels.pod }} is not capturing any flow, so the traffic of the node where it runs is not observed. Please check the agent logs.",
+				"summary":     "NetObserv eBPF agent is not capturing any flow",
+				"runbook_url": alerts.BuildNetObservRunbookURL(string(flowslatest.AlertAgentNoFlows)),
 			},
+			Expr:   intstr.FromString("sum(rate(netobserv_agent_evicted_flows_total[5m])) by (pod) == 0"),
+			For:    &noFlowsDuration,
+			Labels: labels,
 		})
 	}
 
@@ -0,0 +1,78 @@
+package ebpf
+
+import (
+	"context"
+	"fmt"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+	"github.com/netobserv/network-observability-operator/internal/pkg/certs"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// reconcileAgentsTLS issues the client certificate used by the agents for mutual TLS with flowlogs-pipeline,
+// when certificates are managed by the operator without cert-manager, or deletes it when not needed.
+func (c *AgentController) reconcileAgentsTLS(ctx context.Context, target *flowslatest.FlowCollector) error {
+	if !c.useOperatorAgentsTLS(target) {
+		c.Managed.TryDelete(ctx, c.tlsSecret)
+		return nil
+	}
+	// The CA is shared with flowlogs-pipeline, in the main namespace. It is managed by the flowlogs-pipeline controller:
+	// only read it here, and watch its trust bundle so that the client certificate is updated during a CA rotation.
+	caRef := flowslatest.CertificateReference{
+		Type:     flowslatest.RefTypeSecret,
+		Name:     constants.AgentsCASecretName,
+		CertFile: certs.CAFile,
+	}
+	if _, err := c.Watcher.ProcessCertRef(ctx, c.Client, &caRef, c.Namespace); err != nil {
+		if kerr.IsNotFound(err) {
+			return fmt.Errorf("waiting for flowlogs-pipeline to create the agents certificate authority: %w", err)
+		}
+		return err
+	}
+	ca, err := certs.ReadCA(ctx, &c.Client, constants.AgentsCASecretName, c.Namespace)
+	if err != nil {
+		return err
+	}
+	req := certs.Request{
+		CommonName: constants.EBPFAgentName,
+		Client:     true,
+	}
+	return certs.ReconcileCertificate(ctx, &c.Client, constants.EBPFAgentTLSSecretName, c.PrivilegedNamespace(), ca, &req)
+}
+
+// reconcileCertManager requests the certificates of the privileged namespace to cert-manager: the metrics serving certificate and,
+// for mutual TLS with flowlogs-pipeline, the flowlogs-pipeline server certificate. Without the cert-manager CSI driver,
+// which issues a dedicated certificate to each agent pod, a client certificate shared by the agents is also requested.
+func (c *AgentController) reconcileCertManager(ctx context.Context, target *flowslatest.FlowCollector) error {
+	if !c.ClusterInfo.HasCertManager() {
+		return nil
+	}
+	wanted, unused := c.metricsCertificates(&target.Spec.Agent.EBPF)
+	var clients []certs.ClientCertificate
+	if c.useCertManagerAgentsTLS(target) {
+		wanted = append(wanted, certs.ServingCertificate{
+			SecretName:       constants.FLPAgentsCertSecretName,
+			ServiceName:      constants.FLPName,
+			ServiceNamespace: c.Namespace,
+		})
+		if c.ClusterInfo.HasCertManagerCSIDriver() {
+			unused = append(unused, constants.EBPFAgentCertSecretName)
+		} else {
+			clients = append(clients, certs.ClientCertificate{SecretName: constants.EBPFAgentCertSecretName, CommonName: constants.EBPFAgentName})
+		}
+	} else {
+		unused = append(unused, constants.FLPAgentsCertSecretName, constants.EBPFAgentCertSecretName)
+	}
+	return certs.ReconcileCertManager(ctx, &c.Client, c.PrivilegedNamespace(), wanted, clients, unused)
+}
+
+// useOperatorAgentsTLS returns true when the certificates for mutual TLS with flowlogs-pipeline are issued by the operator CA
+func (c *AgentController) useOperatorAgentsTLS(target *flowslatest.FlowCollector) bool {
+	return target.Spec.UseAgentsMutualTLS() && !target.Spec.UseProvidedAgentsCertificates() && !c.ClusterInfo.UseCertManager()
+}
+
+// useCertManagerAgentsTLS returns true when the certificates for mutual TLS with flowlogs-pipeline are issued by cert-manager
+func (c *AgentController) useCertManagerAgentsTLS(target *flowslatest.FlowCollector) bool {
+	return target.Spec.UseAgentsMutualTLS() && !target.Spec.UseProvidedAgentsCertificates() && c.ClusterInfo.UseCertManager()
+}
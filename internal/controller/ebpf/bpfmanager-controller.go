@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"slices"
+	"strings"
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+	"github.com/netobserv/network-observability-operator/internal/pkg/manager/status"
 
 	bpfmaniov1alpha1 "github.com/bpfman/bpfman-operator/apis/v1alpha1"
 	"github.com/sirupsen/logrus"
@@ -18,7 +21,13 @@ import (
 )
 
 const (
-	netobservApp = "netobserv"
+	netobservApp     = "netobserv"
+	bpfReasonError   = "Error"
+	bpfReasonPending = "Pending"
+	// BpfApplicationName is the name of the ClusterBpfApplication managed by the operator
+	BpfApplicationName = netobservApp
+	// BpfAppStateOwnerLabel is the label set by bpfman on ClusterBpfApplicationState objects, referring to their application
+	BpfAppStateOwnerLabel = "bpfman.io/ownedByProgram"
 )
 
 // bpfmanAttachNetobserv Creates BpfmanApplication object with all required ebpf hooks and attaches them using bpfman manager
@@ -288,6 +297,115 @@ func prepareBpfApplication(bpfApp *bpfmaniov1alpha1.ClusterBpfApplication, fc *f
 	}
 }
 
+// bpfmanCheckStatus reads back the ClusterBpfApplication status and its per-node states, and reports them in FlowCollector status
+func (c *AgentController) bpfmanCheckStatus(ctx context.Context) error {
+	bpfApp := bpfmaniov1alpha1.ClusterBpfApplication{}
+	if err := c.Get(ctx, client.ObjectKey{Name: netobservApp}, &bpfApp); err != nil {
+		return fmt.Errorf("failed to get BpfApplication: %w", err)
+	}
+	states := bpfmaniov1alpha1.ClusterBpfApplicationStateList{}
+	if err := c.List(ctx, &states, client.MatchingLabels{BpfAppStateOwnerLabel: netobservApp}); err != nil {
+		return fmt.Errorf("failed to list BpfApplicationStates: %w", err)
+	}
+	cond := getBpfApplicationCondition(&bpfApp, states.Items)
+	c.Status.SetCondition(cond)
+	switch cond.Reason {
+	case bpfReasonError:
+		c.Status.SetFailure("BpfApplicationError", cond.Message)
+	case bpfReasonPending:
+		c.Status.SetInProgress("BpfApplicationPending", cond.Message)
+	}
+	return nil
+}
+
+// bpfmanCleanup deletes the ClusterBpfApplication when the EbpfManager feature is disabled
+func (c *AgentController) bpfmanCleanup(ctx context.Context) error {
+	if !c.ClusterInfo.HasBpfApplication() {
+		return nil
+	}
+	c.Status.SetCondition(v1.Condition{
+		Type:    status.EbpfManagerIssue,
+		Reason:  "Unused",
+		Status:  v1.ConditionUnknown,
+		Message: "EbpfManager feature is disabled",
+	})
+	bpfApp := bpfmaniov1alpha1.ClusterBpfApplication{}
+	if err := c.Get(ctx, client.ObjectKey{Name: netobservApp}, &bpfApp); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get BpfApplication: %w", err)
+	}
+	return c.DeleteIfOwned(ctx, &bpfApp)
+}
+
+func getBpfApplicationCondition(bpfApp *bpfmaniov1alpha1.ClusterBpfApplication, states []bpfmaniov1alpha1.ClusterBpfApplicationState) v1.Condition {
+	counts := make(map[bpfmaniov1alpha1.AppLoadStatus]int)
+	var errs []string
+	for i := range states {
+		st := &states[i].Status
+		counts[st.AppLoadStatus]++
+		if st.AppLoadStatus == bpfmaniov1alpha1.AppLoadError || st.AppLoadStatus == bpfmaniov1alpha1.ProgListChangedError {
+			msg := string(st.AppLoadStatus)
+			for _, cond := range st.Conditions {
+				if cond.Message != "" {
+					msg = cond.Message
+					break
+				}
+			}
+			errs = append(errs, fmt.Sprintf("%s: %s", st.Node, msg))
+		}
+	}
+	slices.Sort(errs)
+	summary := fmt.Sprintf("%d node(s) loaded, %d with errors, %d pending, %d not selected",
+		counts[bpfmaniov1alpha1.AppLoadSuccess],
+		counts[bpfmaniov1alpha1.AppLoadError]+counts[bpfmaniov1alpha1.ProgListChangedError],
+		counts[bpfmaniov1alpha1.AppLoadNotLoaded],
+		counts[bpfmaniov1alpha1.NotSelected],
+	)
+	if len(errs) > 0 {
+		const maxErrors = 3
+		if len(errs) > maxErrors {
+			errs = append(errs[:maxErrors], fmt.Sprintf("and %d more", len(errs)-maxErrors))
+		}
+		return v1.Condition{
+			Type:    status.EbpfManagerIssue,
+			Reason:  bpfReasonError,
+			Status:  v1.ConditionTrue,
+			Message: fmt.Sprintf("%s; errors: %s", summary, strings.Join(errs, "; ")),
+		}
+	}
+	// Check global status, which may report errors not tied to a node
+	for _, cond := range bpfApp.Status.Conditions {
+		if cond.Status != v1.ConditionTrue {
+			continue
+		}
+		switch bpfmaniov1alpha1.BpfApplicationConditionType(cond.Type) {
+		case bpfmaniov1alpha1.BpfAppCondError, bpfmaniov1alpha1.BpfAppCondDeleteError:
+			return v1.Condition{
+				Type:    status.EbpfManagerIssue,
+				Reason:  bpfReasonError,
+				Status:  v1.ConditionTrue,
+				Message: fmt.Sprintf("%s; %s", summary, cond.Message),
+			}
+		case bpfmaniov1alpha1.BpfAppCondSuccess:
+			return v1.Condition{
+				Type:    status.EbpfManagerIssue,
+				Reason:  "Ready",
+				Status:  v1.ConditionFalse,
+				Message: summary,
+			}
+		case bpfmaniov1alpha1.BpfAppCondPending:
+		}
+	}
+	return v1.Condition{
+		Type:    status.EbpfManagerIssue,
+		Reason:  bpfReasonPending,
+		Status:  v1.ConditionUnknown,
+		Message: summary,
+	}
+}
+
 func (c *AgentController) createBpfApplication(ctx context.Context, bpfApp *bpfmaniov1alpha1.ClusterBpfApplication) error {
 	return c.CreateOwned(ctx, bpfApp)
 }
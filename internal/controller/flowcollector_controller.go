@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	bpfmaniov1alpha1 "github.com/bpfman/bpfman-operator/apis/v1alpha1"
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	osv1 "github.com/openshift/api/console/v1"
 	securityv1 "github.com/openshift/api/security/v1"
@@ -20,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
 	"github.com/netobserv/network-observability-operator/internal/controller/consoleplugin"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/controller/ebpf"
@@ -29,6 +31,7 @@ import (
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager"
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager/status"
+	"github.com/netobserv/network-observability-operator/internal/pkg/strimzi"
 	"github.com/netobserv/network-observability-operator/internal/pkg/watchers"
 )
 
@@ -63,7 +66,23 @@ func Start(ctx context.Context, mgr *manager.Manager) (manager.PostCreateHook, e
 		Owns(&ascv2.HorizontalPodAutoscaler{}, reconcilers.UpdateOrDeleteOnlyPred).
 		Owns(&corev1.Namespace{}, reconcilers.UpdateOrDeleteOnlyPred).
 		Owns(&corev1.Service{}, reconcilers.UpdateOrDeleteOnlyPred).
-		Owns(&corev1.ServiceAccount{}, reconcilers.UpdateOrDeleteOnlyPred)
+		Owns(&corev1.ServiceAccount{}, reconcilers.UpdateOrDeleteOnlyPred).
+		Watches(
+			// The agent DaemonSets depend on the nodes kernel compatibility
+			&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []ctrl.Request {
+				return []ctrl.Request{{NamespacedName: constants.FlowCollectorName}}
+			}),
+			reconcilers.NodeKernelChangePred,
+		).
+		Watches(
+			// The console plugin displays the health rules overridden in FlowCollectorSlices
+			&sliceslatest.FlowCollectorSlice{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []ctrl.Request {
+				return []ctrl.Request{{NamespacedName: constants.FlowCollectorName}}
+			}),
+			reconcilers.IgnoreStatusChange,
+		)
 
 	if mgr.ClusterInfo.IsOpenShift() {
 		builder.Owns(&securityv1.SecurityContextConstraints{}, reconcilers.UpdateOrDeleteOnlyPred)
@@ -84,6 +103,19 @@ func Start(ctx context.Context, mgr *manager.Manager) (manager.PostCreateHook, e
 		log.Info("LokiStack CRD detected")
 	}
 
+	if mgr.ClusterInfo.HasBpfApplication() {
+		// Report eBPF Manager status changes in FlowCollector status
+		enqueueFC := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []ctrl.Request {
+			if o.GetName() == ebpf.BpfApplicationName || o.GetLabels()[ebpf.BpfAppStateOwnerLabel] == ebpf.BpfApplicationName {
+				return []ctrl.Request{{NamespacedName: constants.FlowCollectorName}}
+			}
+			return nil
+		})
+		builder.Watches(&bpfmaniov1alpha1.ClusterBpfApplication{}, enqueueFC)
+		builder.Watches(&bpfmaniov1alpha1.ClusterBpfApplicationState{}, enqueueFC)
+		log.Info("ClusterBpfApplication CRD detected")
+	}
+
 	ctrl, err := builder.Build(&r)
 	if err != nil {
 		return nil, err
@@ -91,7 +123,9 @@ func Start(ctx context.Context, mgr *manager.Manager) (manager.PostCreateHook, e
 	r.ctrl = ctrl
 	r.watcher = watchers.NewWatcher(ctrl)
 
-	return nil, nil
+	// Periodically report per-node agents health in status
+	healthMonitor := ebpf.NewHealthMonitor(mgr.Client, mgr.GetAPIReader(), mgr.Status)
+	return healthMonitor.Run, nil
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -182,6 +216,11 @@ func (r *FlowCollectorReconciler) reconcile(ctx context.Context, clh *helper.Cli
 		return err
 	}
 
+	// Use the credentials generated for the provisioned Kafka user, if any; provisioning itself is managed by the FLP controller
+	if r.mgr.ClusterInfo.HasStrimzi() {
+		strimzi.ApplyProvisioning(&desired.Spec)
+	}
+
 	if err := cleanup.CleanPastReferences(ctx, r.Client, ns); err != nil {
 		return err
 	}
@@ -204,6 +243,13 @@ func (r *FlowCollectorReconciler) reconcile(ctx context.Context, clh *helper.Cli
 		}
 	}
 
+	// Refresh the nodes kernel info, which may have changed since the last cluster info refresh
+	nodes := corev1.NodeList{}
+	if err := r.List(ctx, &nodes); err != nil {
+		return r.status.Error("CantListNodes", err)
+	}
+	r.mgr.ClusterInfo.UpdateNodes(nodes.Items)
+
 	// eBPF agent
 	ebpfAgentController := ebpf.NewAgentController(reconcilersInfo.NewInstance(
 		map[reconcilers.ImageRef]string{
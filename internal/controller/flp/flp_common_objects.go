@@ -33,11 +33,14 @@ const (
 	startupPeriodSeconds    = 10
 )
 
-func newGRPCPipeline(desired *flowslatest.FlowCollectorSpec, volumes *volumes.Builder) config.PipelineBuilderStage {
+func newGRPCPipeline(desired *flowslatest.FlowCollectorSpec, volumes *volumes.Builder, useCertManager bool) config.PipelineBuilderStage {
 	adv := helper.GetAdvancedProcessorConfig(desired)
 	cfg := api.IngestGRPCProto{Port: int(*adv.Port)}
-	skipTLS := flowslatest.IsEnvEnabled(adv.Env, "SERVER_NOTLS")
-	if desired.DeploymentModel == flowslatest.DeploymentModelService && !skipTLS {
+	if mtls := helper.GetAgentsTLSConfig(desired, useCertManager); mtls != nil {
+		// Mutual TLS: set up server certificate, and CA to verify agents certificates
+		cfg.CertPath, cfg.KeyPath = volumes.AddCertificate(&mtls.Server, "agents-tls")
+		cfg.ClientCAPath, _ = volumes.AddCertificate(&mtls.ServerCA, "agents-tls-ca")
+	} else if useServiceTLS(desired) {
 		// Communication from agents uses TLS: set up server certificate
 		ref := flowslatest.CertificateReference{
 			Type:     flowslatest.RefTypeSecret,
@@ -52,6 +55,13 @@ func newGRPCPipeline(desired *flowslatest.FlowCollectorSpec, volumes *volumes.Bu
 	return config.NewGRPCPipeline("grpc", cfg)
 }
 
+// useServiceTLS returns true when agents send flows to the flowlogs-pipeline Service using server-side TLS
+func useServiceTLS(desired *flowslatest.FlowCollectorSpec) bool {
+	adv := helper.GetAdvancedProcessorConfig(desired)
+	skipTLS := flowslatest.IsEnvEnabled(adv.Env, "SERVER_NOTLS")
+	return !desired.UseKafka() && desired.DeploymentModel == flowslatest.DeploymentModelService && !skipTLS && !desired.UseAgentsMutualTLS()
+}
+
 func newKafkaPipeline(desired *flowslatest.FlowCollectorSpec, volumes *volumes.Builder) config.PipelineBuilderStage {
 	return config.NewKafkaPipeline("kafka-read", api.IngestKafka{
 		Brokers:           []string{desired.Kafka.Address},
@@ -338,9 +348,13 @@ func promService(desired *flowslatest.FlowCollectorSpec, svcName, namespace, app
 	return &svc
 }
 
-func serviceMonitor(desired *flowslatest.FlowCollectorSpec, smName, svcName, namespace, appLabel, version string, isDownstream, useEndpointSlices bool) *monitoringv1.ServiceMonitor {
+func serviceMonitor(desired *flowslatest.FlowCollectorSpec, smName, svcName, namespace, appLabel, version string, isDownstream, useCertManager, useEndpointSlices bool) *monitoringv1.ServiceMonitor {
 	serverName := fmt.Sprintf("%s.%s.svc", svcName, namespace)
-	scheme, smTLS := helper.GetServiceMonitorTLSConfig(&desired.Processor.Metrics.Server.TLS, serverName, isDownstream)
+	var certManagerSecret string
+	if useCertManager {
+		certManagerSecret = svcName
+	}
+	scheme, smTLS := helper.GetServiceMonitorTLSConfig(&desired.Processor.Metrics.Server.TLS, serverName, certManagerSecret, isDownstream)
 	var sdRole *monitoringv1.ServiceDiscoveryRole
 	if useEndpointSlices {
 		sdRole = ptr.To(monitoringv1.EndpointSliceRole)
@@ -2,6 +2,7 @@ package flp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -13,9 +14,11 @@ import (
 	"github.com/netobserv/network-observability-operator/internal/controller/flp/fmstatus"
 	"github.com/netobserv/network-observability-operator/internal/controller/flp/slicesstatus"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
+	"github.com/netobserv/network-observability-operator/internal/pkg/certs"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager"
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager/status"
+	"github.com/netobserv/network-observability-operator/internal/pkg/strimzi"
 	"github.com/netobserv/network-observability-operator/internal/pkg/watchers"
 	appsv1 "k8s.io/api/apps/v1"
 	ascv2 "k8s.io/api/autoscaling/v2"
@@ -128,6 +131,17 @@ func (r *Reconciler) reconcile(ctx context.Context, clh *helper.Client, fc *flow
 
 	r.watcher.Reset(ns)
 
+	// Provision the Kafka topic and user with Strimzi, and use the generated credentials
+	if fc.Spec.UseKafkaProvisioning() && !r.mgr.ClusterInfo.HasStrimzi() {
+		return r.status.Error("KafkaProvisioningError", errors.New("spec.kafka.provisioning is enabled, but the Strimzi API was not found"))
+	}
+	if r.mgr.ClusterInfo.HasStrimzi() {
+		if err := strimzi.Reconcile(ctx, clh, &fc.Spec); err != nil {
+			return r.status.Error("KafkaProvisioningError", err)
+		}
+	}
+	strimzi.ApplyProvisioning(&fc.Spec)
+
 	// Auto-detect subnets
 	var subnetLabels []flowslatest.SubnetLabel
 	if r.mgr.ClusterInfo.IsOpenShift() && fc.Spec.Processor.HasAutoDetectOpenShiftNetworks() {
@@ -158,6 +172,7 @@ func (r *Reconciler) reconcile(ctx context.Context, clh *helper.Client, fc *flow
 		})
 		slicesstatus.Reset(&fcSlices)
 		defer slicesstatus.Sync(ctx, r.Client, &fcSlices)
+		slicesToHealthRules(&fc.Spec, fcSlices.Items)
 	}
 
 	// Create sub-reconcilers
@@ -250,3 +265,34 @@ func reconcileMonitoringCerts(ctx context.Context, info *reconcilers.Common, tls
 
 	return nil
 }
+
+// reconcileServingCertificates requests serving certificates to cert-manager when the OpenShift service CA isn't available,
+// and annotates pods so that they restart on renewal. Certificates listed in unused are removed.
+func reconcileServingCertificates(ctx context.Context, info *reconcilers.Common, wanted []certs.ServingCertificate, unused []string, annotations map[string]string) error {
+	if !info.ClusterInfo.HasCertManager() {
+		return nil
+	}
+	if !info.ClusterInfo.UseCertManager() {
+		for i := range wanted {
+			unused = append(unused, wanted[i].SecretName)
+		}
+		wanted = nil
+	}
+	if err := certs.ReconcileCertManager(ctx, &info.Client, info.Namespace, wanted, nil, unused); err != nil {
+		return err
+	}
+	for i := range wanted {
+		ref := flowslatest.CertificateReference{
+			Type:     flowslatest.RefTypeSecret,
+			Name:     wanted[i].SecretName,
+			CertFile: certs.CertFile,
+			CertKey:  certs.KeyFile,
+		}
+		digest, err := info.Watcher.ProcessCertRef(ctx, info.Client, &ref, info.Namespace)
+		if err != nil {
+			return fmt.Errorf("reading certificate %s issued by cert-manager: %w", wanted[i].SecretName, err)
+		}
+		annotations[watchers.Annotation(wanted[i].SecretName)] = digest
+	}
+	return nil
+}
@@ -467,7 +467,7 @@ func ControllerSpecs() {
 				}, &pr)
 			}, timeout, interval).Should(Succeed())
 			Expect(pr.Spec.Groups).Should(HaveLen(1))
-			Expect(pr.Spec.Groups[0].Rules).Should(HaveLen(9))
+			Expect(pr.Spec.Groups[0].Rules).Should(HaveLen(13))
 
 			// Manually delete ServiceMonitor
 			By("Deleting ServiceMonitor")
@@ -13,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -22,7 +23,7 @@ const (
 	monoDynConfigMap   = monoName + "-config-dynamic"
 	monoServiceMonitor = monoName + "-monitor"
 	monoPromRule       = monoName + "-alert"
-	monoCertSecretName = monoName + "-cert"
+	monoCertSecretName = constants.FLPCertSecretName
 )
 
 type monolithBuilder struct {
@@ -129,7 +130,7 @@ func (b *monolithBuilder) configMaps() (*corev1.ConfigMap, string, *corev1.Confi
 		b.info.Loki,
 		b.info.ClusterInfo.GetID(),
 		&b.volumes,
-		newGRPCPipeline(b.desired, &b.volumes),
+		newGRPCPipeline(b.desired, &b.volumes, b.info.ClusterInfo.UseCertManager()),
 	)
 	if err != nil {
 		return nil, "", nil, err
@@ -176,6 +177,12 @@ func (b *monolithBuilder) service() *corev1.Service {
 			}},
 		},
 	}
+	if b.desired.UseHostNetwork() {
+		// Agents must reach the instance running on the same node
+		svc.Spec.InternalTrafficPolicy = ptr.To(corev1.ServiceInternalTrafficPolicyLocal)
+	} else {
+		svc.Spec.InternalTrafficPolicy = ptr.To(corev1.ServiceInternalTrafficPolicyCluster)
+	}
 	if b.info.ClusterInfo.IsOpenShift() {
 		svc.Annotations[constants.OpenShiftCertificateAnnotation] = monoCertSecretName
 	}
@@ -213,6 +220,7 @@ func (b *monolithBuilder) serviceMonitor() *monitoringv1.ServiceMonitor {
 		monoName,
 		b.version,
 		b.info.IsDownstream,
+		b.info.ClusterInfo.UseCertManager(),
 		b.info.ClusterInfo.HasPromServiceDiscoveryRole(),
 	)
 }
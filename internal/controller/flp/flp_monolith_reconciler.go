@@ -2,12 +2,14 @@ package flp
 
 import (
 	"context"
+	"fmt"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
@@ -15,10 +17,12 @@ import (
 	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
+	"github.com/netobserv/network-observability-operator/internal/pkg/certs"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager/status"
 	"github.com/netobserv/network-observability-operator/internal/pkg/metrics/alerts"
 	"github.com/netobserv/network-observability-operator/internal/pkg/resources"
+	"github.com/netobserv/network-observability-operator/internal/pkg/watchers"
 )
 
 type monolithReconciler struct {
@@ -36,6 +40,8 @@ type monolithReconciler struct {
 	rbInformer       *rbacv1.ClusterRoleBinding
 	serviceMonitor   *monitoringv1.ServiceMonitor
 	prometheusRule   *monitoringv1.PrometheusRule
+	agentsCASecret   *corev1.Secret
+	agentsTLSSecret  *corev1.Secret
 }
 
 func newMonolithReconciler(cmn *reconcilers.Instance) *monolithReconciler {
@@ -52,6 +58,8 @@ func newMonolithReconciler(cmn *reconcilers.Instance) *monolithReconciler {
 		rbHostNetwork:    cmn.Managed.NewCRB(resources.GetClusterRoleBindingName(monoShortName, constants.HostNetworkRole)),
 		rbLokiWriter:     cmn.Managed.NewCRB(resources.GetClusterRoleBindingName(monoShortName, constants.LokiWriterRole)),
 		rbInformer:       cmn.Managed.NewCRB(resources.GetClusterRoleBindingName(monoShortName, constants.FLPInformersRole)),
+		agentsCASecret:   cmn.Managed.NewSecret(constants.AgentsCASecretName),
+		agentsTLSSecret:  cmn.Managed.NewSecret(constants.FLPAgentsTLSSecretName),
 	}
 	if cmn.ClusterInfo.HasSvcMonitor() {
 		rec.serviceMonitor = cmn.Managed.NewServiceMonitor(monoServiceMonitor)
@@ -97,6 +105,12 @@ func (r *monolithReconciler) reconcile(ctx context.Context, desired *flowslatest
 	annotations := map[string]string{
 		constants.PodConfigurationDigest: configDigest,
 	}
+	if err := r.reconcileAgentsTLS(ctx, desired, annotations); err != nil {
+		return err
+	}
+	if err := r.reconcileServingCertificates(ctx, desired, annotations); err != nil {
+		return err
+	}
 	if !r.Managed.Exists(r.staticConfigMap) {
 		if err := r.CreateOwned(ctx, staticCM); err != nil {
 			return err
@@ -115,7 +129,7 @@ func (r *monolithReconciler) reconcile(ctx context.Context, desired *flowslatest
 		return err
 	}
 
-	if desired.Spec.UseHostNetwork() {
+	if desired.Spec.UseHostNetwork() && !desired.Spec.UseAgentsMutualTLS() {
 		r.Managed.TryDelete(ctx, r.service)
 	} else {
 		if err := r.reconcileService(ctx, &builder); err != nil {
@@ -134,6 +148,14 @@ func (r *monolithReconciler) reconcile(ctx context.Context, desired *flowslatest
 		if _, err = r.Watcher.ProcessCACert(ctx, r.Client, &r.Loki.TLS, r.Namespace); err != nil {
 			return err
 		}
+		// Watch for Loki credentials; need to restart pods in case of rotation
+		authDigest, err := r.Watcher.ProcessHTTPClientAuth(ctx, r.Client, &r.Loki.Auth, r.Namespace)
+		if err != nil {
+			return err
+		}
+		if authDigest != "" {
+			annotations[watchers.Annotation("loki-auth")] = authDigest
+		}
 	}
 
 	// Watch for Kafka exporter certificate if necessary; need to restart pods in case of cert rotation
@@ -157,6 +179,67 @@ func (r *monolithReconciler) reconcile(ctx context.Context, desired *flowslatest
 	return r.reconcileDeployment(ctx, &desired.Spec.Processor, &builder, annotations)
 }
 
+// reconcileAgentsTLS manages the certificates for mutual TLS with the eBPF agents, and annotates pods for restart on renewal.
+// With cert-manager, certificates are requested by the eBPF agent controller in the privileged namespace, and copied from there.
+func (r *monolithReconciler) reconcileAgentsTLS(ctx context.Context, desired *flowslatest.FlowCollector, annotations map[string]string) error {
+	useCertManager := r.ClusterInfo.UseCertManager()
+	mtls := helper.GetAgentsTLSConfig(&desired.Spec, useCertManager)
+	if mtls == nil || desired.Spec.UseProvidedAgentsCertificates() || useCertManager {
+		r.Managed.TryDelete(ctx, r.agentsTLSSecret)
+		r.Managed.TryDelete(ctx, r.agentsCASecret)
+		if mtls == nil {
+			return nil
+		}
+	} else {
+		ca, err := certs.ReconcileCA(ctx, &r.Client, constants.AgentsCASecretName, r.Namespace)
+		if err != nil {
+			return err
+		}
+		// In Direct deployment model, agents also connect through the service, which routes to the instance on the same node
+		req := certs.Request{
+			CommonName: monoName,
+			DNSNames: []string{
+				fmt.Sprintf("%s.%s.svc", monoName, r.Namespace),
+				fmt.Sprintf("%s.%s.svc.cluster.local", monoName, r.Namespace),
+			},
+		}
+		if err := certs.ReconcileCertificate(ctx, &r.Client, mtls.Server.Name, r.Namespace, ca, &req); err != nil {
+			return err
+		}
+	}
+	serverDigest, err := r.Watcher.ProcessCertRef(ctx, r.Client, &mtls.Server, r.Namespace)
+	if err != nil {
+		if useCertManager && kerr.IsNotFound(err) {
+			return fmt.Errorf("waiting for cert-manager to issue the agents server certificate: %w", err)
+		}
+		return err
+	}
+	caDigest, err := r.Watcher.ProcessCertRef(ctx, r.Client, &mtls.ServerCA, r.Namespace)
+	if err != nil {
+		return err
+	}
+	annotations[watchers.Annotation("agents-tls")] = serverDigest
+	annotations[watchers.Annotation("agents-tls-ca")] = caDigest
+	return nil
+}
+
+// reconcileServingCertificates manages the certificates issued by cert-manager for the metrics and gRPC endpoints
+func (r *monolithReconciler) reconcileServingCertificates(ctx context.Context, desired *flowslatest.FlowCollector, annotations map[string]string) error {
+	var wanted []certs.ServingCertificate
+	var unused []string
+	if desired.Spec.Processor.Metrics.Server.TLS.Type == flowslatest.ServerTLSAuto {
+		wanted = append(wanted, certs.ServingCertificate{SecretName: constants.FLPMetricsSvcName, ServiceName: constants.FLPMetricsSvcName})
+	} else {
+		unused = append(unused, constants.FLPMetricsSvcName)
+	}
+	if useServiceTLS(&desired.Spec) {
+		wanted = append(wanted, certs.ServingCertificate{SecretName: monoCertSecretName, ServiceName: monoName})
+	} else {
+		unused = append(unused, monoCertSecretName)
+	}
+	return reconcileServingCertificates(ctx, r.Common, wanted, unused, annotations)
+}
+
 func (r *monolithReconciler) reconcileDynamicConfigMap(ctx context.Context, newDCM *corev1.ConfigMap) error {
 	if !r.Managed.Exists(r.dynamicConfigMap) {
 		if err := r.CreateOwned(ctx, newDCM); err != nil {
@@ -194,7 +277,7 @@ func (r *monolithReconciler) reconcilePrometheusService(ctx context.Context, bui
 		}
 	}
 	if r.ClusterInfo.HasPromRule() {
-		rules := alerts.BuildMonitoringRules(ctx, builder.desired)
+		rules := alerts.BuildMonitoringRules(ctx, builder.desired, builder.fcSlices)
 		promRules := builder.prometheusRule(rules)
 		if err := reconcilers.GenericReconcile(ctx, r.Managed, &r.Client, r.prometheusRule, promRules, &report, helper.PrometheusRuleChanged); err != nil {
 			return err
@@ -21,6 +21,7 @@ import (
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper/loki"
 	otelConfig "github.com/netobserv/network-observability-operator/internal/pkg/helper/otel"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper/schema"
 	"github.com/netobserv/network-observability-operator/internal/pkg/metrics"
 	"github.com/netobserv/network-observability-operator/internal/pkg/volumes"
 )
@@ -617,10 +618,31 @@ func (b *PipelineBuilder) addLokiStage(previous config.PipelineBuilderStage) err
 			Authorization: authorization,
 		}
 	}
+	writerAuth := b.loki.Auth
+	writerAuth.Headers = nil
+	authPaths := b.volumes.AddHTTPClientAuth(&writerAuth, "loki-auth")
+	setHTTPClientAuth(lokiWrite.ClientConfig, &authPaths)
 	lokiStage.WriteLoki("loki", lokiWrite)
 	return nil
 }
 
+// setHTTPClientAuth configures credentials read from the mounted files. Headers are not set, as they cannot be
+// parsed back from the JSON configuration; the Loki tenant is configured separately.
+func setHTTPClientAuth(cfg *promConfig.HTTPClientConfig, paths *volumes.HTTPClientAuthPaths) {
+	if paths.UsernamePath != "" || paths.PasswordPath != "" {
+		cfg.Authorization = nil
+		cfg.BasicAuth = &promConfig.BasicAuth{
+			UsernameFile: paths.UsernamePath,
+			PasswordFile: paths.PasswordPath,
+		}
+	} else if paths.TokenPath != "" {
+		cfg.Authorization = &promConfig.Authorization{
+			Type:            "Bearer",
+			CredentialsFile: paths.TokenPath,
+		}
+	}
+}
+
 func (b *PipelineBuilder) addPrometheusStage(previous config.PipelineBuilderStage) ([]api.MetricsItem, error) {
 	// Configure metrics
 	var flpMetrics []api.MetricsItem
@@ -673,13 +695,19 @@ func (b *PipelineBuilder) addCustomExportStages(previous config.PipelineBuilderS
 
 	for i, exporter := range b.desired.Exporters {
 		if exporter.Type == flowslatest.KafkaExporter {
-			b.createKafkaWriteStage(fmt.Sprintf("kafka-export-%d", i), &exporter.Kafka, &stage)
+			name := fmt.Sprintf(constants.FLPKafkaExportStage, i)
+			preset, err := schema.GetPreset(exporter.GetSchema())
+			if err != nil {
+				return err
+			}
+			fromStage, _ := addSchemaStages(name, preset, exporter.Kafka.FieldsMapping, &stage)
+			b.createKafkaWriteStage(name, &exporter.Kafka, &fromStage)
 		}
 		if exporter.Type == flowslatest.IpfixExporter {
-			createIPFIXWriteStage(fmt.Sprintf("IPFIX-export-%d", i), &exporter.IPFIX, &stage)
+			createIPFIXWriteStage(fmt.Sprintf(constants.FLPIPFIXExportStage, i), &exporter.IPFIX, &stage)
 		}
 		if exporter.Type == flowslatest.OpenTelemetryExporter {
-			err := b.createOpenTelemetryStage(fmt.Sprintf("Otel-export-%d", i), &exporter.OpenTelemetry, &stage, flpMetrics)
+			err := b.createOpenTelemetryStage(fmt.Sprintf(constants.FLPOtelExportStage, i), &exporter.OpenTelemetry, exporter.GetSchema(), &stage, flpMetrics)
 			if err != nil {
 				return err
 			}
@@ -688,6 +716,23 @@ func (b *PipelineBuilder) addCustomExportStages(previous config.PipelineBuilderS
 	return nil
 }
 
+// addSchemaStages adds the stages that convert flows to the exporter schema, and returns the last one along with the renaming rules
+func addSchemaStages(name string, preset *schema.Preset, custom *[]flowslatest.GenericTransformRule, fromStage *config.PipelineBuilderStage) (config.PipelineBuilderStage, []api.GenericTransformRule) {
+	transforms := schema.TransformConfig(preset, custom)
+	if transforms == nil {
+		return *fromStage, nil
+	}
+	stage := *fromStage
+	if transforms.Conversions != nil {
+		stage = stage.TransformFilter(fmt.Sprintf("%s-conversions", name), *transforms.Conversions)
+	}
+	stage = stage.TransformGeneric(fmt.Sprintf("%s-transform", name), transforms.Generic)
+	if transforms.Constants != nil {
+		stage = stage.TransformFilter(fmt.Sprintf("%s-constants", name), *transforms.Constants)
+	}
+	return stage, transforms.Generic.Rules
+}
+
 func (b *PipelineBuilder) createKafkaWriteStage(name string, spec *flowslatest.FlowCollectorKafka, fromStage *config.PipelineBuilderStage) config.PipelineBuilderStage {
 	return fromStage.EncodeKafka(name, api.EncodeKafka{
 		Address: spec.Address,
@@ -719,7 +764,7 @@ func getIPFIXTransport(transport string) string {
 	}
 }
 
-func (b *PipelineBuilder) createOpenTelemetryStage(name string, spec *flowslatest.FlowCollectorOpenTelemetry, fromStage *config.PipelineBuilderStage, flpMetrics []api.MetricsItem) error {
+func (b *PipelineBuilder) createOpenTelemetryStage(name string, spec *flowslatest.FlowCollectorOpenTelemetry, exporterSchema flowslatest.ExporterSchema, fromStage *config.PipelineBuilderStage, flpMetrics []api.MetricsItem) error {
 	conn := api.OtlpConnectionInfo{
 		Address:        spec.TargetHost,
 		Port:           spec.TargetPort,
@@ -732,12 +777,12 @@ func (b *PipelineBuilder) createOpenTelemetryStage(name string, spec *flowslates
 	metricsEnabled := spec.Metrics.Enable != nil && *spec.Metrics.Enable
 
 	if logsEnabled || metricsEnabled {
-		// add transform stage
-		transformCfg, err := otelConfig.GetOtelTransformConfig(spec.FieldsMapping)
+		preset, err := schema.GetPreset(exporterSchema)
 		if err != nil {
 			return err
 		}
-		transformStage := fromStage.TransformGeneric(fmt.Sprintf("%s-transform", name), *transformCfg)
+		// add transform stage
+		transformStage, rules := addSchemaStages(name, preset, spec.FieldsMapping, fromStage)
 
 		// otel logs config
 		if logsEnabled {
@@ -749,10 +794,7 @@ func (b *PipelineBuilder) createOpenTelemetryStage(name string, spec *flowslates
 
 		// otel metrics config
 		if metricsEnabled {
-			metricsCfg, err := otelConfig.GetOtelMetrics(flpMetrics)
-			if err != nil {
-				return err
-			}
+			metricsCfg := otelConfig.ConvertMetrics(rules, flpMetrics)
 			transformStage.EncodeOtelMetrics(fmt.Sprintf("%s-metrics", name), api.EncodeOtlpMetrics{
 				OtlpConnectionInfo: &conn,
 				Prefix:             "netobserv_",
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/netobserv/flowlogs-pipeline/pkg/api"
 	"github.com/netobserv/flowlogs-pipeline/pkg/config"
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
@@ -154,6 +155,125 @@ func getAutoScalerSpecs() (ascv2.HorizontalPodAutoscaler, flowslatest.FlowCollec
 	return autoScaler, getConfig().Processor.KafkaConsumerAutoscaler
 }
 
+func TestLokiStageCredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := getConfig()
+	cfg.Loki.Mode = flowslatest.LokiModeManual
+	cfg.Loki.Manual = flowslatest.LokiManualParams{
+		IngesterURL: "https://mimir.example.com/loki",
+		AuthToken:   flowslatest.LokiAuthDisabled,
+		Auth: flowslatest.HTTPClientAuth{
+			Type:     flowslatest.HTTPClientAuthBasic,
+			Username: &flowslatest.FileReference{Type: flowslatest.RefTypeSecret, Name: "loki-creds", File: "user"},
+			Password: &flowslatest.FileReference{Type: flowslatest.RefTypeSecret, Name: "loki-creds", File: "password"},
+			Headers: []flowslatest.HTTPHeaderReference{
+				{Name: "X-Scope-OrgID", ValueFrom: flowslatest.FileReference{Type: flowslatest.RefTypeConfigMap, Name: "tenant", File: "id"}},
+			},
+		},
+	}
+	b := monoBuilder("namespace", &cfg)
+	cm, _, _, err := b.configMaps()
+	assert.NoError(err)
+
+	var decoded config.Root
+	assert.NoError(json.Unmarshal([]byte(cm.Data[configFile]), &decoded))
+	lokiCfg := decoded.Parameters[3].Write.Loki
+	assert.Nil(lokiCfg.ClientConfig.Authorization)
+	assert.Equal("/var/loki-auth-username/user", lokiCfg.ClientConfig.BasicAuth.UsernameFile)
+	assert.Equal("/var/loki-auth-password/password", lokiCfg.ClientConfig.BasicAuth.PasswordFile)
+	// Headers are only used by the console plugin
+	assert.Nil(lokiCfg.ClientConfig.HTTPHeaders)
+	for _, v := range b.volumes.GetVolumes() {
+		assert.NotEqual("loki-auth-header-0", v.Name)
+	}
+
+	// Bearer token
+	cfg.Loki.Manual.Auth = flowslatest.HTTPClientAuth{
+		Type:  flowslatest.HTTPClientAuthBearer,
+		Token: &flowslatest.FileReference{Type: flowslatest.RefTypeSecret, Name: "loki-creds", File: "token"},
+	}
+	b = monoBuilder("namespace", &cfg)
+	cm, _, _, err = b.configMaps()
+	assert.NoError(err)
+	decoded = config.Root{}
+	assert.NoError(json.Unmarshal([]byte(cm.Data[configFile]), &decoded))
+	lokiCfg = decoded.Parameters[3].Write.Loki
+	assert.Nil(lokiCfg.ClientConfig.BasicAuth)
+	assert.Equal("Bearer", lokiCfg.ClientConfig.Authorization.Type)
+	assert.Equal("/var/loki-auth-token/token", lokiCfg.ClientConfig.Authorization.CredentialsFile)
+}
+
+func TestExporterSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := getConfig()
+	cfg.Exporters = []*flowslatest.FlowCollectorExporter{
+		{
+			Type:   flowslatest.KafkaExporter,
+			Schema: flowslatest.ExporterSchemaECS,
+			Kafka: flowslatest.FlowCollectorKafka{
+				Address:       "kafka",
+				Topic:         "ecs",
+				FieldsMapping: &[]flowslatest.GenericTransformRule{{Input: "DstAddr", Output: "dst"}},
+			},
+		},
+		{
+			Type:  flowslatest.KafkaExporter,
+			Kafka: flowslatest.FlowCollectorKafka{Address: "kafka", Topic: "raw"},
+		},
+	}
+	b := monoBuilder("namespace", &cfg)
+	cm, _, _, err := b.configMaps()
+	assert.NoError(err)
+
+	var decoded config.Root
+	assert.NoError(json.Unmarshal([]byte(cm.Data[configFile]), &decoded))
+	follows := map[string]string{}
+	for _, p := range decoded.Pipeline {
+		follows[p.Name] = p.Follows
+	}
+	assert.Equal("kafka-export-0-conversions", follows["kafka-export-0-transform"])
+	assert.Equal("kafka-export-0-transform", follows["kafka-export-0-constants"])
+	assert.Equal("kafka-export-0-constants", follows["kafka-export-0"])
+	assert.NotContains(follows, "kafka-export-1-transform")
+	assert.Equal(follows["kafka-export-0-conversions"], follows["kafka-export-1"])
+
+	for _, p := range decoded.Parameters {
+		if p.Name == "kafka-export-0-transform" {
+			assert.Contains(p.Transform.Generic.Rules, api.GenericTransformRule{Input: "SrcAddr", Output: "source.ip"})
+			assert.Contains(p.Transform.Generic.Rules, api.GenericTransformRule{Input: "DstAddr", Output: "dst"})
+			assert.NotContains(p.Transform.Generic.Rules, api.GenericTransformRule{Input: "DstAddr", Output: "destination.ip"})
+		}
+	}
+}
+
+func TestServiceMonitorCertManager(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := getConfig()
+	cfg.Processor.Metrics.Server.TLS.Type = flowslatest.ServerTLSAuto
+	clusterInfo := cluster.Info{}
+	clusterInfo.Mock("", "")
+	clusterInfo.MockCertManager(true)
+	info := reconcilers.Common{Namespace: "namespace", ClusterInfo: &clusterInfo}
+	b, _ := newMonolithBuilder(info.NewInstance(image, status.Instance{}), &cfg, &metricslatest.FlowMetricList{}, nil, nil)
+	sm := b.serviceMonitor()
+	assert.Equal(v1.Scheme("https"), *sm.Spec.Endpoints[0].Scheme)
+	tls := sm.Spec.Endpoints[0].TLSConfig
+	assert.Equal("flowlogs-pipeline-prom.namespace.svc", *tls.ServerName)
+	assert.Nil(tls.CA.ConfigMap)
+	assert.Equal("flowlogs-pipeline-prom", tls.CA.Secret.Name)
+	assert.Equal("ca.crt", tls.CA.Secret.Key)
+
+	// On OpenShift, the service CA is used even if cert-manager is installed
+	clusterInfo.Mock("4.20.0", "")
+	b, _ = newMonolithBuilder(info.NewInstance(image, status.Instance{}), &cfg, &metricslatest.FlowMetricList{}, nil, nil)
+	sm = b.serviceMonitor()
+	assert.Nil(sm.Spec.Endpoints[0].TLSConfig.CA.Secret)
+	assert.Equal("openshift-service-ca.crt", sm.Spec.Endpoints[0].TLSConfig.CA.ConfigMap.Name)
+}
+
 func monoBuilder(ns string, cfg *flowslatest.FlowCollectorSpec) monolithBuilder {
 	return monoBuilderWithMetrics(ns, cfg, &metricslatest.FlowMetricList{})
 }
@@ -570,7 +690,7 @@ func TestPrometheusRuleNoChange(t *testing.T) {
 	ns := "namespace"
 	cfg := getConfig()
 	b := monoBuilder(ns, &cfg)
-	r := alerts.BuildMonitoringRules(context.Background(), &cfg)
+	r := alerts.BuildMonitoringRules(context.Background(), &cfg, nil)
 	first := b.prometheusRule(r)
 
 	// Check no change
@@ -587,13 +707,13 @@ func TestPrometheusRuleChanged(t *testing.T) {
 	// Get first
 	cfg := getConfig()
 	b := monoBuilder("namespace", &cfg)
-	r := alerts.BuildMonitoringRules(context.Background(), &cfg)
+	r := alerts.BuildMonitoringRules(context.Background(), &cfg, nil)
 	first := b.prometheusRule(r)
 
 	// Check enabled rule change
 	cfg.Processor.Metrics.DisableAlerts = []flowslatest.HealthRuleTemplate{flowslatest.AlertNoFlows}
 	b = monoBuilder("namespace", &cfg)
-	r = alerts.BuildMonitoringRules(context.Background(), &cfg)
+	r = alerts.BuildMonitoringRules(context.Background(), &cfg, nil)
 	second := b.prometheusRule(r)
 
 	report := helper.NewChangeReport("")
@@ -603,7 +723,7 @@ func TestPrometheusRuleChanged(t *testing.T) {
 	// Check labels change
 	info := reconcilers.Common{Namespace: "namespace2", ClusterInfo: &cluster.Info{}}
 	b, _ = newMonolithBuilder(info.NewInstance(image2, status.Instance{}), &cfg, b.flowMetrics, nil, nil)
-	r = alerts.BuildMonitoringRules(context.Background(), &cfg)
+	r = alerts.BuildMonitoringRules(context.Background(), &cfg, nil)
 	third := b.prometheusRule(r)
 
 	report = helper.NewChangeReport("")
@@ -810,3 +930,63 @@ func TestToleration(t *testing.T) {
 	assert.Len(ds.Spec.Template.Spec.Tolerations, 1)
 	assert.Equal(corev1.Toleration{Operator: "Exists"}, ds.Spec.Template.Spec.Tolerations[0])
 }
+
+func TestGRPCPipelineMutualTLS(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := getConfig()
+	cfg.DeploymentModel = flowslatest.DeploymentModelDirect
+	cfg.Processor.AgentsTLS = flowslatest.FLPAgentsTLS{Mode: flowslatest.FLPAgentsTLSMutual, Certificates: flowslatest.FLPAgentsCertificatesAuto}
+	b := monoBuilder("namespace", &cfg)
+	cm, _, _, err := b.configMaps()
+	assert.NoError(err)
+
+	var decoded config.Root
+	err = json.Unmarshal([]byte(cm.Data[configFile]), &decoded)
+	assert.NoError(err)
+	grpc := decoded.Parameters[0].Ingest.GRPC
+	assert.Equal("/var/agents-tls/tls.crt", grpc.CertPath)
+	assert.Equal("/var/agents-tls/tls.key", grpc.KeyPath)
+	assert.Equal("/var/agents-tls-ca/ca.crt", grpc.ClientCAPath)
+
+	ds := b.daemonSet(annotate("digest"))
+	var secrets []string
+	for _, v := range ds.Spec.Template.Spec.Volumes {
+		if v.Secret != nil {
+			secrets = append(secrets, v.Secret.SecretName)
+		}
+	}
+	assert.Contains(secrets, constants.FLPAgentsTLSSecretName)
+
+	// Agents reach the instance on their node through the service, rather than via the node IP
+	svc := b.service()
+	assert.Equal(corev1.ServiceInternalTrafficPolicyLocal, *svc.Spec.InternalTrafficPolicy)
+
+	// With cert-manager: the server certificate is issued in the privileged namespace, and copied from there
+	loki := helper.NewLokiConfig(&cfg.Loki, "any")
+	info := reconcilers.Common{Namespace: "namespace", Loki: &loki, ClusterInfo: &cluster.Info{}}
+	info.ClusterInfo.MockCertManager(true)
+	b, _ = newMonolithBuilder(info.NewInstance(image, status.Instance{}), &cfg, &metricslatest.FlowMetricList{}, nil, nil)
+	_, _, _, err = b.configMaps()
+	assert.NoError(err)
+	ds = b.daemonSet(annotate("digest"))
+	secrets = nil
+	for _, v := range ds.Spec.Template.Spec.Volumes {
+		if v.Secret != nil {
+			secrets = append(secrets, v.Secret.SecretName)
+		}
+	}
+	assert.Contains(secrets, constants.FLPAgentsCertSecretName)
+	assert.NotContains(secrets, constants.FLPAgentsTLSSecretName)
+
+	// Direct mode without mTLS: no TLS
+	cfg.Processor.AgentsTLS = flowslatest.FLPAgentsTLS{}
+	b = monoBuilder("namespace", &cfg)
+	cm, _, _, err = b.configMaps()
+	assert.NoError(err)
+	var decodedNoTLS config.Root
+	err = json.Unmarshal([]byte(cm.Data[configFile]), &decodedNoTLS)
+	assert.NoError(err)
+	assert.Empty(decodedNoTLS.Parameters[0].Ingest.GRPC.CertPath)
+	assert.Empty(decodedNoTLS.Parameters[0].Ingest.GRPC.ClientCAPath)
+}
@@ -171,6 +171,7 @@ func (b *transfoBuilder) serviceMonitor() *monitoringv1.ServiceMonitor {
 		transfoName,
 		b.version,
 		b.info.IsDownstream,
+		b.info.ClusterInfo.UseCertManager(),
 		b.info.ClusterInfo.HasPromServiceDiscoveryRole(),
 	)
 }
@@ -16,10 +16,12 @@ import (
 	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
+	"github.com/netobserv/network-observability-operator/internal/pkg/certs"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager/status"
 	"github.com/netobserv/network-observability-operator/internal/pkg/metrics/alerts"
 	"github.com/netobserv/network-observability-operator/internal/pkg/resources"
+	"github.com/netobserv/network-observability-operator/internal/pkg/watchers"
 )
 
 type transformerReconciler struct {
@@ -123,6 +125,14 @@ func (r *transformerReconciler) reconcile(ctx context.Context, desired *flowslat
 		if _, err = r.Watcher.ProcessCACert(ctx, r.Client, &r.Loki.TLS, r.Namespace); err != nil {
 			return err
 		}
+		// Watch for Loki credentials; need to restart pods in case of rotation
+		authDigest, err := r.Watcher.ProcessHTTPClientAuth(ctx, r.Client, &r.Loki.Auth, r.Namespace)
+		if err != nil {
+			return err
+		}
+		if authDigest != "" {
+			annotations[watchers.Annotation("loki-auth")] = authDigest
+		}
 	}
 
 	// Watch for Kafka certificate if necessary; need to restart pods in case of cert rotation
@@ -137,6 +147,17 @@ func (r *transformerReconciler) reconcile(ctx context.Context, desired *flowslat
 	if err = reconcileMonitoringCerts(ctx, r.Common, &desired.Spec.Processor.Metrics.Server.TLS, r.Namespace); err != nil {
 		return err
 	}
+	// Request metrics certificate to cert-manager if necessary
+	var wanted []certs.ServingCertificate
+	var unused []string
+	if desired.Spec.Processor.Metrics.Server.TLS.Type == flowslatest.ServerTLSAuto {
+		wanted = append(wanted, certs.ServingCertificate{SecretName: constants.FLPTransfoMetricsSvcName, ServiceName: constants.FLPTransfoMetricsSvcName})
+	} else {
+		unused = append(unused, constants.FLPTransfoMetricsSvcName)
+	}
+	if err = reconcileServingCertificates(ctx, r.Common, wanted, unused, annotations); err != nil {
+		return err
+	}
 
 	if err = r.reconcileDeployment(ctx, &desired.Spec.Processor, &builder, annotations); err != nil {
 		return err
@@ -201,7 +222,7 @@ func (r *transformerReconciler) reconcilePrometheusService(ctx context.Context,
 		}
 	}
 	if r.ClusterInfo.HasPromRule() {
-		rules := alerts.BuildMonitoringRules(ctx, builder.desired)
+		rules := alerts.BuildMonitoringRules(ctx, builder.desired, builder.fcSlices)
 		promRules := builder.prometheusRule(rules)
 		if err := reconcilers.GenericReconcile(ctx, r.Managed, &r.Client, r.prometheusRule, promRules, &report, helper.PrometheusRuleChanged); err != nil {
 			return err
@@ -9,6 +9,7 @@ import (
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
 	"github.com/netobserv/network-observability-operator/internal/controller/flp/slicesstatus"
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics/alerts"
 )
 
 func slicesToFilters(fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice) []api.TransformFilterRule {
@@ -121,3 +122,13 @@ func slicesToFCSubnetLabels(fcSlices []sliceslatest.FlowCollectorSlice, configur
 	}
 	return fcLabels
 }
+
+func slicesToHealthRules(fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice) {
+	for i := range fcSlices {
+		rules, warnings := alerts.TenantHealthRules(fc, fcSlices, &fcSlices[i])
+		fcSlices[i].Status.HealthRules = rules
+		if len(warnings) > 0 {
+			slicesstatus.SetHealthRuleWarnings(&fcSlices[i], warnings)
+		}
+	}
+}
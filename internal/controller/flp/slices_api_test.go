@@ -260,3 +260,54 @@ func TestSlicesEnablesWhitelist(t *testing.T) {
 	assert.Equal(t, 1, slicez[2].Status.SubnetLabelsConfigured)
 	assert.Equal(t, `(skipped, not needed)`, slicez[2].Status.FilterApplied)
 }
+
+func TestSlicesHealthRules(t *testing.T) {
+	slicesstatus.Reset(&sliceslatest.FlowCollectorSliceList{})
+	fc := getConfig()
+	fc.Processor.SlicesConfig = &flowslatest.SlicesConfig{Enable: true}
+	fc.Processor.Metrics.HealthRules = &[]flowslatest.FLPHealthRule{
+		{
+			Template: flowslatest.HealthRuleIngress5xxErrors,
+			Variants: []flowslatest.HealthRuleVariant{{Thresholds: flowslatest.HealthRuleThresholds{Warning: "10"}, GroupBy: flowslatest.GroupByNamespace}},
+		},
+	}
+	fcSlices := []sliceslatest.FlowCollectorSlice{
+		{
+			ObjectMeta: v1.ObjectMeta{Name: "a", Namespace: "ns-a"},
+			Spec: sliceslatest.FlowCollectorSliceSpec{
+				HealthRules: []sliceslatest.HealthRuleOverride{
+					{Template: "Ingress5xxErrors", Thresholds: &sliceslatest.HealthRuleThresholds{Warning: "50"}},
+				},
+			},
+		},
+		{
+			ObjectMeta: v1.ObjectMeta{Name: "b", Namespace: "ns-b"},
+			Spec: sliceslatest.FlowCollectorSliceSpec{
+				HealthRules: []sliceslatest.HealthRuleOverride{
+					{Template: "Ingress5xxErrors", Thresholds: &sliceslatest.HealthRuleThresholds{Warning: "fifty"}},
+				},
+			},
+		},
+	}
+	slicesToHealthRules(&fc, fcSlices)
+
+	// Slice a
+	assert.Contains(t, fcSlices[0].Status.HealthRules, sliceslatest.EffectiveHealthRule{
+		Template:   "Ingress5xxErrors",
+		GroupBy:    "Namespace",
+		Mode:       "Alert",
+		Thresholds: sliceslatest.HealthRuleThresholds{Warning: "50"},
+		Overridden: true,
+	})
+	assert.Nil(t, slicesstatus.GetHealthRuleWarningCondition(&fcSlices[0]))
+	// Slice b: invalid override, FlowCollector rules are used
+	assert.Contains(t, fcSlices[1].Status.HealthRules, sliceslatest.EffectiveHealthRule{
+		Template:   "Ingress5xxErrors",
+		GroupBy:    "Namespace",
+		Mode:       "Alert",
+		Thresholds: sliceslatest.HealthRuleThresholds{Warning: "10"},
+	})
+	warning := slicesstatus.GetHealthRuleWarningCondition(&fcSlices[1])
+	assert.NotNil(t, warning)
+	assert.Equal(t, `invalid threshold "fifty" for template Ingress5xxErrors: it must be parsable as a float, override is ignored`, warning.Message)
+}
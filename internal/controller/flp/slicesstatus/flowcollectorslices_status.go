@@ -2,6 +2,7 @@ package slicesstatus
 
 import (
 	"context"
+	"strings"
 
 	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -14,21 +15,25 @@ import (
 )
 
 const (
-	ConditionReady         = "Ready"
-	ConditionSubnetWarning = "SubnetWarning"
+	ConditionReady             = "Ready"
+	ConditionSubnetWarning     = "SubnetWarning"
+	ConditionHealthRuleWarning = "HealthRuleWarning"
 )
 
 var (
-	mapStatuses       map[types.NamespacedName]*metav1.Condition = make(map[types.NamespacedName]*metav1.Condition)
-	mapSubnetWarnings map[types.NamespacedName]*metav1.Condition = make(map[types.NamespacedName]*metav1.Condition)
+	mapStatuses           map[types.NamespacedName]*metav1.Condition = make(map[types.NamespacedName]*metav1.Condition)
+	mapSubnetWarnings     map[types.NamespacedName]*metav1.Condition = make(map[types.NamespacedName]*metav1.Condition)
+	mapHealthRuleWarnings map[types.NamespacedName]*metav1.Condition = make(map[types.NamespacedName]*metav1.Condition)
 )
 
 func Reset(fcs *sliceslatest.FlowCollectorSliceList) {
 	mapStatuses = make(map[types.NamespacedName]*metav1.Condition)
 	mapSubnetWarnings = make(map[types.NamespacedName]*metav1.Condition)
+	mapHealthRuleWarnings = make(map[types.NamespacedName]*metav1.Condition)
 	for i := range fcs.Items {
 		fcs.Items[i].Status.FilterApplied = ""
 		fcs.Items[i].Status.SubnetLabelsConfigured = 0
+		fcs.Items[i].Status.HealthRules = nil
 	}
 }
 
@@ -69,14 +74,25 @@ func AddSubnetWarning(fcs *sliceslatest.FlowCollectorSlice, msg string) {
 	}
 }
 
+func SetHealthRuleWarnings(fcs *sliceslatest.FlowCollectorSlice, msgs []string) {
+	nsname := types.NamespacedName{Name: fcs.Name, Namespace: fcs.Namespace}
+	mapHealthRuleWarnings[nsname] = &metav1.Condition{
+		Type:    ConditionHealthRuleWarning,
+		Reason:  "OverrideIgnored",
+		Message: strings.Join(msgs, "; "),
+		Status:  metav1.ConditionTrue,
+	}
+}
+
 func Sync(ctx context.Context, c client.Client, fcs *sliceslatest.FlowCollectorSliceList) {
 	log := log.FromContext(ctx)
 	log.Info("Syncing FlowCollectorSlices status")
 	for i := range fcs.Items {
 		nsname := types.NamespacedName{Name: fcs.Items[i].Name, Namespace: fcs.Items[i].Namespace}
-		// main condition is mandatory; subnet warning condition is optional
+		// main condition is mandatory; warning conditions are optional
 		if cond, ok := mapStatuses[nsname]; ok {
 			subnetCond := mapSubnetWarnings[nsname]
+			healthRuleCond := mapHealthRuleWarnings[nsname]
 			setStatus(ctx, c, nsname, func(s *sliceslatest.FlowCollectorSliceStatus) {
 				if cond != nil {
 					meta.SetStatusCondition(&s.Conditions, *cond)
@@ -86,8 +102,14 @@ func Sync(ctx context.Context, c client.Client, fcs *sliceslatest.FlowCollectorS
 				} else {
 					meta.RemoveStatusCondition(&s.Conditions, ConditionSubnetWarning)
 				}
+				if healthRuleCond != nil {
+					meta.SetStatusCondition(&s.Conditions, *healthRuleCond)
+				} else {
+					meta.RemoveStatusCondition(&s.Conditions, ConditionHealthRuleWarning)
+				}
 				s.FilterApplied = fcs.Items[i].Status.FilterApplied
 				s.SubnetLabelsConfigured = fcs.Items[i].Status.SubnetLabelsConfigured
+				s.HealthRules = fcs.Items[i].Status.HealthRules
 			})
 		}
 	}
@@ -103,6 +125,11 @@ func GetSubnetWarningCondition(slice *sliceslatest.FlowCollectorSlice) *metav1.C
 	return mapSubnetWarnings[nsname]
 }
 
+func GetHealthRuleWarningCondition(slice *sliceslatest.FlowCollectorSlice) *metav1.Condition {
+	nsname := types.NamespacedName{Name: slice.Name, Namespace: slice.Namespace}
+	return mapHealthRuleWarnings[nsname]
+}
+
 func setStatus(ctx context.Context, c client.Client, nsname types.NamespacedName, applyStatus func(s *sliceslatest.FlowCollectorSliceStatus)) {
 	log := log.FromContext(ctx)
 
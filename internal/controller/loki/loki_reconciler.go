@@ -82,7 +82,7 @@ func (r *LReconciler) Reconcile(ctx context.Context, desired *flowslatest.FlowCo
 		}
 	}
 
-	return nil
+	return r.reconcileLokiStack(ctx, desired)
 }
 
 func (r *LReconciler) reconcileConfigMap(ctx context.Context, builder *builder) (string, error) {
@@ -0,0 +1,201 @@
+package loki
+
+import (
+	"context"
+	"errors"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+)
+
+const (
+	// Tenant used by the openshift-network mode
+	networkTenant = "network"
+	// Schema used for new LokiStacks; it must be effective in the past, and is never changed afterwards
+	schemaVersion       = lokiv1.ObjectStorageSchemaV13
+	schemaEffectiveDate = "2024-01-01"
+	flowsSelector       = `{_RecordType="flowLog"}`
+	conversationsSelect = `{_RecordType=~"newConnection|heartbeat|endConnection"}`
+)
+
+var errLokiStackUnavailable = errors.New("spec.loki.lokiStack.provisioning is enabled but the LokiStack API is not available; the Loki Operator must be installed")
+
+// reconcileLokiStack creates or updates the LokiStack when provisioning is enabled. Owned LokiStacks are only deleted when
+// provisioning is explicitly disabled, so that switching the Loki mode, disabling Loki or removing the provisioning section
+// never deletes stored flows. User-managed LokiStacks are never removed.
+func (r *LReconciler) reconcileLokiStack(ctx context.Context, desired *flowslatest.FlowCollector) error {
+	hasAPI := r.ClusterInfo.HasLokiStack(ctx)
+	if !desired.Spec.UseLokiStackProvisioning() {
+		if !hasAPI || !isLokiStackDeletionRequested(&desired.Spec) {
+			return nil
+		}
+		return r.deleteOwnedLokiStacks(ctx)
+	}
+	if !hasAPI {
+		return errLokiStackUnavailable
+	}
+
+	nbNodes, err := r.ClusterInfo.GetNbNodes()
+	if err != nil {
+		log.FromContext(ctx).Info("Could not get the number of nodes to size the LokiStack, using the smallest size", "error", err)
+	}
+	newStack := buildLokiStack(&desired.Spec, r.Namespace, nbNodes)
+
+	current, err := r.getLokiStack(ctx, &desired.Spec)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return r.CreateOwned(ctx, newStack)
+	}
+	keepCurrentFields(&desired.Spec, newStack, current)
+	if equality.Semantic.DeepDerivative(newStack.Spec, current.Spec) {
+		return nil
+	}
+	return r.UpdateIfOwned(ctx, current, newStack)
+}
+
+// isLokiStackDeletionRequested returns true when spec.loki.lokiStack.provisioning.enable is explicitly set to false
+func isLokiStackDeletionRequested(spec *flowslatest.FlowCollectorSpec) bool {
+	prov := spec.Loki.LokiStack.Provisioning
+	return prov != nil && !prov.Enable
+}
+
+// deleteOwnedLokiStacks deletes every LokiStack created by the operator, including those left after a rename
+func (r *LReconciler) deleteOwnedLokiStacks(ctx context.Context) error {
+	list := lokiv1.LokiStackList{}
+	if err := r.List(ctx, &list, helper.ManagedSelector()); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		if err := r.DeleteIfOwned(ctx, &list.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keepCurrentFields copies into the desired LokiStack the fields that are only set at creation
+func keepCurrentFields(spec *flowslatest.FlowCollectorSpec, desired, current *lokiv1.LokiStack) {
+	// Storage schemas must not be changed once set
+	if len(current.Spec.Storage.Schemas) > 0 {
+		desired.Spec.Storage.Schemas = current.Spec.Storage.Schemas
+	}
+	// The estimated size is not re-evaluated, so that scaling nodes doesn't resize the LokiStack
+	if spec.Loki.LokiStack.Provisioning.Size == "" && current.Spec.Size != "" {
+		desired.Spec.Size = current.Spec.Size
+	}
+}
+
+func (r *LReconciler) getLokiStack(ctx context.Context, spec *flowslatest.FlowCollectorSpec) (*lokiv1.LokiStack, error) {
+	current := lokiv1.LokiStack{}
+	if err := r.Get(ctx, lokiStackName(spec, r.Namespace), &current); err != nil {
+		if kerr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &current, nil
+}
+
+func lokiStackName(spec *flowslatest.FlowCollectorSpec, ns string) types.NamespacedName {
+	nsname := types.NamespacedName{Name: spec.Loki.LokiStack.Name, Namespace: ns}
+	if spec.Loki.LokiStack.Namespace != "" {
+		nsname.Namespace = spec.Loki.LokiStack.Namespace
+	}
+	return nsname
+}
+
+func buildLokiStack(spec *flowslatest.FlowCollectorSpec, ns string, nbNodes uint16) *lokiv1.LokiStack {
+	prov := spec.Loki.LokiStack.Provisioning
+	nsname := lokiStackName(spec, ns)
+	size := lokiv1.LokiStackSizeType(prov.Size)
+	if size == "" {
+		size = lokiStackSize(nbNodes, spec.GetSampling())
+	}
+	secretType := lokiv1.ObjectStorageSecretType(prov.Storage.SecretType)
+	if secretType == "" {
+		secretType = lokiv1.ObjectStorageSecretS3
+	}
+	stack := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsname.Name,
+			Namespace: nsname.Namespace,
+		},
+		Spec: lokiv1.LokiStackSpec{
+			ManagementState: lokiv1.ManagementStateManaged,
+			Size:            size,
+			Storage: lokiv1.ObjectStorageSpec{
+				Schemas: []lokiv1.ObjectStorageSchema{{
+					Version:       schemaVersion,
+					EffectiveDate: schemaEffectiveDate,
+				}},
+				Secret: lokiv1.ObjectStorageSecretSpec{
+					Name: prov.Storage.SecretName,
+					Type: secretType,
+				},
+			},
+			StorageClassName: prov.StorageClassName,
+			Tenants: &lokiv1.TenantsSpec{
+				Mode: lokiv1.OpenshiftNetwork,
+			},
+		},
+	}
+	if retention := buildRetention(prov.Retention); retention != nil {
+		stack.Spec.Limits = &lokiv1.LimitsSpec{
+			Tenants: map[string]lokiv1.PerTenantLimitsTemplateSpec{
+				networkTenant: {
+					Retention: retention,
+				},
+			},
+		}
+	}
+	return &stack
+}
+
+func buildRetention(spec *flowslatest.LokiStackRetention) *lokiv1.RetentionLimitSpec {
+	if spec == nil || (spec.Days == nil && spec.Flows == nil && spec.Conversations == nil) {
+		return nil
+	}
+	retention := lokiv1.RetentionLimitSpec{}
+	if spec.Days != nil {
+		retention.Days = uint(*spec.Days)
+	}
+	if spec.Flows != nil {
+		retention.Streams = append(retention.Streams, &lokiv1.RetentionStreamSpec{
+			Days:     uint(*spec.Flows),
+			Selector: flowsSelector,
+		})
+	}
+	if spec.Conversations != nil {
+		retention.Streams = append(retention.Streams, &lokiv1.RetentionStreamSpec{
+			Days:     uint(*spec.Conversations),
+			Selector: conversationsSelect,
+		})
+	}
+	return &retention
+}
+
+// lokiStackSize estimates the LokiStack size from the flows volume, which grows with the number of nodes
+// and decreases with the sampling ratio. Thresholds are expressed in number of nodes at the default sampling of 50.
+func lokiStackSize(nbNodes uint16, sampling int) lokiv1.LokiStackSizeType {
+	if sampling < 1 {
+		sampling = 1
+	}
+	load := int(nbNodes) * 50 / sampling
+	switch {
+	case load <= 25:
+		return lokiv1.SizeOneXExtraSmall
+	case load <= 120:
+		return lokiv1.SizeOneXSmall
+	default:
+		return lokiv1.SizeOneXMedium
+	}
+}
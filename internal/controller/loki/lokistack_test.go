@@ -0,0 +1,120 @@
+package loki
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+)
+
+func provisionedSpec(prov *flowslatest.LokiStackProvisioning) *flowslatest.FlowCollectorSpec {
+	return &flowslatest.FlowCollectorSpec{
+		Loki: flowslatest.FlowCollectorLoki{
+			Mode: flowslatest.LokiModeLokiStack,
+			LokiStack: flowslatest.LokiStackRef{
+				Name:         "loki",
+				Provisioning: prov,
+			},
+		},
+	}
+}
+
+func TestLokiStackSize(t *testing.T) {
+	assert.Equal(t, lokiv1.SizeOneXExtraSmall, lokiStackSize(0, 50))
+	assert.Equal(t, lokiv1.SizeOneXExtraSmall, lokiStackSize(25, 50))
+	assert.Equal(t, lokiv1.SizeOneXSmall, lokiStackSize(26, 50))
+	assert.Equal(t, lokiv1.SizeOneXSmall, lokiStackSize(100, 50))
+	assert.Equal(t, lokiv1.SizeOneXMedium, lokiStackSize(200, 50))
+	// Lower sampling ratio means more flows
+	assert.Equal(t, lokiv1.SizeOneXSmall, lokiStackSize(10, 10))
+	assert.Equal(t, lokiv1.SizeOneXMedium, lokiStackSize(10, 1))
+	assert.Equal(t, lokiv1.SizeOneXMedium, lokiStackSize(10, 0))
+	// Higher sampling ratio means less flows
+	assert.Equal(t, lokiv1.SizeOneXExtraSmall, lokiStackSize(100, 500))
+}
+
+func TestBuildLokiStack(t *testing.T) {
+	spec := provisionedSpec(&flowslatest.LokiStackProvisioning{
+		Enable:           true,
+		Storage:          flowslatest.LokiStackStorage{SecretName: "loki-s3"},
+		StorageClassName: "gp3-csi",
+	})
+	spec.Loki.LokiStack.Namespace = "loki-ns"
+
+	stack := buildLokiStack(spec, "netobserv", 50)
+	assert.Equal(t, "loki", stack.Name)
+	assert.Equal(t, "loki-ns", stack.Namespace)
+	assert.Equal(t, lokiv1.SizeOneXSmall, stack.Spec.Size)
+	assert.Equal(t, lokiv1.OpenshiftNetwork, stack.Spec.Tenants.Mode)
+	assert.Equal(t, lokiv1.ObjectStorageSecretSpec{Name: "loki-s3", Type: lokiv1.ObjectStorageSecretS3}, stack.Spec.Storage.Secret)
+	assert.Equal(t, "gp3-csi", stack.Spec.StorageClassName)
+	assert.Len(t, stack.Spec.Storage.Schemas, 1)
+	assert.Nil(t, stack.Spec.Limits)
+
+	// Explicit size, default namespace
+	spec.Loki.LokiStack.Namespace = ""
+	spec.Loki.LokiStack.Provisioning.Size = "1x.medium"
+	stack = buildLokiStack(spec, "netobserv", 50)
+	assert.Equal(t, "netobserv", stack.Namespace)
+	assert.Equal(t, lokiv1.SizeOneXMedium, stack.Spec.Size)
+}
+
+func TestKeepCurrentFields(t *testing.T) {
+	spec := provisionedSpec(&flowslatest.LokiStackProvisioning{Enable: true})
+	current := buildLokiStack(spec, "netobserv", 10)
+	current.Spec.Storage.Schemas[0].EffectiveDate = "2023-01-01"
+	assert.Equal(t, lokiv1.SizeOneXExtraSmall, current.Spec.Size)
+
+	// More nodes: the estimated size is kept
+	desired := buildLokiStack(spec, "netobserv", 200)
+	keepCurrentFields(spec, desired, current)
+	assert.Equal(t, lokiv1.SizeOneXExtraSmall, desired.Spec.Size)
+	assert.Equal(t, lokiv1.StorageSchemaEffectiveDate("2023-01-01"), desired.Spec.Storage.Schemas[0].EffectiveDate)
+
+	// Explicit size is applied
+	spec.Loki.LokiStack.Provisioning.Size = "1x.medium"
+	desired = buildLokiStack(spec, "netobserv", 200)
+	keepCurrentFields(spec, desired, current)
+	assert.Equal(t, lokiv1.SizeOneXMedium, desired.Spec.Size)
+}
+
+func TestBuildLokiStackRetention(t *testing.T) {
+	spec := provisionedSpec(&flowslatest.LokiStackProvisioning{
+		Enable:  true,
+		Storage: flowslatest.LokiStackStorage{SecretName: "loki-azure", SecretType: flowslatest.LokiStackStorageAzure},
+		Retention: &flowslatest.LokiStackRetention{
+			Days:          ptr.To(int32(7)),
+			Conversations: ptr.To(int32(30)),
+		},
+	})
+
+	stack := buildLokiStack(spec, "netobserv", 3)
+	assert.Equal(t, lokiv1.ObjectStorageSecretAzure, stack.Spec.Storage.Secret.Type)
+	retention := stack.Spec.Limits.Tenants["network"].Retention
+	assert.Equal(t, uint(7), retention.Days)
+	assert.Equal(t, []*lokiv1.RetentionStreamSpec{{Days: 30, Selector: `{_RecordType=~"newConnection|heartbeat|endConnection"}`}}, retention.Streams)
+
+	spec.Loki.LokiStack.Provisioning.Retention = &flowslatest.LokiStackRetention{}
+	stack = buildLokiStack(spec, "netobserv", 3)
+	assert.Nil(t, stack.Spec.Limits)
+}
+
+func TestLokiStackDeletionRequested(t *testing.T) {
+	// Only an explicit enable: false deletes the LokiStack
+	assert.True(t, isLokiStackDeletionRequested(provisionedSpec(&flowslatest.LokiStackProvisioning{Enable: false})))
+	assert.False(t, isLokiStackDeletionRequested(provisionedSpec(&flowslatest.LokiStackProvisioning{Enable: true})))
+	assert.False(t, isLokiStackDeletionRequested(provisionedSpec(nil)))
+
+	// Switching mode or disabling Loki keeps it
+	spec := provisionedSpec(&flowslatest.LokiStackProvisioning{Enable: true})
+	spec.Loki.Mode = flowslatest.LokiModeMonolithic
+	assert.False(t, spec.UseLokiStackProvisioning())
+	assert.False(t, isLokiStackDeletionRequested(spec))
+	spec = provisionedSpec(&flowslatest.LokiStackProvisioning{Enable: true})
+	spec.Loki.Enable = ptr.To(false)
+	assert.False(t, spec.UseLokiStackProvisioning())
+	assert.False(t, isLokiStackDeletionRequested(spec))
+}
@@ -0,0 +1,62 @@
+package monitoring
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
+	"github.com/netobserv/network-observability-operator/internal/pkg/dashboards"
+)
+
+const flowDashboardConditionReady = "Ready"
+
+// buildFlowDashboards returns the dashboards built from the FlowDashboard resources, per resource name, and updates their status.
+// FlowDashboards referring to metrics that are not enabled are reported in their status and skipped.
+func (r *Reconciler) buildFlowDashboards(ctx context.Context, ns string, allMetrics []metricslatest.FlowMetric) (map[string]*dashboards.Dashboard, error) {
+	fds := metricslatest.FlowDashboardList{}
+	if err := r.Client.List(ctx, &fds, &client.ListOptions{Namespace: ns}); err != nil {
+		return nil, r.status.Error("CantListFlowDashboards", err)
+	}
+	log.FromContext(ctx).WithValues("items count", len(fds.Items)).Info("FlowDashboards loaded")
+
+	custom := make(map[string]*dashboards.Dashboard)
+	for i := range fds.Items {
+		fd := &fds.Items[i]
+		d, buildErr := dashboards.BuildFlowDashboard(fd, allMetrics)
+		if buildErr == nil {
+			custom[customDashboardName(fd)] = d
+		}
+		if err := r.setFlowDashboardStatus(ctx, fd, flowDashboardCondition(buildErr)); err != nil {
+			return nil, err
+		}
+	}
+	return custom, nil
+}
+
+func flowDashboardCondition(err error) metav1.Condition {
+	if err != nil {
+		return metav1.Condition{
+			Type:    flowDashboardConditionReady,
+			Reason:  "MetricNotFound",
+			Message: err.Error(),
+			Status:  metav1.ConditionFalse,
+		}
+	}
+	return metav1.Condition{
+		Type:    flowDashboardConditionReady,
+		Reason:  "Ready",
+		Message: "Dashboard generated",
+		Status:  metav1.ConditionTrue,
+	}
+}
+
+func (r *Reconciler) setFlowDashboardStatus(ctx context.Context, fd *metricslatest.FlowDashboard, cond metav1.Condition) error {
+	if !meta.SetStatusCondition(&fd.Status.Conditions, cond) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Status().Update(ctx, fd))
+}
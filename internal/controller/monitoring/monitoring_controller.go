@@ -3,10 +3,14 @@ package monitoring
 import (
 	"context"
 	"fmt"
+	"time"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -19,10 +23,12 @@ import (
 	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/controller/reconcilers"
+	"github.com/netobserv/network-observability-operator/internal/pkg/dashboards"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager"
 	"github.com/netobserv/network-observability-operator/internal/pkg/manager/status"
 	"github.com/netobserv/network-observability-operator/internal/pkg/metrics"
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics/alerts"
 	"github.com/netobserv/network-observability-operator/internal/pkg/resources"
 )
 
@@ -31,6 +37,8 @@ type Reconciler struct {
 	mgr              *manager.Manager
 	status           status.Instance
 	currentNamespace string
+	// nextMaintenanceUpdate is when the maintenance rules and silences must be rebuilt, at the end of the earliest maintenance window occurrence
+	nextMaintenanceUpdate time.Time
 }
 
 func Start(ctx context.Context, mgr *manager.Manager) (manager.PostCreateHook, error) {
@@ -47,17 +55,24 @@ func Start(ctx context.Context, mgr *manager.Manager) (manager.PostCreateHook, e
 		Owns(&corev1.Namespace{}, reconcilers.UpdateOrDeleteOnlyPred).
 		Watches(
 			&metricslatest.FlowMetric{},
-			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
-				if o.GetNamespace() == r.currentNamespace {
-					return []reconcile.Request{{NamespacedName: constants.FlowCollectorName}}
-				}
-				return []reconcile.Request{}
-			}),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueFromCurrentNamespace),
+			reconcilers.IgnoreStatusChange,
+		).
+		Watches(
+			&metricslatest.FlowDashboard{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueFromCurrentNamespace),
 			reconcilers.IgnoreStatusChange,
 		).
 		Complete(&r)
 }
 
+func (r *Reconciler) enqueueFromCurrentNamespace(_ context.Context, o client.Object) []reconcile.Request {
+	if o.GetNamespace() == r.currentNamespace {
+		return []reconcile.Request{{NamespacedName: constants.FlowCollectorName}}
+	}
+	return []reconcile.Request{}
+}
+
 // Reconcile is the controller entry point for reconciling current state with desired state.
 // It manages the controller status at a high level. Business logic is delegated into `reconcile`.
 func (r *Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
@@ -87,6 +102,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result
 	}
 
 	r.status.SetReady()
+	if !r.nextMaintenanceUpdate.IsZero() {
+		return ctrl.Result{RequeueAfter: time.Until(r.nextMaintenanceUpdate)}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -121,7 +139,15 @@ func (r *Reconciler) reconcile(ctx context.Context, clh *helper.Client, desired
 	}
 
 	// Dashboards
-	if r.mgr.ClusterInfo.IsOpenShift() && r.mgr.ClusterInfo.HasSvcMonitor() {
+	consoleDashboards := r.mgr.ClusterInfo.IsOpenShift() && r.mgr.ClusterInfo.HasSvcMonitor()
+	grafana := desired.Spec.GetGrafanaDashboards()
+	grafanaMode := r.grafanaMode(grafana.Mode)
+	hasPerses := r.mgr.ClusterInfo.HasPerses()
+	useRecordingRules := desired.Spec.UseDashboardsRecordingRules() && r.mgr.ClusterInfo.HasPromRule()
+	var cms []*corev1.ConfigMap
+	var persesDashboards []*unstructured.Unstructured
+	var recordingRules []monitoringv1.Rule
+	if consoleDashboards || grafanaMode != flowslatest.GrafanaDashboardsDisabled || hasPerses {
 		// List custom metrics
 		fm := metricslatest.FlowMetricList{}
 		if err := r.Client.List(ctx, &fm, &client.ListOptions{Namespace: ns}); err != nil {
@@ -132,43 +158,220 @@ func (r *Reconciler) reconcile(ctx context.Context, clh *helper.Client, desired
 		allMetrics := metrics.MergePredefined(fm.Items, &desired.Spec)
 		log.WithValues("metrics count", len(allMetrics)).Info("Merged metrics")
 
-		req, err := labels.NewRequirement("netobserv-managed", selection.Exists, []string{})
+		// Build desired dashboards
+		flowMetricsDashboards := dashboards.BuildFlowMetricsDashboards(allMetrics)
+		nsFlowsMetric := getNamespacedFlowsMetric(allMetrics)
+		healthDashboard := dashboards.BuildHealthDashboard(ns, nsFlowsMetric)
+
+		// Build dashboards from FlowDashboards
+		custom, err := r.buildFlowDashboards(ctx, ns, allMetrics)
 		if err != nil {
-			return r.status.Error("CantQueryRequirement", err)
+			return err
 		}
-		// List existing dashboards
-		currentDashboards := corev1.ConfigMapList{}
-		if err := r.Client.List(ctx, &currentDashboards, &client.ListOptions{
-			Namespace:     dashboardCMNamespace,
-			LabelSelector: labels.NewSelector().Add(*req),
-		}); err != nil {
-			return r.status.Error("CantListDashboards", err)
+
+		if useRecordingRules {
+			all := []*dashboards.Dashboard{healthDashboard}
+			for _, d := range flowMetricsDashboards {
+				all = append(all, d)
+			}
+			for _, d := range custom {
+				all = append(all, d)
+			}
+			recordingRules = dashboards.UseRecordingRules(allMetrics, all...)
 		}
 
-		// Build desired dashboards
-		cms := buildFlowMetricsDashboards(allMetrics)
-		nsFlowsMetric := getNamespacedFlowsMetric(allMetrics)
-		if desiredHealthDashboardCM, del, err := buildHealthDashboard(ns, nsFlowsMetric); err != nil {
-			return err
-		} else if !del {
+		cms = buildFlowMetricsDashboards(flowMetricsDashboards)
+		if desiredHealthDashboardCM, del := buildHealthDashboard(healthDashboard); !del {
 			cms = append(cms, desiredHealthDashboardCM)
 		}
+		cms = append(cms, buildCustomDashboards(custom)...)
 
-		for _, cm := range cms {
-			current := findAndRemoveConfigMapFromList(&currentDashboards, cm.Name)
-			if err := reconcilers.ReconcileConfigMap(ctx, clh, current, cm); err != nil {
-				return err
+		if hasPerses {
+			if persesDashboards, err = buildPersesDashboards(ns, flowMetricsDashboards, healthDashboard, custom); err != nil {
+				return r.status.Error("CantBuildPersesDashboards", err)
 			}
 		}
+	}
+
+	if r.mgr.ClusterInfo.HasPromRule() {
+		var desiredRules *monitoringv1.PrometheusRule
+		if len(recordingRules) > 0 {
+			desiredRules = buildRecordingRules(ns, recordingRules)
+		}
+		if err := r.reconcilePrometheusRule(ctx, clh, ns, recordingRulesName, desiredRules); err != nil {
+			return err
+		}
+	}
+
+	if consoleDashboards {
+		if err := r.reconcileDashboardConfigMaps(ctx, clh, dashboardCMNamespace, "netobserv-managed", cms); err != nil {
+			return err
+		}
+	}
+
+	if hasPerses {
+		if err := r.reconcileDashboardObjects(ctx, clh, persesDashboardGVK, persesDashboardLabel, persesDashboards); err != nil {
+			return err
+		}
+	}
+
+	if err := r.reconcileGrafanaDashboards(ctx, clh, &grafana, grafanaMode, cms); err != nil {
+		return err
+	}
 
-		// Delete any CM that remained in currentDashboards list
-		for i := range currentDashboards.Items {
-			if err := reconcilers.ReconcileConfigMap(ctx, clh, &currentDashboards.Items[i], nil); err != nil {
+	return r.reconcileMaintenanceWindows(ctx, clh, &desired.Spec)
+}
+
+// reconcilePrometheusRule creates or updates a PrometheusRule, or deletes it when desired is nil
+func (r *Reconciler) reconcilePrometheusRule(ctx context.Context, clh *helper.Client, ns, name string, desired *monitoringv1.PrometheusRule) error {
+	current := &monitoringv1.PrometheusRule{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: ns}, current); err != nil {
+		if !errors.IsNotFound(err) {
+			return r.status.Error("CantGetPrometheusRule", err)
+		}
+		current = nil
+	}
+	return reconcilers.ReconcilePrometheusRule(ctx, clh, current, desired)
+}
+
+// reconcileMaintenanceWindows records the maintenance windows for the health dashboard, and creates the matching silences in Alertmanager when configured.
+// When silences cannot be created, the recorded windows inhibit the health rule alerts instead.
+func (r *Reconciler) reconcileMaintenanceWindows(ctx context.Context, clh *helper.Client, spec *flowslatest.FlowCollectorSpec) error {
+	now := time.Now()
+	inhibit := true
+	var syncErr error
+	if spec.UseAlertManagerSilences() {
+		// Always sync, even without windows, so that the silences previously created by the operator are expired,
+		// including when the windows were removed while the operator was not running
+		syncErr = r.syncSilences(ctx, spec, alerts.BuildMaintenanceSilences(spec, now))
+		if syncErr == nil {
+			inhibit = false
+		} else {
+			log.FromContext(ctx).Error(syncErr, "Could not sync Alertmanager silences, falling back to alerts inhibition")
+		}
+	}
+
+	rules, nextUpdate := alerts.BuildMaintenanceRules(spec, now, inhibit)
+	r.nextMaintenanceUpdate = nextUpdate
+	if r.mgr.ClusterInfo.HasPromRule() {
+		var desired *monitoringv1.PrometheusRule
+		if len(rules) > 0 {
+			desired = buildMaintenanceRules(spec.GetNamespace(), rules)
+		}
+		if err := r.reconcilePrometheusRule(ctx, clh, spec.GetNamespace(), maintenanceRulesName, desired); err != nil {
+			return err
+		}
+	}
+	if syncErr != nil {
+		return r.status.Error("CantSyncSilences", syncErr)
+	}
+	return nil
+}
+
+func (r *Reconciler) syncSilences(ctx context.Context, spec *flowslatest.FlowCollectorSpec, silences []alerts.Silence) error {
+	amc, err := newAlertManagerClient(ctx, r.Client, spec.Prometheus.Querier.Manual.AlertManager, spec.GetNamespace())
+	if err != nil {
+		return err
+	}
+	return amc.syncSilences(ctx, silences)
+}
+
+// grafanaMode resolves the "Auto" mode depending on the available APIs
+func (r *Reconciler) grafanaMode(mode flowslatest.GrafanaDashboardsMode) flowslatest.GrafanaDashboardsMode {
+	if mode == flowslatest.GrafanaDashboardsAuto {
+		if r.mgr.ClusterInfo.HasGrafanaOperator() {
+			return flowslatest.GrafanaDashboardsGrafanaOperator
+		}
+		return flowslatest.GrafanaDashboardsDisabled
+	}
+	return mode
+}
+
+// reconcileDashboardConfigMaps creates or updates the desired dashboard ConfigMaps, and deletes the other ones matching the label.
+// An empty namespace means that ConfigMaps are looked up across all namespaces.
+func (r *Reconciler) reconcileDashboardConfigMaps(ctx context.Context, clh *helper.Client, namespace, label string, cms []*corev1.ConfigMap) error {
+	req, err := labels.NewRequirement(label, selection.Exists, []string{})
+	if err != nil {
+		return r.status.Error("CantQueryRequirement", err)
+	}
+	// List existing dashboards
+	currentDashboards := corev1.ConfigMapList{}
+	if err := r.Client.List(ctx, &currentDashboards, &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: labels.NewSelector().Add(*req),
+	}); err != nil {
+		return r.status.Error("CantListDashboards", err)
+	}
+
+	for _, cm := range cms {
+		current := findAndRemoveConfigMapFromList(&currentDashboards, cm.Name, cm.Namespace)
+		if err := reconcilers.ReconcileConfigMap(ctx, clh, current, cm); err != nil {
+			return err
+		}
+	}
+
+	// Delete any CM that remained in currentDashboards list
+	for i := range currentDashboards.Items {
+		if err := reconcilers.ReconcileConfigMap(ctx, clh, &currentDashboards.Items[i], nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileGrafanaDashboards provides the dashboards to Grafana, either as GrafanaDashboard resources or as sidecar ConfigMaps,
+// and deletes the ones that are not desired anymore, such as after a mode or namespace change.
+func (r *Reconciler) reconcileGrafanaDashboards(ctx context.Context, clh *helper.Client, cfg *flowslatest.GrafanaDashboards, mode flowslatest.GrafanaDashboardsMode, cms []*corev1.ConfigMap) error {
+	var sidecarCMs []*corev1.ConfigMap
+	var grafanaDashboards []*unstructured.Unstructured
+	var err error
+	switch mode {
+	case flowslatest.GrafanaDashboardsSidecar:
+		if sidecarCMs, err = buildGrafanaSidecarConfigMaps(cms, cfg); err != nil {
+			return r.status.Error("CantBuildGrafanaDashboards", err)
+		}
+	case flowslatest.GrafanaDashboardsGrafanaOperator:
+		if !r.mgr.ClusterInfo.HasGrafanaOperator() {
+			return r.status.Error("GrafanaOperatorNotFound", fmt.Errorf("the GrafanaDashboard API (%s) is not available", grafanaDashboardGVK.GroupVersion()))
+		}
+		if grafanaDashboards, err = buildGrafanaDashboards(cms, cfg); err != nil {
+			return r.status.Error("CantBuildGrafanaDashboards", err)
+		}
+	case flowslatest.GrafanaDashboardsAuto, flowslatest.GrafanaDashboardsDisabled:
+	}
+
+	if err := r.reconcileDashboardConfigMaps(ctx, clh, "", grafanaDashboardLabel, sidecarCMs); err != nil {
+		return err
+	}
+
+	if !r.mgr.ClusterInfo.HasGrafanaOperator() {
+		return nil
+	}
+	return r.reconcileDashboardObjects(ctx, clh, grafanaDashboardGVK, grafanaDashboardLabel, grafanaDashboards)
+}
+
+// reconcileDashboardObjects creates or updates the desired third-party dashboard resources, and deletes the other ones matching the label
+func (r *Reconciler) reconcileDashboardObjects(ctx context.Context, clh *helper.Client, gvk schema.GroupVersionKind, label string, objs []*unstructured.Unstructured) error {
+	current := unstructured.UnstructuredList{}
+	current.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := r.Client.List(ctx, &current, client.HasLabels{label}); err != nil {
+		return r.status.Error("CantListDashboards", err)
+	}
+	desired := map[types.NamespacedName]bool{}
+	for _, obj := range objs {
+		desired[types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}] = true
+		if err := clh.ReconcileUnstructured(ctx, obj); err != nil {
+			return err
+		}
+	}
+	for i := range current.Items {
+		obj := &current.Items[i]
+		if !desired[types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}] {
+			if err := clh.DeleteIfOwned(ctx, obj); err != nil {
 				return err
 			}
 		}
 	}
-
 	return nil
 }
 
@@ -181,9 +384,9 @@ func getNamespacedFlowsMetric(metrics []metricslatest.FlowMetric) string {
 	return "netobserv_workload_flows_total"
 }
 
-func findAndRemoveConfigMapFromList(list *corev1.ConfigMapList, name string) *corev1.ConfigMap {
+func findAndRemoveConfigMapFromList(list *corev1.ConfigMapList, name, namespace string) *corev1.ConfigMap {
 	for i := len(list.Items) - 1; i >= 0; i-- {
-		if list.Items[i].Name == name {
+		if list.Items[i].Name == name && list.Items[i].Namespace == namespace {
 			cm := list.Items[i]
 			// Remove that element from the list, so the list ends up with elements to delete
 			removeFromList(list, i)
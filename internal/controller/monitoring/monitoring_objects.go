@@ -4,10 +4,17 @@ import (
 	"regexp"
 	"strings"
 
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	"github.com/netobserv/network-observability-operator/internal/pkg/dashboards"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const (
@@ -21,6 +28,18 @@ const (
 
 	healthDashboardCMName = "grafana-dashboard-netobserv-health"
 	healthDashboardCMFile = "netobserv-health-metrics.json"
+
+	grafanaDashboardLabel = "netobserv.io/grafana-dashboard"
+	persesDashboardLabel  = "netobserv.io/perses-dashboard"
+	persesHealthName      = "netobserv-health"
+
+	recordingRulesName   = "netobserv-dashboards"
+	maintenanceRulesName = "netobserv-maintenance"
+)
+
+var (
+	grafanaDashboardGVK = schema.GroupVersionKind{Group: "grafana.integreatly.org", Version: "v1beta1", Kind: "GrafanaDashboard"}
+	persesDashboardGVK  = schema.GroupVersionKind{Group: "perses.dev", Version: "v1alpha1", Kind: "PersesDashboard"}
 )
 
 var k8sInvalidChar = regexp.MustCompile(`[^a-z0-9\-]`)
@@ -38,12 +57,14 @@ func buildNamespace(ns string, isDownstream bool) *corev1.Namespace {
 	}
 }
 
-func buildFlowMetricsDashboards(metrics []metricslatest.FlowMetric) []*corev1.ConfigMap {
-	var cms []*corev1.ConfigMap
-	dash := dashboards.CreateFlowMetricsDashboards(metrics)
+func dashboardK8sName(name string) string {
+	return "netobserv-" + k8sInvalidChar.ReplaceAllString(strings.ToLower(name), "-")
+}
 
-	for name, json := range dash {
-		k8sName := "netobserv-" + k8sInvalidChar.ReplaceAllString(strings.ToLower(name), "-")
+func buildFlowMetricsDashboards(dash map[string]*dashboards.Dashboard) []*corev1.ConfigMap {
+	var cms []*corev1.ConfigMap
+	for name, d := range dash {
+		k8sName := dashboardK8sName(name)
 		configMap := corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      k8sName,
@@ -53,7 +74,7 @@ func buildFlowMetricsDashboards(metrics []metricslatest.FlowMetric) []*corev1.Co
 				},
 			},
 			Data: map[string]string{
-				flowDashboardCMFile: json,
+				flowDashboardCMFile: d.ToGrafanaJSON(),
 			},
 		}
 		cms = append(cms, &configMap)
@@ -61,12 +82,8 @@ func buildFlowMetricsDashboards(metrics []metricslatest.FlowMetric) []*corev1.Co
 	return cms
 }
 
-func buildHealthDashboard(namespace, nsFlowsMetric string) (*corev1.ConfigMap, bool, error) {
-	dashboard, err := dashboards.CreateHealthDashboard(namespace, nsFlowsMetric)
-	if err != nil {
-		return nil, false, err
-	}
-
+func buildHealthDashboard(d *dashboards.Dashboard) (*corev1.ConfigMap, bool) {
+	dashboard := d.ToGrafanaJSON()
 	configMap := corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      healthDashboardCMName,
@@ -79,5 +96,140 @@ func buildHealthDashboard(namespace, nsFlowsMetric string) (*corev1.ConfigMap, b
 			healthDashboardCMFile: dashboard,
 		},
 	}
-	return &configMap, len(dashboard) == 0, nil
+	return &configMap, len(dashboard) == 0
+}
+
+// dashboardJSON returns the dashboard JSON held by a console dashboard ConfigMap
+func dashboardJSON(cm *corev1.ConfigMap) string {
+	for _, json := range cm.Data {
+		return json
+	}
+	return ""
+}
+
+func buildGrafanaDashboards(cms []*corev1.ConfigMap, cfg *flowslatest.GrafanaDashboards) ([]*unstructured.Unstructured, error) {
+	selector := map[string]interface{}{}
+	for k, v := range cfg.InstanceSelector {
+		selector[k] = v
+	}
+	var objs []*unstructured.Unstructured
+	for _, cm := range cms {
+		json, err := dashboards.WithDatasource(dashboardJSON(cm), cfg.Datasource)
+		if err != nil {
+			return nil, err
+		}
+		obj := helper.NewUnstructured(grafanaDashboardGVK, cm.Name, cfg.Namespace, map[string]interface{}{
+			"instanceSelector": map[string]interface{}{
+				"matchLabels": selector,
+			},
+			"folder": cfg.Folder,
+			"json":   json,
+		})
+		obj.SetLabels(map[string]string{grafanaDashboardLabel: "true"})
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func buildGrafanaSidecarConfigMaps(cms []*corev1.ConfigMap, cfg *flowslatest.GrafanaDashboards) ([]*corev1.ConfigMap, error) {
+	var sidecarCMs []*corev1.ConfigMap
+	for _, cm := range cms {
+		json, err := dashboards.WithDatasource(dashboardJSON(cm), cfg.Datasource)
+		if err != nil {
+			return nil, err
+		}
+		sidecarCMs = append(sidecarCMs, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cm.Name,
+				Namespace: cfg.Namespace,
+				Labels: map[string]string{
+					cfg.SidecarLabel:      "1",
+					grafanaDashboardLabel: "true",
+				},
+				Annotations: map[string]string{
+					// folder annotation used by the Grafana Helm chart sidecar, when "folderAnnotation" is configured with this name
+					"grafana_folder": cfg.Folder,
+				},
+			},
+			Data: map[string]string{
+				cm.Name + ".json": json,
+			},
+		})
+	}
+	return sidecarCMs, nil
+}
+
+func customDashboardName(fd *metricslatest.FlowDashboard) string {
+	return "netobserv-dashboard-" + fd.Name
+}
+
+func buildCustomDashboards(custom map[string]*dashboards.Dashboard) []*corev1.ConfigMap {
+	var cms []*corev1.ConfigMap
+	for name, d := range custom {
+		cms = append(cms, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: dashboardCMNamespace,
+				Labels: map[string]string{
+					dashboardCMAnnotation: "true",
+				},
+			},
+			Data: map[string]string{
+				flowDashboardCMFile: d.ToGrafanaJSON(),
+			},
+		})
+	}
+	return cms
+}
+
+func buildPersesDashboards(namespace string, flowMetrics map[string]*dashboards.Dashboard, health *dashboards.Dashboard, custom map[string]*dashboards.Dashboard) ([]*unstructured.Unstructured, error) {
+	all := map[string]*dashboards.Dashboard{persesHealthName: health}
+	for name, d := range flowMetrics {
+		all[dashboardK8sName(name)] = d
+	}
+	for name, d := range custom {
+		all[name] = d
+	}
+	var objs []*unstructured.Unstructured
+	for name, d := range all {
+		if len(d.Rows) == 0 {
+			continue
+		}
+		spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(d.ToPersesSpec())
+		if err != nil {
+			return nil, err
+		}
+		obj := helper.NewUnstructured(persesDashboardGVK, name, namespace, spec)
+		obj.SetLabels(map[string]string{persesDashboardLabel: "true"})
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func buildRecordingRules(namespace string, rules []monitoringv1.Rule) *monitoringv1.PrometheusRule {
+	return buildPrometheusRule(recordingRulesName, namespace, "NetObservDashboards", rules)
+}
+
+func buildMaintenanceRules(namespace string, rules []monitoringv1.Rule) *monitoringv1.PrometheusRule {
+	return buildPrometheusRule(maintenanceRulesName, namespace, "NetObservMaintenance", rules)
+}
+
+func buildPrometheusRule(name, namespace, group string, rules []monitoringv1.Rule) *monitoringv1.PrometheusRule {
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"part-of": constants.OperatorName,
+			},
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name:  group,
+					Rules: rules,
+				},
+			},
+		},
+	}
 }
@@ -0,0 +1,173 @@
+package monitoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
+	"github.com/netobserv/network-observability-operator/internal/pkg/dashboards"
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics"
+	"github.com/netobserv/network-observability-operator/internal/pkg/test/util"
+)
+
+func grafanaConfig() flowslatest.GrafanaDashboards {
+	spec := flowslatest.FlowCollectorSpec{
+		Namespace: "netobserv",
+		Processor: flowslatest.FlowCollectorFLP{
+			Metrics: flowslatest.FLPMetrics{
+				Dashboards: &flowslatest.FLPDashboards{
+					Grafana: flowslatest.GrafanaDashboards{Namespace: "grafana", Datasource: "thanos"},
+				},
+			},
+		},
+	}
+	return spec.GetGrafanaDashboards()
+}
+
+func TestBuildGrafanaDashboards(t *testing.T) {
+	cfg := grafanaConfig()
+	cm, del := buildHealthDashboard(dashboards.BuildHealthDashboard("netobserv", "netobserv_namespace_flows_total"))
+	require.False(t, del)
+
+	objs, err := buildGrafanaDashboards([]*corev1.ConfigMap{cm}, &cfg)
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	obj := objs[0]
+	assert.Equal(t, grafanaDashboardGVK, obj.GroupVersionKind())
+	assert.Equal(t, "grafana-dashboard-netobserv-health", obj.GetName())
+	assert.Equal(t, "grafana", obj.GetNamespace())
+	assert.Equal(t, "true", obj.GetLabels()[grafanaDashboardLabel])
+
+	folder, _, _ := unstructured.NestedString(obj.Object, "spec", "folder")
+	assert.Equal(t, "NetObserv", folder)
+	selector, _, _ := unstructured.NestedMap(obj.Object, "spec", "instanceSelector", "matchLabels")
+	assert.Equal(t, map[string]interface{}{"dashboards": "grafana"}, selector)
+	json, _, _ := unstructured.NestedString(obj.Object, "spec", "json")
+	assert.Contains(t, json, `"datasource":"thanos"`)
+	assert.Contains(t, json, `"title":"NetObserv / Health"`)
+}
+
+func TestBuildGrafanaSidecarConfigMaps(t *testing.T) {
+	cfg := grafanaConfig()
+	cfg.Folder = "Network"
+	cm, _ := buildHealthDashboard(dashboards.BuildHealthDashboard("netobserv", "netobserv_namespace_flows_total"))
+
+	sidecarCMs, err := buildGrafanaSidecarConfigMaps([]*corev1.ConfigMap{cm}, &cfg)
+	require.NoError(t, err)
+	require.Len(t, sidecarCMs, 1)
+	sidecar := sidecarCMs[0]
+	assert.Equal(t, "grafana-dashboard-netobserv-health", sidecar.Name)
+	assert.Equal(t, "grafana", sidecar.Namespace)
+	assert.Equal(t, map[string]string{"grafana_dashboard": "1", grafanaDashboardLabel: "true"}, sidecar.Labels)
+	assert.Equal(t, "Network", sidecar.Annotations["grafana_folder"])
+	assert.Contains(t, sidecar.Data["grafana-dashboard-netobserv-health.json"], `"datasource":"thanos"`)
+}
+
+func TestBuildPersesDashboards(t *testing.T) {
+	spec := util.SpecForMetrics("node_ingress_bytes_total")
+	objs, err := buildPersesDashboards(
+		"netobserv",
+		dashboards.BuildFlowMetricsDashboards(metrics.GetDefinitions(spec, false)),
+		dashboards.BuildHealthDashboard("netobserv", "netobserv_namespace_flows_total"),
+		nil,
+	)
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+
+	names := map[string]*unstructured.Unstructured{}
+	for _, obj := range objs {
+		assert.Equal(t, persesDashboardGVK, obj.GroupVersionKind())
+		assert.Equal(t, "netobserv", obj.GetNamespace())
+		assert.Equal(t, "true", obj.GetLabels()[persesDashboardLabel])
+		names[obj.GetName()] = obj
+	}
+	require.Contains(t, names, "netobserv-main")
+	require.Contains(t, names, "netobserv-health")
+
+	title, _, _ := unstructured.NestedString(names["netobserv-main"].Object, "spec", "display", "name")
+	assert.Equal(t, "NetObserv / Main", title)
+	layouts, _, _ := unstructured.NestedSlice(names["netobserv-main"].Object, "spec", "layouts")
+	assert.Len(t, layouts, 2)
+	kind, _, _ := unstructured.NestedString(names["netobserv-health"].Object, "spec", "panels", "0_0", "spec", "plugin", "kind")
+	assert.Equal(t, "StatChart", kind)
+}
+
+func TestBuildCustomDashboards(t *testing.T) {
+	defs := metrics.GetDefinitions(util.SpecForMetrics("namespace_flows_total"), false)
+	fd := metricslatest.FlowDashboard{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: "netobserv"},
+		Spec: metricslatest.FlowDashboardSpec{
+			Title: "My dashboard",
+			Rows: []metricslatest.DashboardRow{{
+				Panels: []metricslatest.DashboardPanel{{
+					Title:   "Flows",
+					Type:    metricslatest.ChartTypeSingleStat,
+					Queries: []metricslatest.DashboardQuery{{Metric: "namespace_flows_total", PromQL: "sum(rate($METRIC[2m]))"}},
+				}},
+			}},
+		},
+	}
+	d, err := dashboards.BuildFlowDashboard(&fd, defs)
+	require.NoError(t, err)
+	custom := map[string]*dashboards.Dashboard{customDashboardName(&fd): d}
+
+	cms := buildCustomDashboards(custom)
+	require.Len(t, cms, 1)
+	assert.Equal(t, "netobserv-dashboard-custom", cms[0].Name)
+	assert.Equal(t, dashboardCMNamespace, cms[0].Namespace)
+	assert.Equal(t, "true", cms[0].Labels[dashboardCMAnnotation])
+	assert.Contains(t, cms[0].Data[flowDashboardCMFile], `"title": "NetObserv / My dashboard"`)
+
+	objs, err := buildPersesDashboards("netobserv", nil, dashboards.BuildHealthDashboard("netobserv", "netobserv_namespace_flows_total"), custom)
+	require.NoError(t, err)
+	names := []string{}
+	for _, obj := range objs {
+		names = append(names, obj.GetName())
+	}
+	assert.ElementsMatch(t, []string{"netobserv-health", "netobserv-dashboard-custom"}, names)
+}
+
+func TestFlowDashboardCondition(t *testing.T) {
+	cond := flowDashboardCondition(nil)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, "Ready", cond.Reason)
+
+	_, err := dashboards.BuildFlowDashboard(&metricslatest.FlowDashboard{
+		Spec: metricslatest.FlowDashboardSpec{
+			Rows: []metricslatest.DashboardRow{{
+				Panels: []metricslatest.DashboardPanel{{
+					Title:   "Flows",
+					Type:    metricslatest.ChartTypeLine,
+					Queries: []metricslatest.DashboardQuery{{Metric: "unknown", PromQL: "sum(rate($METRIC[2m]))"}},
+				}},
+			}},
+		},
+	}, nil)
+	cond = flowDashboardCondition(err)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "MetricNotFound", cond.Reason)
+	assert.Equal(t, "referenced metrics not found or not enabled: unknown", cond.Message)
+}
+
+func TestBuildRecordingRules(t *testing.T) {
+	defs := metrics.GetDefinitions(util.SpecForMetrics("namespace_flows_total"), false)
+	health := dashboards.BuildHealthDashboard("netobserv", "netobserv_namespace_flows_total")
+	rules := dashboards.UseRecordingRules(defs, health)
+	require.Len(t, rules, 1)
+
+	cm, _ := buildHealthDashboard(health)
+	assert.Contains(t, cm.Data[healthDashboardCMFile], "netobserv:namespace_flows_total:rate1m")
+
+	pr := buildRecordingRules("netobserv", rules)
+	assert.Equal(t, "netobserv-dashboards", pr.Name)
+	assert.Equal(t, "netobserv", pr.Namespace)
+	require.Len(t, pr.Spec.Groups, 1)
+	assert.Equal(t, "NetObservDashboards", pr.Spec.Groups[0].Name)
+	assert.Equal(t, rules, pr.Spec.Groups[0].Rules)
+}
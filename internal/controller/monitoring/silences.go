@@ -0,0 +1,216 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics/alerts"
+)
+
+const alertManagerTimeout = 10 * time.Second
+
+// gettableSilence is a silence as returned by the Alertmanager v2 API
+type gettableSilence struct {
+	alerts.Silence
+	ID     string `json:"id"`
+	Status struct {
+		State string `json:"state"`
+	} `json:"status"`
+}
+
+// alertManagerClient manages silences through the Alertmanager v2 API
+type alertManagerClient struct {
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// newAlertManagerClient returns a client configured from the FlowCollector Alertmanager settings, reading the credentials from secrets or config maps
+func newAlertManagerClient(ctx context.Context, cl client.Client, cfg *flowslatest.AlertManagerQuerierManual, namespace string) (*alertManagerClient, error) {
+	amc := alertManagerClient{
+		url:     strings.TrimSuffix(cfg.URL, "/"),
+		headers: map[string]string{},
+	}
+	transport := &http.Transport{}
+	if cfg.TLS.Enable {
+		tlsConfig := &tls.Config{InsecureSkipVerify: cfg.TLS.InsecureSkipVerify}
+		if !cfg.TLS.InsecureSkipVerify && cfg.TLS.CACert.Name != "" {
+			ca, err := readReference(ctx, cl, cfg.TLS.CACert.Type, cfg.TLS.CACert.Name, cfg.TLS.CACert.Namespace, cfg.TLS.CACert.CertFile, namespace)
+			if err != nil {
+				return nil, err
+			}
+			pool := x509.NewCertPool()
+			pool.AppendCertsFromPEM(ca)
+			tlsConfig.RootCAs = pool
+		}
+		if ref := &cfg.TLS.UserCert; ref.Name != "" {
+			cert, err := readReference(ctx, cl, ref.Type, ref.Name, ref.Namespace, ref.CertFile, namespace)
+			if err != nil {
+				return nil, err
+			}
+			key, err := readReference(ctx, cl, ref.Type, ref.Name, ref.Namespace, ref.CertKey, namespace)
+			if err != nil {
+				return nil, err
+			}
+			pair, err := tls.X509KeyPair(cert, key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Alertmanager user certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	amc.httpClient = &http.Client{Timeout: alertManagerTimeout, Transport: transport}
+
+	read := func(ref *flowslatest.FileReference) (string, error) {
+		if ref == nil {
+			return "", nil
+		}
+		b, err := readReference(ctx, cl, ref.Type, ref.Name, ref.Namespace, ref.File, namespace)
+		return strings.TrimSpace(string(b)), err
+	}
+	switch cfg.Auth.Type {
+	case flowslatest.HTTPClientAuthBasic:
+		user, err := read(cfg.Auth.Username)
+		if err != nil {
+			return nil, err
+		}
+		password, err := read(cfg.Auth.Password)
+		if err != nil {
+			return nil, err
+		}
+		req := http.Request{Header: http.Header{}}
+		req.SetBasicAuth(user, password)
+		amc.headers["Authorization"] = req.Header.Get("Authorization")
+	case flowslatest.HTTPClientAuthBearer:
+		token, err := read(cfg.Auth.Token)
+		if err != nil {
+			return nil, err
+		}
+		amc.headers["Authorization"] = "Bearer " + token
+	case flowslatest.HTTPClientAuthNone:
+	}
+	for i := range cfg.Auth.Headers {
+		value, err := read(&cfg.Auth.Headers[i].ValueFrom)
+		if err != nil {
+			return nil, err
+		}
+		amc.headers[cfg.Auth.Headers[i].Name] = value
+	}
+	return &amc, nil
+}
+
+func readReference(ctx context.Context, cl client.Client, refType flowslatest.MountableType, name, namespace, file, defaultNamespace string) ([]byte, error) {
+	nsname := types.NamespacedName{Name: name, Namespace: namespace}
+	if nsname.Namespace == "" {
+		nsname.Namespace = defaultNamespace
+	}
+	if refType == flowslatest.RefTypeSecret {
+		secret := corev1.Secret{}
+		if err := cl.Get(ctx, nsname, &secret); err != nil {
+			return nil, err
+		}
+		return secret.Data[file], nil
+	}
+	cm := corev1.ConfigMap{}
+	if err := cl.Get(ctx, nsname, &cm); err != nil {
+		return nil, err
+	}
+	return []byte(cm.Data[file]), nil
+}
+
+func (c *alertManagerClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from Alertmanager %s %s: %s", resp.StatusCode, method, path, string(respBody))
+	}
+	if out != nil {
+		return json.Unmarshal(respBody, out)
+	}
+	return nil
+}
+
+// syncSilences creates the desired silences that do not exist yet in Alertmanager, and expires the silences previously created by the operator that are not desired anymore
+func (c *alertManagerClient) syncSilences(ctx context.Context, desired []alerts.Silence) error {
+	var current []gettableSilence
+	if err := c.do(ctx, http.MethodGet, "/api/v2/silences", nil, &current); err != nil {
+		return err
+	}
+	toCreate := slices.Clone(desired)
+	for i := range current {
+		s := &current[i]
+		if s.CreatedBy != alerts.SilenceCreator || s.Status.State == "expired" {
+			continue
+		}
+		if idx := slices.IndexFunc(toCreate, func(d alerts.Silence) bool { return sameSilence(&s.Silence, &d) }); idx >= 0 {
+			toCreate = slices.Delete(toCreate, idx, idx+1)
+			continue
+		}
+		log.FromContext(ctx).WithValues("id", s.ID, "comment", s.Comment).Info("Expiring Alertmanager silence")
+		if err := c.do(ctx, http.MethodDelete, "/api/v2/silence/"+s.ID, nil, nil); err != nil {
+			return err
+		}
+	}
+	for i := range toCreate {
+		log.FromContext(ctx).WithValues("comment", toCreate[i].Comment, "startsAt", toCreate[i].StartsAt).Info("Creating Alertmanager silence")
+		if err := c.do(ctx, http.MethodPost, "/api/v2/silences", &toCreate[i], nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameSilence compares silences regardless of their start, as Alertmanager moves the start of the silences created in the past to their creation time,
+// and regardless of the order of their matchers
+func sameSilence(a, b *alerts.Silence) bool {
+	if a.Comment != b.Comment || !a.EndsAt.Equal(b.EndsAt) || len(a.Matchers) != len(b.Matchers) {
+		return false
+	}
+	for _, m := range a.Matchers {
+		if !slices.Contains(b.Matchers, m) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics/alerts"
+)
+
+// fakeAlertManager implements the silences endpoints of the Alertmanager v2 API
+type fakeAlertManager struct {
+	mu       sync.Mutex
+	silences []gettableSilence
+	created  int
+	expired  []string
+}
+
+func (f *fakeAlertManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silences":
+		_ = json.NewEncoder(w).Encode(f.silences)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+		var s gettableSilence
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.created++
+		s.ID = "id-" + s.Comment
+		s.Status.State = "active"
+		f.silences = append(f.silences, s)
+		_, _ = w.Write([]byte(`{"silenceID":"` + s.ID + `"}`))
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v2/silence/"):
+		id := strings.TrimPrefix(r.URL.Path, "/api/v2/silence/")
+		for i := range f.silences {
+			if f.silences[i].ID == id {
+				f.silences[i].Status.State = "expired"
+			}
+		}
+		f.expired = append(f.expired, id)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestSyncSilences(t *testing.T) {
+	am := fakeAlertManager{
+		silences: []gettableSilence{
+			{Silence: alerts.Silence{CreatedBy: "someone", Comment: "manual silence"}, ID: "manual"},
+		},
+	}
+	server := httptest.NewServer(&am)
+	defer server.Close()
+
+	spec := flowslatest.FlowCollectorSpec{
+		Prometheus: flowslatest.FlowCollectorPrometheus{
+			Querier: flowslatest.PrometheusQuerier{
+				Mode:   flowslatest.PromModeManual,
+				Manual: flowslatest.PrometheusQuerierManual{AlertManager: &flowslatest.AlertManagerQuerierManual{URL: server.URL + "/"}},
+			},
+		},
+		Processor: flowslatest.FlowCollectorFLP{
+			Metrics: flowslatest.FLPMetrics{
+				MaintenanceWindows: []flowslatest.MaintenanceWindow{
+					{Name: "weekly", Schedule: "0 2 * * 6", Duration: &metav1.Duration{Duration: 2 * time.Hour}},
+				},
+			},
+		},
+	}
+	amc, err := newAlertManagerClient(context.Background(), nil, spec.Prometheus.Querier.Manual.AlertManager, "netobserv")
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 24, 3, 0, 0, 0, time.UTC)
+
+	// Create
+	err = amc.syncSilences(context.Background(), alerts.BuildMaintenanceSilences(&spec, now))
+	require.NoError(t, err)
+	assert.Equal(t, 1, am.created)
+	require.Len(t, am.silences, 2)
+	assert.Equal(t, "NetObserv maintenance window weekly", am.silences[1].Comment)
+
+	// Keep, even when Alertmanager changed the start
+	am.silences[1].StartsAt = now
+	err = amc.syncSilences(context.Background(), alerts.BuildMaintenanceSilences(&spec, now))
+	require.NoError(t, err)
+	assert.Equal(t, 1, am.created)
+	assert.Empty(t, am.expired)
+
+	// Expire when the window is removed, leaving other silences unchanged
+	spec.Processor.Metrics.MaintenanceWindows = nil
+	err = amc.syncSilences(context.Background(), alerts.BuildMaintenanceSilences(&spec, now))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id-NetObserv maintenance window weekly"}, am.expired)
+	assert.Equal(t, "", am.silences[0].Status.State)
+}
+
+func TestSyncSilencesWithoutWindows(t *testing.T) {
+	// Silences left by a previous operator run, such as when the windows were removed while it was not running
+	am := fakeAlertManager{
+		silences: []gettableSilence{
+			{Silence: alerts.Silence{CreatedBy: "someone", Comment: "manual silence"}, ID: "manual"},
+			{Silence: alerts.Silence{CreatedBy: alerts.SilenceCreator, Comment: "NetObserv maintenance window old"}, ID: "stale"},
+			{Silence: alerts.Silence{CreatedBy: alerts.SilenceCreator, Comment: "NetObserv maintenance window older"}, ID: "expired"},
+		},
+	}
+	am.silences[2].Status.State = "expired"
+	server := httptest.NewServer(&am)
+	defer server.Close()
+
+	amc, err := newAlertManagerClient(context.Background(), nil, &flowslatest.AlertManagerQuerierManual{URL: server.URL}, "netobserv")
+	require.NoError(t, err)
+	err = amc.syncSilences(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, am.created)
+	assert.Equal(t, []string{"stale"}, am.expired)
+}
+
+func TestSyncSilencesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	amc, err := newAlertManagerClient(context.Background(), nil, &flowslatest.AlertManagerQuerierManual{URL: server.URL}, "netobserv")
+	require.NoError(t, err)
+	err = amc.syncSilences(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status code 401")
+}
+
+func TestSameSilence(t *testing.T) {
+	a := alerts.Silence{
+		Comment: "NetObserv maintenance window upgrade",
+		EndsAt:  time.Date(2026, 11, 8, 2, 0, 0, 0, time.UTC),
+		Matchers: []alerts.SilenceMatcher{
+			{Name: "netobserv", Value: "true", IsEqual: true},
+			{Name: "namespace", Value: "app-a|app-b", IsRegex: true, IsEqual: true},
+		},
+	}
+	b := a
+	b.StartsAt = time.Date(2026, 11, 7, 22, 0, 0, 0, time.UTC)
+	b.Matchers = []alerts.SilenceMatcher{a.Matchers[1], a.Matchers[0]}
+	assert.True(t, sameSilence(&a, &b))
+
+	b.EndsAt = b.EndsAt.Add(time.Hour)
+	assert.False(t, sameSilence(&a, &b))
+
+	b.EndsAt = a.EndsAt
+	b.Matchers = a.Matchers[:1]
+	assert.False(t, sameSilence(&a, &b))
+}
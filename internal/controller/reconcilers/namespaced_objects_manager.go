@@ -54,6 +54,12 @@ func (m *NamespacedObjectManager) NewConfigMap(name string) *corev1.ConfigMap {
 	return &cm
 }
 
+func (m *NamespacedObjectManager) NewSecret(name string) *corev1.Secret {
+	secret := corev1.Secret{}
+	m.AddManagedObject(name, &secret)
+	return &secret
+}
+
 func (m *NamespacedObjectManager) NewPersistentVolumeClaim(name string) *corev1.PersistentVolumeClaim {
 	pvc := corev1.PersistentVolumeClaim{}
 	m.AddManagedObject(name, &pvc)
@@ -7,6 +7,7 @@ import (
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	ascv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -34,6 +35,22 @@ var (
 		DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
 		GenericFunc: func(_ event.GenericEvent) bool { return false },
 	})
+	// NodeKernelChangePred triggers a reconcile when a node is added or removed, or when its kernel, OS image or labels change
+	NodeKernelChangePred = builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			if !okOld || !okNew {
+				return false
+			}
+			return oldNode.Status.NodeInfo.KernelVersion != newNode.Status.NodeInfo.KernelVersion ||
+				oldNode.Status.NodeInfo.OSImage != newNode.Status.NodeInfo.OSImage ||
+				!equality.Semantic.DeepEqual(oldNode.GetLabels(), newNode.GetLabels())
+		},
+		CreateFunc:  func(_ event.CreateEvent) bool { return true },
+		DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
+		GenericFunc: func(_ event.GenericEvent) bool { return false },
+	})
 	UpdateOrDeleteOnlyPred = builder.WithPredicates(predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			// Update only if new object is owned - we want to watch for status changes as well (e.g. to know when a deployment is ready)
@@ -122,6 +139,24 @@ func ReconcileConfigMap(ctx context.Context, cl *helper.Client, current, desired
 	return cl.UpdateIfOwned(ctx, current, desired)
 }
 
+func ReconcilePrometheusRule(ctx context.Context, cl *helper.Client, current, desired *monitoringv1.PrometheusRule) error {
+	if current == nil {
+		if desired == nil {
+			return nil
+		}
+		return cl.CreateOwned(ctx, desired)
+	}
+	if desired == nil {
+		return cl.DeleteIfOwned(ctx, current)
+	}
+	report := helper.NewChangeReport("PrometheusRule")
+	if !helper.PrometheusRuleChanged(current, desired, &report) {
+		// rule already reconciled. Exiting
+		return nil
+	}
+	return cl.UpdateIfOwned(ctx, current, desired)
+}
+
 func ReconcileDaemonSet(ctx context.Context, ci *Instance, old, n *appsv1.DaemonSet, containerName string, report *helper.ChangeReport) error {
 	if !ci.Managed.Exists(old) {
 		ci.Status.SetCreatingDaemonSet(n)
@@ -172,6 +207,9 @@ func ReconcileService(ctx context.Context, ci *Instance, old, n *corev1.Service,
 		// In case we're updating an existing service, we need to build from the old one to keep immutable fields such as clusterIP
 		newSVC := old.DeepCopy()
 		newSVC.Spec.Ports = n.Spec.Ports
+		if n.Spec.InternalTrafficPolicy != nil {
+			newSVC.Spec.InternalTrafficPolicy = n.Spec.InternalTrafficPolicy
+		}
 		newSVC.ObjectMeta.Annotations = n.ObjectMeta.Annotations
 		if err := ci.UpdateIfOwned(ctx, old, newSVC); err != nil {
 			return err
@@ -0,0 +1,159 @@
+package certs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+)
+
+const (
+	// csiCertDuration is the validity of the certificates issued by the cert-manager CSI driver to each pod. Since the eBPF agents
+	// load their certificate at startup, they are restarted every CSIRotationPeriod to get a fresh one before it expires.
+	csiCertDuration   = 90 * 24 * time.Hour
+	CSIRotationPeriod = 30 * 24 * time.Hour
+)
+
+var (
+	IssuerGVK      = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"}
+	CertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+)
+
+// ServingCertificate describes a serving certificate for a Service, issued by cert-manager.
+type ServingCertificate struct {
+	// SecretName is the name of both the cert-manager Certificate and the Secret that it produces
+	SecretName  string
+	ServiceName string
+	// ServiceNamespace is the namespace of the Service, when it differs from the namespace of the certificate
+	ServiceNamespace string
+}
+
+// ClientCertificate describes a client certificate issued by cert-manager.
+type ClientCertificate struct {
+	// SecretName is the name of both the cert-manager Certificate and the Secret that it produces
+	SecretName string
+	CommonName string
+}
+
+// ReconcileCertManager makes sure that cert-manager issues the wanted serving and client certificates in the namespace, signed by a
+// self-signed CA dedicated to that namespace. Each resulting Secret contains tls.crt, tls.key and ca.crt.
+// Certificates listed in unused are deleted, as well as the issuers when no certificate is wanted.
+func ReconcileCertManager(ctx context.Context, cl *helper.Client, namespace string, wanted []ServingCertificate, clients []ClientCertificate, unused []string) error {
+	for _, name := range unused {
+		if err := cl.DeleteUnstructuredIfOwned(ctx, CertificateGVK, name, namespace); err != nil {
+			return err
+		}
+	}
+	issuers := []*unstructured.Unstructured{
+		newIssuer(constants.CertManagerSelfSignedIssuerName, namespace, map[string]interface{}{"selfSigned": map[string]interface{}{}}),
+		newCertificate(constants.CertManagerCAName, namespace, map[string]interface{}{
+			"isCA":       true,
+			"commonName": constants.CertManagerCAName,
+			"secretName": constants.CertManagerCAName,
+			"privateKey": privateKeySpec(),
+			"issuerRef":  issuerRef(constants.CertManagerSelfSignedIssuerName),
+		}),
+		newIssuer(constants.CertManagerCAName, namespace, map[string]interface{}{"ca": map[string]interface{}{"secretName": constants.CertManagerCAName}}),
+	}
+	if len(wanted) == 0 && len(clients) == 0 {
+		for _, obj := range issuers {
+			if err := cl.DeleteUnstructuredIfOwned(ctx, obj.GroupVersionKind(), obj.GetName(), namespace); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, obj := range issuers {
+		if err := cl.ReconcileUnstructured(ctx, obj); err != nil {
+			return err
+		}
+	}
+	for i := range wanted {
+		if err := cl.ReconcileUnstructured(ctx, newServingCertificate(&wanted[i], namespace)); err != nil {
+			return err
+		}
+	}
+	for i := range clients {
+		if err := cl.ReconcileUnstructured(ctx, newClientCertificate(&clients[i], namespace)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CSIVolumeAttributes returns the attributes of a cert-manager CSI driver volume that provides each pod with its own client certificate,
+// signed by the CA of the namespace managed in ReconcileCertManager. The certificate common name is made of the pod name and namespace.
+// The volume contains tls.crt, tls.key and ca.crt.
+func CSIVolumeAttributes() map[string]string {
+	return map[string]string{
+		"csi.cert-manager.io/issuer-name":  constants.CertManagerCAName,
+		"csi.cert-manager.io/issuer-kind":  IssuerGVK.Kind,
+		"csi.cert-manager.io/issuer-group": IssuerGVK.Group,
+		"csi.cert-manager.io/common-name":  "${POD_NAME}.${POD_NAMESPACE}",
+		"csi.cert-manager.io/key-usages":   "client auth,digital signature,key encipherment",
+		"csi.cert-manager.io/duration":     csiCertDuration.String(),
+	}
+}
+
+// CSIRotationEpoch returns a value that changes every CSIRotationPeriod, used to restart pods that don't reload their certificate.
+func CSIRotationEpoch(now time.Time) string {
+	return strconv.FormatInt(now.Unix()/int64(CSIRotationPeriod.Seconds()), 10)
+}
+
+func newServingCertificate(sc *ServingCertificate, namespace string) *unstructured.Unstructured {
+	svcNamespace := namespace
+	if sc.ServiceNamespace != "" {
+		svcNamespace = sc.ServiceNamespace
+	}
+	return newCertificate(sc.SecretName, namespace, map[string]interface{}{
+		"secretName": sc.SecretName,
+		"commonName": fmt.Sprintf("%s.%s.svc", sc.ServiceName, svcNamespace),
+		"dnsNames": []interface{}{
+			fmt.Sprintf("%s.%s.svc", sc.ServiceName, svcNamespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", sc.ServiceName, svcNamespace),
+		},
+		"usages":     []interface{}{"server auth", "digital signature", "key encipherment"},
+		"privateKey": privateKeySpec(),
+		"issuerRef":  issuerRef(constants.CertManagerCAName),
+	})
+}
+
+func newClientCertificate(cc *ClientCertificate, namespace string) *unstructured.Unstructured {
+	return newCertificate(cc.SecretName, namespace, map[string]interface{}{
+		"secretName": cc.SecretName,
+		"commonName": cc.CommonName,
+		"usages":     []interface{}{"client auth", "digital signature", "key encipherment"},
+		"privateKey": privateKeySpec(),
+		"issuerRef":  issuerRef(constants.CertManagerCAName),
+	})
+}
+
+func newIssuer(name, namespace string, spec map[string]interface{}) *unstructured.Unstructured {
+	return helper.NewUnstructured(IssuerGVK, name, namespace, spec)
+}
+
+func newCertificate(name, namespace string, spec map[string]interface{}) *unstructured.Unstructured {
+	return helper.NewUnstructured(CertificateGVK, name, namespace, spec)
+}
+
+func privateKeySpec() map[string]interface{} {
+	return map[string]interface{}{
+		"algorithm":      "ECDSA",
+		"size":           int64(256),
+		"rotationPolicy": "Always",
+	}
+}
+
+func issuerRef(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":  name,
+		"kind":  IssuerGVK.Kind,
+		"group": IssuerGVK.Group,
+	}
+}
@@ -0,0 +1,46 @@
+package certs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewServingCertificate(t *testing.T) {
+	cert := newServingCertificate(&ServingCertificate{SecretName: "flowlogs-pipeline-cert", ServiceName: "flowlogs-pipeline"}, "netobserv")
+
+	assert.Equal(t, CertificateGVK, cert.GroupVersionKind())
+	assert.Equal(t, "flowlogs-pipeline-cert", cert.GetName())
+	assert.Equal(t, "netobserv", cert.GetNamespace())
+	secretName, _, _ := unstructured.NestedString(cert.Object, "spec", "secretName")
+	assert.Equal(t, "flowlogs-pipeline-cert", secretName)
+	dnsNames, _, _ := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames")
+	assert.Equal(t, []string{"flowlogs-pipeline.netobserv.svc", "flowlogs-pipeline.netobserv.svc.cluster.local"}, dnsNames)
+	issuer, _, _ := unstructured.NestedStringMap(cert.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"name": "netobserv-serving-ca", "kind": "Issuer", "group": "cert-manager.io"}, issuer)
+}
+
+func TestNewClientAndCrossNamespaceCertificates(t *testing.T) {
+	cert := newServingCertificate(&ServingCertificate{SecretName: "flowlogs-pipeline-agents-cert", ServiceName: "flowlogs-pipeline", ServiceNamespace: "netobserv"}, "netobserv-privileged")
+	assert.Equal(t, "netobserv-privileged", cert.GetNamespace())
+	dnsNames, _, _ := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames")
+	assert.Equal(t, []string{"flowlogs-pipeline.netobserv.svc", "flowlogs-pipeline.netobserv.svc.cluster.local"}, dnsNames)
+
+	client := newClientCertificate(&ClientCertificate{SecretName: "netobserv-ebpf-agent-cert", CommonName: "netobserv-ebpf-agent"}, "netobserv-privileged")
+	assert.Equal(t, "netobserv-ebpf-agent-cert", client.GetName())
+	commonName, _, _ := unstructured.NestedString(client.Object, "spec", "commonName")
+	assert.Equal(t, "netobserv-ebpf-agent", commonName)
+	usages, _, _ := unstructured.NestedStringSlice(client.Object, "spec", "usages")
+	assert.Contains(t, usages, "client auth")
+	_, found, _ := unstructured.NestedStringSlice(client.Object, "spec", "dnsNames")
+	assert.False(t, found)
+}
+
+func TestCSIRotationEpoch(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, CSIRotationEpoch(now), CSIRotationEpoch(now.Add(time.Second)))
+	assert.NotEqual(t, CSIRotationEpoch(now), CSIRotationEpoch(now.Add(CSIRotationPeriod)))
+	assert.Less(t, CSIRotationPeriod, csiCertDuration)
+}
@@ -0,0 +1,195 @@
+package certs
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+	"time"
+)
+
+const (
+	caValidity   = 3 * 365 * 24 * time.Hour
+	certValidity = 365 * 24 * time.Hour
+)
+
+// Request describes a certificate to issue.
+type Request struct {
+	CommonName string
+	DNSNames   []string
+	IPs        []string
+	Client     bool
+}
+
+// GenerateCA creates a self-signed Certificate Authority, returned as PEM-encoded certificate and key.
+func GenerateCA(commonName string, now time.Time) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encode(der, key)
+}
+
+// Issue creates a certificate signed by the provided CA, returned as PEM-encoded certificate and key.
+func Issue(caCertPEM, caKeyPEM []byte, req *Request, now time.Time) (certPEM, keyPEM []byte, err error) {
+	caCert, caKey, err := parseCA(caCertPEM, caKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+	usage := x509.ExtKeyUsageServerAuth
+	if req.Client {
+		usage = x509.ExtKeyUsageClientAuth
+	}
+	tmpl := x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: req.CommonName},
+		DNSNames:     req.DNSNames,
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     minTime(now.Add(certValidity), caCert.NotAfter),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	for _, ip := range req.IPs {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, parsed)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encode(der, key)
+}
+
+// NeedsRenewal returns true when the certificate must be (re-)issued: when it cannot be parsed, when it isn't signed by the CA,
+// when it doesn't match the request, or when less than a third of its lifetime remains.
+func NeedsRenewal(certPEM, caCertPEM []byte, req *Request, now time.Time) bool {
+	cert, err := parseCert(certPEM)
+	if err != nil {
+		return true
+	}
+	if caCertPEM != nil {
+		caCert, err := parseCert(caCertPEM)
+		if err != nil || cert.CheckSignatureFrom(caCert) != nil {
+			return true
+		}
+	}
+	if req != nil {
+		var ips []string
+		for _, ip := range cert.IPAddresses {
+			ips = append(ips, ip.String())
+		}
+		if cert.Subject.CommonName != req.CommonName || !sameElements(cert.DNSNames, req.DNSNames) || !sameElements(ips, normalizeIPs(req.IPs)) {
+			return true
+		}
+	}
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotAfter.Sub(now) < lifetime/3
+}
+
+// isExpired returns true when the certificate cannot be parsed, or is no longer valid.
+func isExpired(certPEM []byte, now time.Time) bool {
+	cert, err := parseCert(certPEM)
+	return err != nil || now.After(cert.NotAfter)
+}
+
+func parseCert(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parseCA(caCertPEM, caKeyPEM []byte) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	caCert, err := parseCert(caCertPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	block, _ := pem.Decode(caKeyPEM)
+	if block == nil {
+		return nil, nil, errors.New("invalid CA key: no PEM data found")
+	}
+	caKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CA key: %w", err)
+	}
+	return caCert, caKey, nil
+}
+
+func encode(der []byte, key *ecdsa.PrivateKey) (certPEM, keyPEM []byte, err error) {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+func newSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func normalizeIPs(ips []string) []string {
+	var result []string
+	for _, ip := range ips {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			result = append(result, parsed.String())
+		}
+	}
+	return result
+}
+
+func sameElements(a, b []string) bool {
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// samePEM returns true when both PEM-encoded contents are identical.
+func samePEM(a, b []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(a), bytes.TrimSpace(b))
+}
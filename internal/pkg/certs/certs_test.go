@@ -0,0 +1,133 @@
+package certs
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueAndVerify(t *testing.T) {
+	now := time.Now()
+	caCert, caKey, err := GenerateCA("test-ca", now)
+	require.NoError(t, err)
+
+	req := Request{
+		CommonName: "flowlogs-pipeline",
+		DNSNames:   []string{"flowlogs-pipeline.netobserv.svc"},
+		IPs:        []string{"10.0.0.2", "10.0.0.1"},
+	}
+	certPEM, keyPEM, err := Issue(caCert, caKey, &req, now)
+	require.NoError(t, err)
+	assert.NotEmpty(t, keyPEM)
+
+	cert, err := parseCert(certPEM)
+	require.NoError(t, err)
+	ca, err := parseCert(caCert)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:       pool,
+		DNSName:     "10.0.0.1",
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		CurrentTime: now,
+	})
+	require.NoError(t, err)
+
+	clientPEM, _, err := Issue(caCert, caKey, &Request{CommonName: "agent", Client: true}, now)
+	require.NoError(t, err)
+	client, err := parseCert(clientPEM)
+	require.NoError(t, err)
+	_, err = client.Verify(x509.VerifyOptions{Roots: pool, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, CurrentTime: now})
+	require.NoError(t, err)
+	_, err = client.Verify(x509.VerifyOptions{Roots: pool, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, CurrentTime: now})
+	require.Error(t, err)
+}
+
+func TestNeedsRenewal(t *testing.T) {
+	now := time.Now()
+	caCert, caKey, err := GenerateCA("test-ca", now)
+	require.NoError(t, err)
+	otherCACert, _, err := GenerateCA("other-ca", now)
+	require.NoError(t, err)
+	req := Request{CommonName: "flp", DNSNames: []string{"flp.ns.svc"}, IPs: []string{"10.0.0.1"}}
+	certPEM, _, err := Issue(caCert, caKey, &req, now)
+	require.NoError(t, err)
+
+	assert.False(t, NeedsRenewal(certPEM, caCert, &req, now))
+	assert.False(t, NeedsRenewal(caCert, nil, nil, now))
+
+	// Different SANs
+	assert.True(t, NeedsRenewal(certPEM, caCert, &Request{CommonName: "flp", DNSNames: []string{"flp.ns.svc"}, IPs: []string{"10.0.0.1", "10.0.0.2"}}, now))
+	// Signed by another CA
+	assert.True(t, NeedsRenewal(certPEM, otherCACert, &req, now))
+	// Close to expiry
+	assert.True(t, NeedsRenewal(certPEM, caCert, &req, now.Add(300*24*time.Hour)))
+	// Invalid
+	assert.True(t, NeedsRenewal([]byte("invalid"), caCert, &req, now))
+}
+
+func TestRotateCA(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	bundle := func(data map[string][]byte) []*x509.Certificate {
+		var certs []*x509.Certificate
+		rest := data[CAFile]
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				return certs
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			require.NoError(t, err)
+			certs = append(certs, cert)
+		}
+	}
+
+	// Creation
+	initial, err := rotateCA(ctx, "ca", nil, now)
+	require.NoError(t, err)
+	assert.Len(t, bundle(initial), 1)
+	assert.Equal(t, initial[CertFile], initial[CAFile])
+
+	// Nothing to do
+	data, err := rotateCA(ctx, "ca", initial, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, initial, data)
+
+	// Renewal: the next CA is trusted, but doesn't sign yet
+	renewal := now.Add(2 * 365 * 24 * time.Hour)
+	withNext, err := rotateCA(ctx, "ca", initial, renewal)
+	require.NoError(t, err)
+	assert.Equal(t, initial[CertFile], withNext[CertFile])
+	assert.Equal(t, initial[KeyFile], withNext[KeyFile])
+	assert.NotEmpty(t, withNext[NextKeyFile])
+	assert.Len(t, bundle(withNext), 2)
+
+	// Still in the overlap period
+	data, err = rotateCA(ctx, "ca", withNext, renewal.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, withNext, data)
+
+	// The next CA signs, and the previous one remains trusted
+	switched, err := rotateCA(ctx, "ca", withNext, renewal.Add(caOverlap))
+	require.NoError(t, err)
+	assert.Equal(t, withNext[NextCertFile], switched[CertFile])
+	assert.Equal(t, withNext[NextKeyFile], switched[KeyFile])
+	assert.Equal(t, initial[CertFile], switched[PreviousCertFile])
+	assert.Empty(t, switched[NextKeyFile])
+	assert.Len(t, bundle(switched), 2)
+
+	// The previous CA expired
+	cleaned, err := rotateCA(ctx, "ca", switched, now.Add(caValidity+time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, switched[CertFile], cleaned[CertFile])
+	assert.Empty(t, cleaned[PreviousCertFile])
+	assert.Len(t, bundle(cleaned), 1)
+}
@@ -0,0 +1,169 @@
+package certs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+)
+
+const (
+	CAFile   = "ca.crt"
+	CertFile = "tls.crt"
+	KeyFile  = "tls.key"
+	// During a CA rotation, the next CA is stored aside until it is trusted by every peer,
+	// and the previous CA remains trusted until it expires
+	NextCertFile     = "next.crt"
+	NextKeyFile      = "next.key"
+	PreviousCertFile = "previous.crt"
+
+	// caOverlap is the time given to all peers to trust the next CA, before it starts signing certificates
+	caOverlap = 24 * time.Hour
+)
+
+// CA is a Certificate Authority managed by the operator. Bundle holds the certificates that peers must trust:
+// the signing CA and, during a rotation, the next or the previous one.
+type CA struct {
+	CertPEM []byte
+	KeyPEM  []byte
+	Bundle  []byte
+}
+
+// ReconcileCA returns the Certificate Authority stored in the given secret, creating or rotating it when needed.
+// A CA must be reconciled by a single controller; others use ReadCA.
+func ReconcileCA(ctx context.Context, cl *helper.Client, name, namespace string) (*CA, error) {
+	current := corev1.Secret{}
+	err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &current)
+	if err != nil && !kerr.IsNotFound(err) {
+		return nil, err
+	}
+	exists := err == nil
+	data, err := rotateCA(ctx, name, current.Data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	ca := caFromData(data)
+	if exists && reflect.DeepEqual(current.Data, data) {
+		return ca, nil
+	}
+	desired := newSecret(name, namespace, data)
+	if exists {
+		return ca, cl.UpdateOwned(ctx, &current, desired)
+	}
+	if err := cl.CreateOwned(ctx, desired); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// rotateCA returns the desired content of a CA secret. When the signing CA needs renewal, a next CA is generated and added
+// to the trust bundle; it replaces the signing CA once peers had time to trust it. The replaced CA stays in the bundle until
+// it expires, so that certificates that it signed remain valid while they are re-issued.
+func rotateCA(ctx context.Context, name string, current map[string][]byte, now time.Time) (map[string][]byte, error) {
+	rlog := log.FromContext(ctx)
+	data := map[string][]byte{}
+	if len(current[KeyFile]) == 0 || isExpired(current[CertFile], now) {
+		rlog.Info("generating Certificate Authority", "name", name)
+		certPEM, keyPEM, err := GenerateCA(name, now)
+		if err != nil {
+			return nil, err
+		}
+		data[CertFile], data[KeyFile] = certPEM, keyPEM
+	} else {
+		data[CertFile], data[KeyFile] = current[CertFile], current[KeyFile]
+		if len(current[PreviousCertFile]) > 0 {
+			data[PreviousCertFile] = current[PreviousCertFile]
+		}
+		if len(current[NextKeyFile]) > 0 && !isExpired(current[NextCertFile], now) {
+			if next, err := parseCert(current[NextCertFile]); err == nil && now.Sub(next.NotBefore) >= caOverlap {
+				rlog.Info("switching to the next Certificate Authority", "name", name)
+				data[PreviousCertFile] = current[CertFile]
+				data[CertFile], data[KeyFile] = current[NextCertFile], current[NextKeyFile]
+			} else {
+				data[NextCertFile], data[NextKeyFile] = current[NextCertFile], current[NextKeyFile]
+			}
+		} else if NeedsRenewal(current[CertFile], nil, nil, now) {
+			rlog.Info("generating next Certificate Authority", "name", name)
+			certPEM, keyPEM, err := GenerateCA(name, now)
+			if err != nil {
+				return nil, err
+			}
+			data[NextCertFile], data[NextKeyFile] = certPEM, keyPEM
+		}
+		if isExpired(data[PreviousCertFile], now) {
+			delete(data, PreviousCertFile)
+		}
+	}
+	var bundle []byte
+	for _, key := range []string{CertFile, NextCertFile, PreviousCertFile} {
+		bundle = append(bundle, data[key]...)
+	}
+	data[CAFile] = bundle
+	return data, nil
+}
+
+// ReadCA returns a Certificate Authority reconciled by another controller.
+// It returns an error when the CA doesn't exist yet, so that the caller retries later.
+func ReadCA(ctx context.Context, cl *helper.Client, name, namespace string) (*CA, error) {
+	current := corev1.Secret{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &current); err != nil {
+		if kerr.IsNotFound(err) {
+			return nil, fmt.Errorf("certificate authority %s/%s is not created yet", namespace, name)
+		}
+		return nil, err
+	}
+	if len(current.Data[KeyFile]) == 0 || len(current.Data[CAFile]) == 0 || isExpired(current.Data[CertFile], time.Now()) {
+		return nil, fmt.Errorf("certificate authority %s/%s is not renewed yet", namespace, name)
+	}
+	return caFromData(current.Data), nil
+}
+
+// ReconcileCertificate makes sure that the given secret holds a valid certificate issued by the CA for the request,
+// along with the CA trust bundle, issuing a new one when needed.
+func ReconcileCertificate(ctx context.Context, cl *helper.Client, name, namespace string, ca *CA, req *Request) error {
+	current := corev1.Secret{}
+	err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &current)
+	if err != nil && !kerr.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	now := time.Now()
+	certPEM, keyPEM := current.Data[CertFile], current.Data[KeyFile]
+	if !exists || NeedsRenewal(certPEM, ca.CertPEM, req, now) {
+		log.FromContext(ctx).Info("issuing certificate", "name", name, "namespace", namespace)
+		certPEM, keyPEM, err = Issue(ca.CertPEM, ca.KeyPEM, req, now)
+		if err != nil {
+			return err
+		}
+	} else if samePEM(current.Data[CAFile], ca.Bundle) {
+		return nil
+	}
+	desired := newSecret(name, namespace, map[string][]byte{CAFile: ca.Bundle, CertFile: certPEM, KeyFile: keyPEM})
+	if exists {
+		return cl.UpdateOwned(ctx, &current, desired)
+	}
+	return cl.CreateOwned(ctx, desired)
+}
+
+func caFromData(data map[string][]byte) *CA {
+	return &CA{CertPEM: data[CertFile], KeyPEM: data[KeyFile], Bundle: data[CAFile]}
+}
+
+func newSecret(name, namespace string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: data,
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"strings"
 	"sync"
 
+	bpfmaniov1alpha1 "github.com/bpfman/bpfman-operator/apis/v1alpha1"
 	"github.com/coreos/go-semver/semver"
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	osv1 "github.com/openshift/api/console/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	securityv1 "github.com/openshift/api/security/v1"
@@ -36,7 +38,9 @@ type Info struct {
 	openShiftVersion            *semver.Version
 	cni                         flowslatest.NetworkType
 	nbNodes                     uint16
+	nodesKernelInfo             []flowslatest.NodeKernelInfo
 	hasPromServiceDiscoveryRole bool
+	hasCertManagerCSIDriver     bool
 	ready                       bool
 	readinessLock               sync.RWMutex
 	dcl                         discoveryClient
@@ -52,6 +56,11 @@ var (
 	ocpSecurity    = "securitycontextconstraints." + securityv1.SchemeGroupVersion.String()
 	endpointSlices = "endpointslices." + discoveryv1.SchemeGroupVersion.String()
 	lokistacks     = "lokistacks." + lokiv1.GroupVersion.String()
+	bpfApps        = "clusterbpfapplications." + bpfmaniov1alpha1.GroupVersion.String()
+	certManager    = "certificates.cert-manager.io/v1"
+	strimzi        = "kafkatopics.kafka.strimzi.io/v1beta2"
+	grafanaOp      = "grafanadashboards.grafana.integreatly.org/v1beta1"
+	perses         = "persesdashboards.perses.dev/v1alpha1"
 )
 
 func NewInfo(ctx context.Context, cfg *rest.Config, dcl *discovery.DiscoveryClient, onRefresh func()) (*Info, func(ctx context.Context) error, error) {
@@ -108,6 +117,11 @@ func (c *Info) fetchAvailableAPIsInternal(ctx context.Context, allowCriticalFail
 			ocpSecurity:    false,
 			endpointSlices: false,
 			lokistacks:     false,
+			bpfApps:        false,
+			certManager:    false,
+			strimzi:        false,
+			grafanaOp:      false,
+			perses:         false,
 		}
 		firstRun = true
 	}
@@ -181,7 +195,7 @@ func (c *Info) fetchClusterInfo(ctx context.Context) error {
 	var openShiftVersion *semver.Version
 	var cni flowslatest.NetworkType
 	var nbNodes uint16
-	var hasPromServiceDiscoveryRole bool
+	var hasPromServiceDiscoveryRole, hasCertManagerCSIDriver bool
 	if c.IsOpenShift() {
 		// Fetch cluster ID, version and CNI
 		cversion, err := c.livecl.getClusterVersion(ctx)
@@ -211,12 +225,20 @@ func (c *Info) fetchClusterInfo(ctx context.Context) error {
 		}
 		hasPromServiceDiscoveryRole = hasCRDProperty(ctx, crd, "v1", "spec.serviceDiscoveryRole")
 	}
+	if c.HasCertManager() {
+		var err error
+		hasCertManagerCSIDriver, err = c.livecl.hasCSIDriver(ctx, constants.CertManagerCSIDriverName)
+		if err != nil {
+			return fmt.Errorf("could not check for cert-manager CSI driver presence: %w", err)
+		}
+	}
 
 	l, err := c.livecl.getNodes(ctx)
 	if err != nil {
 		return fmt.Errorf("could not retrieve number of nodes: %w", err)
 	}
 	nbNodes = uint16(len(l.Items))
+	nodesKernelInfo := getNodesKernelInfo(l.Items)
 	if cni == "" {
 		cni = guessCNIFromNodes(l.Items)
 		if cni == "" {
@@ -227,13 +249,14 @@ func (c *Info) fetchClusterInfo(ctx context.Context) error {
 			cni = guessCNIFromSystemDS(ds.Items)
 		}
 	}
-	c.setInfo(id, openShiftVersion, cni, nbNodes, hasPromServiceDiscoveryRole)
+	c.setInfo(id, openShiftVersion, cni, nbNodes, nodesKernelInfo, hasPromServiceDiscoveryRole, hasCertManagerCSIDriver)
 	log.FromContext(ctx).Info("Cluster info fetched",
 		"id", id,
 		"openShiftVersion", openShiftVersion,
 		"cni", cni,
 		"nbNodes", nbNodes,
 		"hasPromServiceDiscoveryRole", hasPromServiceDiscoveryRole,
+		"hasCertManagerCSIDriver", hasCertManagerCSIDriver,
 	)
 
 	return nil
@@ -250,6 +273,19 @@ func guessCNIFromNodes(nodes []v1.Node) flowslatest.NetworkType {
 	return ""
 }
 
+func getNodesKernelInfo(nodes []v1.Node) []flowslatest.NodeKernelInfo {
+	infos := make([]flowslatest.NodeKernelInfo, 0, len(nodes))
+	for i := range nodes {
+		infos = append(infos, flowslatest.NodeKernelInfo{
+			Name:          nodes[i].Name,
+			KernelVersion: nodes[i].Status.NodeInfo.KernelVersion,
+			OSImage:       nodes[i].Status.NodeInfo.OSImage,
+			Labels:        nodes[i].Labels,
+		})
+	}
+	return infos
+}
+
 func guessCNIFromSystemDS(ds []appsv1.DaemonSet) flowslatest.NetworkType {
 	for i := range ds {
 		if ds[i].Name == "kindnet" {
@@ -259,14 +295,16 @@ func guessCNIFromSystemDS(ds []appsv1.DaemonSet) flowslatest.NetworkType {
 	return ""
 }
 
-func (c *Info) setInfo(id string, openShiftVersion *semver.Version, cni flowslatest.NetworkType, nbNodes uint16, hasPromServiceDiscoveryRole bool) {
+func (c *Info) setInfo(id string, openShiftVersion *semver.Version, cni flowslatest.NetworkType, nbNodes uint16, nodesKernelInfo []flowslatest.NodeKernelInfo, hasPromServiceDiscoveryRole, hasCertManagerCSIDriver bool) {
 	c.readinessLock.Lock()
 	defer c.readinessLock.Unlock()
 	c.id = id
 	c.openShiftVersion = openShiftVersion
 	c.cni = cni
 	c.nbNodes = nbNodes
+	c.nodesKernelInfo = nodesKernelInfo
 	c.hasPromServiceDiscoveryRole = hasPromServiceDiscoveryRole
+	c.hasCertManagerCSIDriver = hasCertManagerCSIDriver
 	c.ready = true
 }
 
@@ -323,6 +361,78 @@ func (c *Info) GetNbNodes() (uint16, error) {
 	return c.nbNodes, nil
 }
 
+// GetNodesKernelInfo returns the kernel version and OS image of every node, as collected during the last refresh or nodes update
+func (c *Info) GetNodesKernelInfo() ([]flowslatest.NodeKernelInfo, error) {
+	c.readinessLock.RLock()
+	defer c.readinessLock.RUnlock()
+	if !c.ready {
+		return nil, errors.New("cluster info not collected")
+	}
+	return c.nodesKernelInfo, nil
+}
+
+// MockCertManager shouldn't be used except for testing
+func (c *Info) MockCertManager(available bool) {
+	c.apisMapLock.Lock()
+	defer c.apisMapLock.Unlock()
+	if c.apisMap == nil {
+		c.apisMap = make(map[string]bool)
+	}
+	c.apisMap[certManager] = available
+}
+
+// MockCertManagerCSIDriver shouldn't be used except for testing
+func (c *Info) MockCertManagerCSIDriver(available bool) {
+	c.readinessLock.Lock()
+	defer c.readinessLock.Unlock()
+	c.hasCertManagerCSIDriver = available
+}
+
+// MockStrimzi shouldn't be used except for testing
+func (c *Info) MockStrimzi(available bool) {
+	c.apisMapLock.Lock()
+	defer c.apisMapLock.Unlock()
+	if c.apisMap == nil {
+		c.apisMap = make(map[string]bool)
+	}
+	c.apisMap[strimzi] = available
+}
+
+// MockGrafanaOperator shouldn't be used except for testing
+func (c *Info) MockGrafanaOperator(available bool) {
+	c.apisMapLock.Lock()
+	defer c.apisMapLock.Unlock()
+	if c.apisMap == nil {
+		c.apisMap = make(map[string]bool)
+	}
+	c.apisMap[grafanaOp] = available
+}
+
+// MockPerses shouldn't be used except for testing
+func (c *Info) MockPerses(available bool) {
+	c.apisMapLock.Lock()
+	defer c.apisMapLock.Unlock()
+	if c.apisMap == nil {
+		c.apisMap = make(map[string]bool)
+	}
+	c.apisMap[perses] = available
+}
+
+// UpdateNodes refreshes the number of nodes and their kernel info, such as when a node is added or upgraded
+func (c *Info) UpdateNodes(nodes []v1.Node) {
+	c.readinessLock.Lock()
+	defer c.readinessLock.Unlock()
+	c.nbNodes = uint16(len(nodes))
+	c.nodesKernelInfo = getNodesKernelInfo(nodes)
+}
+
+// MockNodesKernelInfo shouldn't be used except for testing
+func (c *Info) MockNodesKernelInfo(nodes []flowslatest.NodeKernelInfo) {
+	c.readinessLock.Lock()
+	defer c.readinessLock.Unlock()
+	c.nodesKernelInfo = nodes
+}
+
 func (c *Info) HasPromServiceDiscoveryRole() bool {
 	return c.hasPromServiceDiscoveryRole
 }
@@ -432,6 +542,54 @@ func getCRDPropertyInVersion(v *apix.CustomResourceDefinitionVersion, parts []st
 	return nil
 }
 
+// HasBpfApplication returns true if "clusterbpfapplications.bpfman.io" API was found
+func (c *Info) HasBpfApplication() bool {
+	c.apisMapLock.RLock()
+	defer c.apisMapLock.RUnlock()
+	return c.apisMap[bpfApps]
+}
+
+// HasCertManager returns true if "certificates.cert-manager.io" API was found
+func (c *Info) HasCertManager() bool {
+	c.apisMapLock.RLock()
+	defer c.apisMapLock.RUnlock()
+	return c.apisMap[certManager]
+}
+
+// HasCertManagerCSIDriver returns true if the cert-manager CSI driver, which issues certificates dedicated to each pod, was found
+func (c *Info) HasCertManagerCSIDriver() bool {
+	c.readinessLock.RLock()
+	defer c.readinessLock.RUnlock()
+	return c.hasCertManagerCSIDriver
+}
+
+// HasStrimzi returns true if the Strimzi "kafkatopics" API was found
+func (c *Info) HasStrimzi() bool {
+	c.apisMapLock.RLock()
+	defer c.apisMapLock.RUnlock()
+	return c.apisMap[strimzi]
+}
+
+// HasGrafanaOperator returns true if the Grafana Operator "grafanadashboards" API was found
+func (c *Info) HasGrafanaOperator() bool {
+	c.apisMapLock.RLock()
+	defer c.apisMapLock.RUnlock()
+	return c.apisMap[grafanaOp]
+}
+
+// HasPerses returns true if the Perses "persesdashboards" API was found
+func (c *Info) HasPerses() bool {
+	c.apisMapLock.RLock()
+	defer c.apisMapLock.RUnlock()
+	return c.apisMap[perses]
+}
+
+// UseCertManager returns true when serving certificates in "Auto" mode must be issued by cert-manager,
+// i.e. when cert-manager is installed and the OpenShift service CA isn't available
+func (c *Info) UseCertManager() bool {
+	return !c.IsOpenShift() && c.HasCertManager()
+}
+
 // HasLokiStack returns true if "lokistack" API was found
 func (c *Info) HasLokiStack(ctx context.Context) bool {
 	if !c.apisMap[lokistacks] {
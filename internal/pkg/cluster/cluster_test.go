@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -541,6 +542,7 @@ type mockLiveClient struct {
 	network      *configv1.Network
 	cv           *configv1.ClusterVersion
 	crds         map[string]*apix.CustomResourceDefinition
+	csiDrivers   []string
 }
 
 func (m *mockLiveClient) getNodes(_ context.Context) (*v1.NodeList, error) {
@@ -572,6 +574,10 @@ func (m *mockLiveClient) getCRD(_ context.Context, name string) (*apix.CustomRes
 	return nil, fmt.Errorf("CRD %s not found", name)
 }
 
+func (m *mockLiveClient) hasCSIDriver(_ context.Context, name string) (bool, error) {
+	return slices.Contains(m.csiDrivers, name), nil
+}
+
 func stubOpenShiftInfo(version string) (*Info, *configv1.ClusterVersion) {
 	return &Info{
 			apisMap: map[string]bool{
@@ -652,3 +658,47 @@ func TestFetchClusterInfo_CNI_Kindnet(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, flowslatest.Kindnet, info.cni)
 }
+
+func TestUpdateNodes(t *testing.T) {
+	node := func(name, kernel string) v1.Node {
+		return v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     v1.NodeStatus{NodeInfo: v1.NodeSystemInfo{KernelVersion: kernel, OSImage: "RHEL"}},
+		}
+	}
+	info := Info{}
+	info.livecl = &mockLiveClient{nodes: []v1.Node{node("a", "4.18.0")}}
+	err := info.fetchClusterInfo(context.Background())
+	require.NoError(t, err)
+
+	// A node is upgraded and another one added after the cluster info was fetched
+	info.UpdateNodes([]v1.Node{node("a", "5.14.0"), node("b", "4.18.0")})
+	nodes, err := info.GetNodesKernelInfo()
+	require.NoError(t, err)
+	assert.Equal(t, []flowslatest.NodeKernelInfo{
+		{Name: "a", KernelVersion: "5.14.0", OSImage: "RHEL"},
+		{Name: "b", KernelVersion: "4.18.0", OSImage: "RHEL"},
+	}, nodes)
+	nb, err := info.GetNbNodes()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(2), nb)
+}
+
+func TestFetchClusterInfo_CertManagerCSIDriver(t *testing.T) {
+	info := Info{}
+	info.livecl = &mockLiveClient{csiDrivers: []string{"csi.cert-manager.io"}}
+	err := info.fetchClusterInfo(context.Background())
+	require.NoError(t, err)
+	// Not checked without cert-manager
+	assert.False(t, info.HasCertManagerCSIDriver())
+
+	info.MockCertManager(true)
+	err = info.fetchClusterInfo(context.Background())
+	require.NoError(t, err)
+	assert.True(t, info.HasCertManagerCSIDriver())
+
+	info.livecl = &mockLiveClient{}
+	err = info.fetchClusterInfo(context.Background())
+	require.NoError(t, err)
+	assert.False(t, info.HasCertManagerCSIDriver())
+}
@@ -9,6 +9,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apix "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
@@ -23,6 +24,7 @@ type liveClient interface {
 	getNetworkConfig(ctx context.Context) (*configv1.Network, error)
 	getClusterVersion(ctx context.Context) (*configv1.ClusterVersion, error)
 	getCRD(ctx context.Context, name string) (*apix.CustomResourceDefinition, error)
+	hasCSIDriver(ctx context.Context, name string) (bool, error)
 }
 
 type liveClientImpl struct {
@@ -85,3 +87,11 @@ func (lc *liveClientImpl) getCRD(ctx context.Context, name string) (*apix.Custom
 	}
 	return &obj, nil
 }
+
+func (lc *liveClientImpl) hasCSIDriver(ctx context.Context, name string) (bool, error) {
+	_, err := lc.kc.StorageV1().CSIDrivers().Get(ctx, name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -63,11 +63,12 @@ func rearrangeRows(rows []*Row, mapTopPanels, mapBodyPanels map[string][]Panel)
 	}
 }
 
-func createFlowMetricsDashboard(dashboardName string, charts []chart) string {
+func createFlowMetricsDashboard(dashboardName string, charts []chart) *Dashboard {
 	mapRows := make(map[string]*Row)
 	mapTopPanels := make(map[string][]Panel)
 	mapBodyPanels := make(map[string][]Panel)
 	var orderedRows []*Row
+	d := Dashboard{Title: "NetObserv / " + dashboardName}
 	chartsDedupMap := make(map[string]any)
 	for i := range charts {
 		chart := charts[i]
@@ -79,6 +80,10 @@ func createFlowMetricsDashboard(dashboardName string, charts []chart) string {
 		}
 		chartsDedupMap[dedupKey] = true
 
+		for _, v := range chart.Variables {
+			d.AddVariable(Variable{Name: v.Name, Label: v.Label, Metric: chart.seriesName()})
+		}
+
 		if chart.Type == metricslatest.ChartTypeSingleStat {
 			mapTopPanels[chart.SectionName] = append(mapTopPanels[chart.SectionName], createSingleStatPanels(&chart)...)
 		} else {
@@ -93,12 +98,34 @@ func createFlowMetricsDashboard(dashboardName string, charts []chart) string {
 	}
 
 	rearrangeRows(orderedRows, mapTopPanels, mapBodyPanels)
-	d := Dashboard{Rows: orderedRows, Title: "NetObserv / " + dashboardName}
-	return d.ToGrafanaJSON()
+	d.Rows = orderedRows
+	return &d
+}
+
+// seriesName returns the name of a Prometheus series of the chart metric, used to look up label values
+func (c *chart) seriesName() string {
+	return seriesName(c.mptr)
+}
+
+func seriesName(m *metricslatest.FlowMetric) string {
+	name := "netobserv_" + m.Spec.MetricName
+	if m.Spec.Type == metricslatest.HistogramMetric {
+		return name + "_count"
+	}
+	return name
 }
 
 func CreateFlowMetricsDashboards(metrics []metricslatest.FlowMetric) map[string]string {
 	dashboardsJSON := make(map[string]string)
+	for name, d := range BuildFlowMetricsDashboards(metrics) {
+		dashboardsJSON[name] = d.ToGrafanaJSON()
+	}
+	return dashboardsJSON
+}
+
+// BuildFlowMetricsDashboards returns the dashboards model, per dashboard name, built from the metrics charts
+func BuildFlowMetricsDashboards(metrics []metricslatest.FlowMetric) map[string]*Dashboard {
+	dashboards := make(map[string]*Dashboard)
 	chartsPerDashboard := make(map[string][]chart)
 	// Sort alphabetically to enforce consistent ordering
 	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
@@ -113,7 +140,7 @@ func CreateFlowMetricsDashboards(metrics []metricslatest.FlowMetric) map[string]
 		}
 	}
 	for name, charts := range chartsPerDashboard {
-		dashboardsJSON[name] = createFlowMetricsDashboard(name, charts)
+		dashboards[name] = createFlowMetricsDashboard(name, charts)
 	}
-	return dashboardsJSON
+	return dashboards
 }
@@ -1,6 +1,7 @@
 package dashboards
 
 import (
+	"encoding/json"
 	"testing"
 
 	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
@@ -21,40 +22,61 @@ func TestCreateFlowMetricsDashboard_All(t *testing.T) {
 
 	assert.Equal("NetObserv / Main", d.Title)
 
-	assert.Equal([]string{"", "Traffic rates per node", "Traffic rates per namespace", "Traffic rates per workload", "TCP latencies", "Byte and packet drops", "DNS", "Network Policy", "IPsec"}, d.Titles())
+	assert.Equal([]string{"Overview", "Traffic rates per node", "Traffic rates per namespace", "Traffic rates per workload", "TCP latencies", "Byte and packet drops", "DNS", "Network Policy", "IPsec", "TCP connections"}, d.Titles())
 
-	assert.Len(d.Rows[0].Panels, 8)
+	assert.Len(d.Rows[0].Panels, 9)
 	assert.Len(d.Rows[1].Panels, 2)
 
 	p := d.FindPanel("Top egress traffic per node")
 	assert.NotNil(p)
 	assert.Len(p.Targets, 1)
-	assert.Equal("topk(7, sum(rate(netobserv_node_egress_bytes_total{}[2m])) by (SrcK8S_HostName,DstK8S_HostName))", p.Targets[0].Expr)
+	assert.Equal(
+		`topk(7, (sum(rate(netobserv_node_egress_bytes_total{SrcK8S_HostName=~"$node"}[2m])) by (SrcK8S_HostName,DstK8S_HostName))`+
+			` or (sum(rate(netobserv_node_egress_bytes_total{DstK8S_HostName=~"$node"}[2m])) by (SrcK8S_HostName,DstK8S_HostName)))`,
+		p.Targets[0].Expr,
+	)
 
 	p = d.FindPanel("Top P50 DNS latency per node (ms)")
 	assert.NotNil(p)
 	assert.Len(p.Targets, 1)
-	assert.Equal("topk(7, histogram_quantile(0.5, sum(rate(netobserv_node_dns_latency_seconds_bucket{}[2m])) by (le,SrcK8S_HostName,DstK8S_HostName))*1000 > 0)", p.Targets[0].Expr)
+	assert.Equal(
+		`topk(7, (histogram_quantile(0.5, sum(rate(netobserv_node_dns_latency_seconds_bucket{SrcK8S_HostName=~"$node"}[2m])) by (le,SrcK8S_HostName,DstK8S_HostName))*1000 > 0)`+
+			` or (histogram_quantile(0.5, sum(rate(netobserv_node_dns_latency_seconds_bucket{DstK8S_HostName=~"$node"}[2m])) by (le,SrcK8S_HostName,DstK8S_HostName))*1000 > 0))`,
+		p.Targets[0].Expr,
+	)
 
 	p = d.FindPanel("Top P99 DNS latency per node (ms)")
 	assert.NotNil(p)
 	assert.Len(p.Targets, 1)
-	assert.Equal("topk(7, histogram_quantile(0.99, sum(rate(netobserv_node_dns_latency_seconds_bucket{}[2m])) by (le,SrcK8S_HostName,DstK8S_HostName))*1000 > 0)", p.Targets[0].Expr)
+	assert.Equal(
+		`topk(7, (histogram_quantile(0.99, sum(rate(netobserv_node_dns_latency_seconds_bucket{SrcK8S_HostName=~"$node"}[2m])) by (le,SrcK8S_HostName,DstK8S_HostName))*1000 > 0)`+
+			` or (histogram_quantile(0.99, sum(rate(netobserv_node_dns_latency_seconds_bucket{DstK8S_HostName=~"$node"}[2m])) by (le,SrcK8S_HostName,DstK8S_HostName))*1000 > 0))`,
+		p.Targets[0].Expr,
+	)
+
+	p = d.FindPanel("TCP resets rate per app namespace")
+	assert.NotNil(p)
+	assert.Len(p.Targets, 1)
+	assert.Equal(
+		`topk(7, (sum(rate(netobserv_namespace_tcp_flags_total{Flags=~"RST|RST_ACK",K8S_FlowLayer="app",SrcK8S_Namespace=~"$namespace"}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace))`+
+			` or (sum(rate(netobserv_namespace_tcp_flags_total{Flags=~"RST|RST_ACK",K8S_FlowLayer="app",DstK8S_Namespace=~"$namespace"}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace)))`,
+		p.Targets[0].Expr,
+	)
 
 	p = d.FindPanel("Top ingress traffic per app namespace")
 	assert.NotNil(p)
 	assert.Len(p.Targets, 1)
 	assert.Equal(
-		`topk(7, (sum(rate(netobserv_namespace_ingress_bytes_total{K8S_FlowLayer="app",SrcK8S_Namespace!=""}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace))`+
-			` or (sum(rate(netobserv_namespace_ingress_bytes_total{K8S_FlowLayer="app",DstK8S_Namespace!=""}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace)))`,
+		`topk(7, (sum(rate(netobserv_namespace_ingress_bytes_total{K8S_FlowLayer="app",SrcK8S_Namespace=~"$namespace"}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace))`+
+			` or (sum(rate(netobserv_namespace_ingress_bytes_total{K8S_FlowLayer="app",DstK8S_Namespace=~"$namespace"}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace)))`,
 		p.Targets[0].Expr,
 	)
 	p = d.FindPanel("Top ingress traffic per infra namespace")
 	assert.NotNil(p)
 	assert.Len(p.Targets, 1)
 	assert.Equal(
-		`topk(7, (sum(rate(netobserv_namespace_ingress_bytes_total{K8S_FlowLayer="infra",SrcK8S_Namespace!=""}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace))`+
-			` or (sum(rate(netobserv_namespace_ingress_bytes_total{K8S_FlowLayer="infra",DstK8S_Namespace!=""}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace)))`,
+		`topk(7, (sum(rate(netobserv_namespace_ingress_bytes_total{K8S_FlowLayer="infra",SrcK8S_Namespace=~"$namespace"}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace))`+
+			` or (sum(rate(netobserv_namespace_ingress_bytes_total{K8S_FlowLayer="infra",DstK8S_Namespace=~"$namespace"}[2m])) by (SrcK8S_Namespace,DstK8S_Namespace)))`,
 		p.Targets[0].Expr,
 	)
 
@@ -62,8 +84,8 @@ func TestCreateFlowMetricsDashboard_All(t *testing.T) {
 	assert.NotNil(p)
 	assert.Len(p.Targets, 1)
 	assert.Equal(
-		`topk(7, (histogram_quantile(0.5, sum(rate(netobserv_namespace_rtt_seconds_bucket{K8S_FlowLayer="infra",SrcK8S_Namespace!=""}[2m])) by (le,SrcK8S_Namespace,DstK8S_Namespace))*1000 > 0)`+
-			` or (histogram_quantile(0.5, sum(rate(netobserv_namespace_rtt_seconds_bucket{K8S_FlowLayer="infra",DstK8S_Namespace!=""}[2m])) by (le,SrcK8S_Namespace,DstK8S_Namespace))*1000 > 0))`,
+		`topk(7, (histogram_quantile(0.5, sum(rate(netobserv_namespace_rtt_seconds_bucket{K8S_FlowLayer="infra",SrcK8S_Namespace=~"$namespace"}[2m])) by (le,SrcK8S_Namespace,DstK8S_Namespace))*1000 > 0)`+
+			` or (histogram_quantile(0.5, sum(rate(netobserv_namespace_rtt_seconds_bucket{K8S_FlowLayer="infra",DstK8S_Namespace=~"$namespace"}[2m])) by (le,SrcK8S_Namespace,DstK8S_Namespace))*1000 > 0))`,
 		p.Targets[0].Expr,
 	)
 }
@@ -175,7 +197,7 @@ func TestCreateHealthDashboard_Default(t *testing.T) {
 	assert.NoError(err)
 
 	assert.Equal("NetObserv / Health", d.Title)
-	assert.Equal([]string{"", "Flowlogs-pipeline statistics", "eBPF agent statistics", "Operator statistics", "Resource usage"}, d.Titles())
+	assert.Equal([]string{"", "Network health score", "Flowlogs-pipeline statistics", "eBPF agent statistics", "Operator statistics", "Resource usage"}, d.Titles())
 
 	// First row
 	row := 0
@@ -183,6 +205,113 @@ func TestCreateHealthDashboard_Default(t *testing.T) {
 	assert.Equal("Flows per second", d.Rows[row].Panels[0].Title)
 	assert.Len(d.Rows[row].Panels[0].Targets, 1)
 	assert.Contains(d.Rows[row].Panels[0].Targets[0].Expr, "netobserv_ingest_flows_processed")
+
+	// Health scores
+	row = 1
+	assert.Len(d.Rows[row].Panels, 4)
+	assert.Equal("Lowest namespace scores", d.Rows[row].Panels[1].Title)
+	assert.Equal("bottomk(10, netobserv:health:score:namespace)", d.Rows[row].Panels[1].Targets[0].Expr)
+	assert.Equal("Active maintenance windows", d.Rows[row].Panels[3].Title)
+	assert.Equal("count(netobserv:maintenance:active) by (window)", d.Rows[row].Panels[3].Targets[0].Expr)
+}
+
+func TestWithDatasource(t *testing.T) {
+	assert := assert.New(t)
+
+	js, err := CreateHealthDashboard("netobserv", "netobserv_namespace_flows_total")
+	assert.NoError(err)
+	assert.Contains(js, `"datasource": "prometheus"`)
+
+	js, err = WithDatasource(js, "thanos")
+	assert.NoError(err)
+	assert.NotContains(js, `"datasource":"prometheus"`)
+	assert.Contains(js, `"datasource":"thanos"`)
+
+	d, err := FromBytes([]byte(js))
+	assert.NoError(err)
+	assert.Equal("NetObserv / Health", d.Title)
+	assert.Len(d.Rows[0].Panels, 4)
+
+	_, err = WithDatasource("not json", "thanos")
+	assert.Error(err)
+}
+
+func TestDashboardVariables(t *testing.T) {
+	assert := assert.New(t)
+
+	dashboards := BuildFlowMetricsDashboards([]metricslatest.FlowMetric{
+		{
+			Spec: metricslatest.FlowMetricSpec{
+				MetricName: "my_metric",
+				Type:       metricslatest.HistogramMetric,
+				Charts: []metricslatest.Chart{
+					{
+						DashboardName: "Main",
+						SectionName:   "My section",
+						Title:         "My chart",
+						Type:          metricslatest.ChartTypeLine,
+						Queries:       []metricslatest.Query{{PromQL: `sum(rate($METRIC_bucket{ns=~"$ns"}[5m])) by (ns)`, Legend: "{{ns}}"}},
+						Variables:     []metricslatest.ChartVariable{{Name: "ns", Label: "ns"}},
+					},
+					{
+						DashboardName: "Main",
+						SectionName:   "My section",
+						Title:         "My other chart",
+						Type:          metricslatest.ChartTypeLine,
+						Queries:       []metricslatest.Query{{PromQL: `sum(rate($METRIC_bucket{ns=~"$ns",pod=~"$pod"}[5m])) by (pod)`, Legend: "{{pod}}"}},
+						Variables:     []metricslatest.ChartVariable{{Name: "ns", Label: "ns"}, {Name: "pod", Label: "pod"}},
+					},
+				},
+			},
+		},
+	})
+	d := dashboards["Main"]
+	assert.Equal([]Variable{
+		{Name: "ns", Label: "ns", Metric: "netobserv_my_metric_count"},
+		{Name: "pod", Label: "pod", Metric: "netobserv_my_metric_count"},
+	}, d.Variables)
+	assert.Equal(`topk(7, sum(rate(netobserv_my_metric_bucket{ns=~"$ns"}[5m])) by (ns))`, d.FindPanel("My chart").Targets[0].Expr)
+
+	// Grafana
+	js, err := WithDatasource(d.ToGrafanaJSON(), "thanos")
+	assert.NoError(err)
+	var grafana struct {
+		Templating struct {
+			List []map[string]interface{} `json:"list"`
+		} `json:"templating"`
+	}
+	assert.NoError(json.Unmarshal([]byte(js), &grafana))
+	assert.Len(grafana.Templating.List, 2)
+	assert.Equal("ns", grafana.Templating.List[0]["name"])
+	assert.Equal("label_values(netobserv_my_metric_count, ns)", grafana.Templating.List[0]["query"])
+	assert.Equal(".+", grafana.Templating.List[0]["allValue"])
+	assert.Equal(true, grafana.Templating.List[0]["includeAll"])
+	assert.Equal("thanos", grafana.Templating.List[0]["datasource"])
+
+	// Perses
+	spec := d.ToPersesSpec()
+	assert.Len(spec.Variables, 2)
+	assert.Equal("ListVariable", spec.Variables[1].Kind)
+	assert.Equal("pod", spec.Variables[1].Spec.Name)
+	assert.Equal(".+", spec.Variables[1].Spec.CustomAllValue)
+	assert.Equal(map[string]interface{}{"labelName": "pod", "matchers": []interface{}{"netobserv_my_metric_count"}}, spec.Variables[1].Spec.Plugin.Spec)
+}
+
+func TestPredefinedDashboardVariables(t *testing.T) {
+	assert := assert.New(t)
+
+	defs := metrics.GetDefinitions(util.SpecForMetrics("node_ingress_bytes_total", "workload_ingress_bytes_total"), false)
+	d := BuildFlowMetricsDashboards(defs)["Main"]
+	var names []string
+	for _, v := range d.Variables {
+		names = append(names, v.Name)
+	}
+	assert.ElementsMatch([]string{"node", "namespace", "workload"}, names)
+
+	p := d.FindPanel("Top ingress traffic per app workload")
+	assert.NotNil(p)
+	assert.Contains(p.Targets[0].Expr, `SrcK8S_Namespace=~"$namespace",SrcK8S_OwnerName=~"$workload"`)
+	assert.Contains(p.Targets[0].Expr, `DstK8S_Namespace=~"$namespace",DstK8S_OwnerName=~"$workload"`)
 }
 
 func TestCreateCustomDashboard(t *testing.T) {
@@ -0,0 +1,79 @@
+package dashboards
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
+)
+
+// BuildFlowDashboard returns the model of a FlowDashboard, resolving the referenced metrics among the enabled ones.
+// It returns an error when some referenced metrics are not found.
+func BuildFlowDashboard(fd *metricslatest.FlowDashboard, metrics []metricslatest.FlowMetric) (*Dashboard, error) {
+	byName := make(map[string]*metricslatest.FlowMetric, len(metrics))
+	for i := range metrics {
+		byName[metrics[i].Spec.MetricName] = &metrics[i]
+	}
+	missing := make(map[string]any)
+	resolve := func(name string) *metricslatest.FlowMetric {
+		m, ok := byName[name]
+		if !ok {
+			missing[name] = nil
+		}
+		return m
+	}
+
+	title := fd.Spec.Title
+	if title == "" {
+		title = fd.Name
+	}
+	d := Dashboard{Title: "NetObserv / " + title}
+	for _, v := range fd.Spec.Variables {
+		if m := resolve(v.Metric); m != nil {
+			d.AddVariable(Variable{Name: v.Name, Label: v.Label, Metric: seriesName(m)})
+		}
+	}
+	for i := range fd.Spec.Rows {
+		row := &fd.Spec.Rows[i]
+		var panels []Panel
+		for j := range row.Panels {
+			p := &row.Panels[j]
+			var targets []Target
+			for _, q := range p.Queries {
+				m := resolve(q.Metric)
+				if m == nil {
+					continue
+				}
+				query := strings.ReplaceAll(q.PromQL, "$METRIC", "netobserv_"+m.Spec.MetricName)
+				if p.Type != metricslatest.ChartTypeSingleStat {
+					top := 7
+					if q.Top > 0 {
+						top = q.Top
+					}
+					query = fmt.Sprintf("topk(%d, %s)", top, query)
+				}
+				targets = append(targets, NewTarget(query, q.Legend))
+			}
+			span := p.Span
+			if span == 0 {
+				span = 4
+				if p.Type == metricslatest.ChartTypeSingleStat {
+					span = 3
+				}
+			}
+			panels = append(panels, NewPanel(p.Title, p.Type, p.Unit, span, targets...))
+		}
+		d.Rows = append(d.Rows, NewRow(row.Title, row.Collapse, "250px", panels))
+	}
+
+	if len(missing) > 0 {
+		var names []string
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("referenced metrics not found or not enabled: %s", strings.Join(names, ", "))
+	}
+	return &d, nil
+}
@@ -0,0 +1,96 @@
+package dashboards
+
+import (
+	"testing"
+
+	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics"
+	"github.com/netobserv/network-observability-operator/internal/pkg/test/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func flowDashboard(rows ...metricslatest.DashboardRow) *metricslatest.FlowDashboard {
+	return &metricslatest.FlowDashboard{
+		ObjectMeta: v1.ObjectMeta{Name: "my-dashboard", Namespace: "netobserv"},
+		Spec:       metricslatest.FlowDashboardSpec{Rows: rows},
+	}
+}
+
+func TestBuildFlowDashboard(t *testing.T) {
+	assert := assert.New(t)
+
+	defs := metrics.GetDefinitions(util.SpecForMetrics("namespace_flows_total", "namespace_drop_packets_total"), false)
+	fd := flowDashboard(
+		metricslatest.DashboardRow{
+			Title: "Overview",
+			Panels: []metricslatest.DashboardPanel{
+				{
+					Title:   "Total flows",
+					Type:    metricslatest.ChartTypeSingleStat,
+					Queries: []metricslatest.DashboardQuery{{Metric: "namespace_flows_total", PromQL: "sum(rate($METRIC[2m]))"}},
+				},
+				{
+					Title: "Flows and drops",
+					Type:  metricslatest.ChartTypeLine,
+					Span:  6,
+					Queries: []metricslatest.DashboardQuery{
+						{Metric: "namespace_flows_total", PromQL: `sum(rate($METRIC{SrcK8S_Namespace=~"$namespace"}[2m]))`, Legend: "flows", Top: 3},
+						{Metric: "namespace_drop_packets_total", PromQL: "sum(rate($METRIC[2m]))", Legend: "drops"},
+					},
+				},
+			},
+		},
+	)
+	fd.Spec.Variables = []metricslatest.DashboardVariable{
+		{ChartVariable: metricslatest.ChartVariable{Name: "namespace", Label: "SrcK8S_Namespace"}, Metric: "namespace_flows_total"},
+	}
+
+	d, err := BuildFlowDashboard(fd, defs)
+	require.NoError(t, err)
+
+	// Title defaults to the resource name
+	assert.Equal("NetObserv / my-dashboard", d.Title)
+	assert.Equal([]string{"Overview"}, d.Titles())
+	assert.Equal([]Variable{{Name: "namespace", Label: "SrcK8S_Namespace", Metric: "netobserv_namespace_flows_total"}}, d.Variables)
+
+	p := d.FindPanel("Total flows")
+	require.NotNil(t, p)
+	assert.Equal(3, p.Span)
+	assert.Len(p.Targets, 1)
+	assert.Equal("sum(rate(netobserv_namespace_flows_total[2m]))", p.Targets[0].Expr)
+
+	p = d.FindPanel("Flows and drops")
+	require.NotNil(t, p)
+	assert.Equal(6, p.Span)
+	assert.Len(p.Targets, 2)
+	assert.Equal(`topk(3, sum(rate(netobserv_namespace_flows_total{SrcK8S_Namespace=~"$namespace"}[2m])))`, p.Targets[0].Expr)
+	assert.Equal("flows", p.Targets[0].LegendFormat)
+	assert.Equal("topk(7, sum(rate(netobserv_namespace_drop_packets_total[2m])))", p.Targets[1].Expr)
+	assert.Equal("drops", p.Targets[1].LegendFormat)
+}
+
+func TestBuildFlowDashboard_MissingMetrics(t *testing.T) {
+	defs := metrics.GetDefinitions(util.SpecForMetrics("namespace_flows_total"), false)
+	fd := flowDashboard(
+		metricslatest.DashboardRow{
+			Panels: []metricslatest.DashboardPanel{
+				{
+					Title: "Flows and drops",
+					Type:  metricslatest.ChartTypeLine,
+					Queries: []metricslatest.DashboardQuery{
+						{Metric: "namespace_flows_total", PromQL: "sum(rate($METRIC[2m]))"},
+						{Metric: "namespace_drop_packets_total", PromQL: "sum(rate($METRIC[2m]))"},
+						{Metric: "my_custom_metric", PromQL: "sum(rate($METRIC[2m]))"},
+					},
+				},
+			},
+		},
+	)
+	fd.Spec.Title = "Custom"
+
+	d, err := BuildFlowDashboard(fd, defs)
+	assert.Nil(t, d)
+	assert.EqualError(t, err, "referenced metrics not found or not enabled: my_custom_metric, namespace_drop_packets_total")
+}
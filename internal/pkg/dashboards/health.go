@@ -4,11 +4,18 @@ import (
 	"fmt"
 	"strings"
 
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics/alerts"
 )
 
 func CreateHealthDashboard(netobsNs, nsFlowsMetric string) (string, error) {
+	return BuildHealthDashboard(netobsNs, nsFlowsMetric).ToGrafanaJSON(), nil
+}
+
+// BuildHealthDashboard returns the model of the health dashboard
+func BuildHealthDashboard(netobsNs, nsFlowsMetric string) *Dashboard {
 	d := Dashboard{Title: "NetObserv / Health"}
 
 	// Global stats
@@ -31,6 +38,22 @@ func CreateHealthDashboard(netobsNs, nsFlowsMetric string) (string, error) {
 			`, "")),
 	}))
 
+	// Health scores, recorded from the active health rules
+	d.Rows = append(d.Rows, NewRow("Network health score", false, "250px", []Panel{
+		NewPanel("Lowest node scores", metricslatest.ChartTypeLine, "", 3,
+			NewTarget(fmt.Sprintf("bottomk(10, %s)", alerts.HealthScoreRecordingName(flowslatest.GroupByNode)), "{{node}}"),
+		),
+		NewPanel("Lowest namespace scores", metricslatest.ChartTypeLine, "", 3,
+			NewTarget(fmt.Sprintf("bottomk(10, %s)", alerts.HealthScoreRecordingName(flowslatest.GroupByNamespace)), "{{namespace}}"),
+		),
+		NewPanel("Lowest workload scores", metricslatest.ChartTypeLine, "", 3,
+			NewTarget(fmt.Sprintf("bottomk(10, %s)", alerts.HealthScoreRecordingName(flowslatest.GroupByWorkload)), "{{workload}} ({{namespace}})"),
+		),
+		NewPanel("Active maintenance windows", metricslatest.ChartTypeLine, "", 3,
+			NewTarget(fmt.Sprintf("count(%s) by (window)", alerts.MaintenanceRecordingName), "{{window}}"),
+		),
+	}))
+
 	// FLP stats
 	overheadQuery := fmt.Sprintf(
 		"100 * sum(rate(%s{SrcK8S_Namespace='%s'}[1m]) or rate(%s{SrcK8S_Namespace!='%s',DstK8S_Namespace='%s'}[1m])) / sum(rate(%s[1m]))",
@@ -146,5 +169,5 @@ func CreateHealthDashboard(netobsNs, nsFlowsMetric string) (string, error) {
 		),
 	}))
 
-	return d.ToGrafanaJSON(), nil
+	return &d
 }
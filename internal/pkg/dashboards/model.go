@@ -9,8 +9,29 @@ import (
 )
 
 type Dashboard struct {
-	Rows  []*Row
-	Title string
+	Rows      []*Row
+	Title     string
+	Variables []Variable
+}
+
+// Variable is a dashboard template variable, populated from the values of a metric label
+type Variable struct {
+	Name   string
+	Label  string
+	Metric string
+}
+
+// variableAllValue is the value of a variable when all values are selected: it matches any non-empty label value
+const variableAllValue = ".+"
+
+// AddVariable adds a template variable to the dashboard, unless a variable with the same name already exists
+func (d *Dashboard) AddVariable(v Variable) {
+	for i := range d.Variables {
+		if d.Variables[i].Name == v.Name {
+			return
+		}
+	}
+	d.Variables = append(d.Variables, v)
 }
 
 type Row struct {
@@ -120,6 +141,10 @@ func (d *Dashboard) ToGrafanaJSON() string {
 	}
 
 	rowsStr := strings.Join(rows, ",")
+	var variables []string
+	for i := range d.Variables {
+		variables = append(variables, d.Variables[i].ToGrafanaJSON())
+	}
 	return fmt.Sprintf(`
 	{
 		"__inputs": [],
@@ -141,7 +166,7 @@ func (d *Dashboard) ToGrafanaJSON() string {
 			"networking-mixin"
 		],
 		"templating": {
-			"list": []
+			"list": [%s]
 		},
 		"time": {
 			"from": "now",
@@ -176,7 +201,33 @@ func (d *Dashboard) ToGrafanaJSON() string {
 		"title": "%s",
 		"version": 0
 	}
-	`, rowsStr, d.Title)
+	`, rowsStr, strings.Join(variables, ","), d.Title)
+}
+
+func (v *Variable) ToGrafanaJSON() string {
+	return fmt.Sprintf(`
+	{
+		"allValue": "%s",
+		"current": {
+			"selected": true,
+			"text": "All",
+			"value": "$__all"
+		},
+		"datasource": "prometheus",
+		"hide": 0,
+		"includeAll": true,
+		"label": "%s",
+		"multi": true,
+		"name": "%s",
+		"options": [],
+		"query": "label_values(%s, %s)",
+		"refresh": 2,
+		"regex": "",
+		"skipUrlSync": false,
+		"sort": 1,
+		"type": "query"
+	}
+	`, variableAllValue, v.Name, v.Name, v.Metric, v.Label)
 }
 
 func (r *Row) Titles() []string {
@@ -317,3 +368,33 @@ func (t *Target) ToGrafanaJSON() string {
 	}
 	`, expr, t.LegendFormat)
 }
+
+// WithDatasource returns the Grafana dashboard JSON with every panel and template variable querying the provided datasource
+func WithDatasource(dashboardJSON, datasource string) (string, error) {
+	var d map[string]interface{}
+	if err := json.Unmarshal([]byte(dashboardJSON), &d); err != nil {
+		return "", err
+	}
+	rows, _ := d["rows"].([]interface{})
+	for _, r := range rows {
+		row, _ := r.(map[string]interface{})
+		panels, _ := row["panels"].([]interface{})
+		for _, p := range panels {
+			if panel, ok := p.(map[string]interface{}); ok {
+				panel["datasource"] = datasource
+			}
+		}
+	}
+	templating, _ := d["templating"].(map[string]interface{})
+	variables, _ := templating["list"].([]interface{})
+	for _, v := range variables {
+		if variable, ok := v.(map[string]interface{}); ok {
+			variable["datasource"] = datasource
+		}
+	}
+	b, err := json.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,238 @@
+package dashboards
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
+)
+
+const (
+	persesGridWidth = 24
+	// Perses grid rows are about 30px high
+	persesRowPixels = 30
+)
+
+// PersesDashboardSpec is the spec of a Perses dashboard, as used in the PersesDashboard resource (perses.dev/v1alpha1)
+type PersesDashboardSpec struct {
+	Display   PersesDisplay          `json:"display"`
+	Duration  string                 `json:"duration"`
+	Variables []PersesVariable       `json:"variables,omitempty"`
+	Panels    map[string]PersesPanel `json:"panels"`
+	Layouts   []PersesLayout         `json:"layouts"`
+}
+
+type PersesDisplay struct {
+	Name string `json:"name"`
+}
+
+type PersesPanel struct {
+	Kind string          `json:"kind"`
+	Spec PersesPanelSpec `json:"spec"`
+}
+
+type PersesPanelSpec struct {
+	Display PersesDisplay `json:"display"`
+	Plugin  PersesPlugin  `json:"plugin"`
+	Queries []PersesQuery `json:"queries"`
+}
+
+type PersesPlugin struct {
+	Kind string                 `json:"kind"`
+	Spec map[string]interface{} `json:"spec"`
+}
+
+type PersesQuery struct {
+	Kind string          `json:"kind"`
+	Spec PersesQuerySpec `json:"spec"`
+}
+
+type PersesQuerySpec struct {
+	Plugin PersesPlugin `json:"plugin"`
+}
+
+type PersesVariable struct {
+	Kind string             `json:"kind"`
+	Spec PersesVariableSpec `json:"spec"`
+}
+
+type PersesVariableSpec struct {
+	Name           string        `json:"name"`
+	Display        PersesDisplay `json:"display"`
+	AllowAllValue  bool          `json:"allowAllValue"`
+	AllowMultiple  bool          `json:"allowMultiple"`
+	CustomAllValue string        `json:"customAllValue"`
+	DefaultValue   string        `json:"defaultValue"`
+	Sort           string        `json:"sort"`
+	Plugin         PersesPlugin  `json:"plugin"`
+}
+
+type PersesLayout struct {
+	Kind string           `json:"kind"`
+	Spec PersesLayoutSpec `json:"spec"`
+}
+
+type PersesLayoutSpec struct {
+	Display *PersesLayoutDisplay `json:"display,omitempty"`
+	Items   []PersesLayoutItem   `json:"items"`
+}
+
+type PersesLayoutDisplay struct {
+	Title    string         `json:"title"`
+	Collapse PersesCollapse `json:"collapse"`
+}
+
+type PersesCollapse struct {
+	Open bool `json:"open"`
+}
+
+type PersesLayoutItem struct {
+	X       int               `json:"x"`
+	Y       int               `json:"y"`
+	Width   int               `json:"width"`
+	Height  int               `json:"height"`
+	Content map[string]string `json:"content"`
+}
+
+var persesExprCleaner = strings.NewReplacer(
+	"\t", "",
+	"\n", "",
+)
+
+// ToPersesSpec converts the dashboard into a Perses dashboard spec. Each row becomes a grid layout.
+func (d *Dashboard) ToPersesSpec() *PersesDashboardSpec {
+	spec := PersesDashboardSpec{
+		Display:  PersesDisplay{Name: d.Title},
+		Duration: "1h",
+		Panels:   map[string]PersesPanel{},
+		Layouts:  []PersesLayout{},
+	}
+	for i := range d.Variables {
+		spec.Variables = append(spec.Variables, d.Variables[i].ToPersesVariable())
+	}
+	for i, r := range d.Rows {
+		layout := PersesLayout{Kind: "Grid", Spec: PersesLayoutSpec{Items: []PersesLayoutItem{}}}
+		if r.Title != "" {
+			layout.Spec.Display = &PersesLayoutDisplay{Title: r.Title, Collapse: PersesCollapse{Open: !r.Collapse}}
+		}
+		height := r.persesHeight()
+		x, y := 0, 0
+		for j := range r.Panels {
+			p := &r.Panels[j]
+			key := fmt.Sprintf("%d_%d", i, j)
+			spec.Panels[key] = p.ToPersesPanel()
+			width := p.Span * persesGridWidth / 12
+			if width <= 0 || width > persesGridWidth {
+				width = persesGridWidth
+			}
+			if x+width > persesGridWidth {
+				x = 0
+				y += height
+			}
+			layout.Spec.Items = append(layout.Spec.Items, PersesLayoutItem{
+				X:       x,
+				Y:       y,
+				Width:   width,
+				Height:  height,
+				Content: map[string]string{"$ref": "#/spec/panels/" + key},
+			})
+			x += width
+		}
+		spec.Layouts = append(spec.Layouts, layout)
+	}
+	return &spec
+}
+
+func (v *Variable) ToPersesVariable() PersesVariable {
+	return PersesVariable{
+		Kind: "ListVariable",
+		Spec: PersesVariableSpec{
+			Name:           v.Name,
+			Display:        PersesDisplay{Name: v.Name},
+			AllowAllValue:  true,
+			AllowMultiple:  true,
+			CustomAllValue: variableAllValue,
+			DefaultValue:   "$__all",
+			Sort:           "alphabetical-asc",
+			Plugin: PersesPlugin{Kind: "PrometheusLabelValuesVariable", Spec: map[string]interface{}{
+				"labelName": v.Label,
+				"matchers":  []interface{}{v.Metric},
+			}},
+		},
+	}
+}
+
+func (r *Row) persesHeight() int {
+	px, err := strconv.Atoi(strings.TrimSuffix(r.Height, "px"))
+	if err != nil || px < persesRowPixels {
+		return 8
+	}
+	return (px + persesRowPixels/2) / persesRowPixels
+}
+
+func (p *Panel) ToPersesPanel() PersesPanel {
+	unit := persesUnit(p.Unit)
+	var plugin PersesPlugin
+	switch p.Type {
+	case metricslatest.ChartTypeSingleStat:
+		plugin = PersesPlugin{Kind: "StatChart", Spec: map[string]interface{}{
+			"calculation": "last-number",
+			"format":      map[string]interface{}{"unit": unit},
+		}}
+	case metricslatest.ChartTypeStackArea:
+		plugin = PersesPlugin{Kind: "TimeSeriesChart", Spec: map[string]interface{}{
+			"legend": map[string]interface{}{"position": "bottom"},
+			"yAxis":  map[string]interface{}{"format": map[string]interface{}{"unit": unit}},
+			"visual": map[string]interface{}{"display": "line", "stack": "all", "areaOpacity": 0.5},
+		}}
+	case metricslatest.ChartTypeLine:
+		plugin = PersesPlugin{Kind: "TimeSeriesChart", Spec: map[string]interface{}{
+			"legend": map[string]interface{}{"position": "bottom"},
+			"yAxis":  map[string]interface{}{"format": map[string]interface{}{"unit": unit}},
+			"visual": map[string]interface{}{"display": "line"},
+		}}
+	}
+	queries := []PersesQuery{}
+	for i := range p.Targets {
+		queries = append(queries, p.Targets[i].ToPersesQuery())
+	}
+	return PersesPanel{
+		Kind: "Panel",
+		Spec: PersesPanelSpec{
+			Display: PersesDisplay{Name: p.Title},
+			Plugin:  plugin,
+			Queries: queries,
+		},
+	}
+}
+
+func (t *Target) ToPersesQuery() PersesQuery {
+	spec := map[string]interface{}{"query": persesExprCleaner.Replace(t.Expr)}
+	if t.LegendFormat != "" {
+		spec["seriesNameFormat"] = t.LegendFormat
+	}
+	return PersesQuery{
+		Kind: "TimeSeriesQuery",
+		Spec: PersesQuerySpec{
+			Plugin: PersesPlugin{Kind: "PrometheusTimeSeriesQuery", Spec: spec},
+		},
+	}
+}
+
+func persesUnit(unit metricslatest.Unit) string {
+	switch unit {
+	case metricslatest.UnitBytes:
+		return "bytes"
+	case metricslatest.UnitSeconds:
+		return "seconds"
+	case metricslatest.UnitBPS:
+		return "bytes/sec"
+	case metricslatest.UnitPPS:
+		return "packets/sec"
+	case metricslatest.UnitPercent:
+		// values are ratios between 0 and 1, as with Grafana "percentunit"
+		return "percent-decimal"
+	}
+	return "decimal"
+}
@@ -0,0 +1,69 @@
+package dashboards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics"
+	"github.com/netobserv/network-observability-operator/internal/pkg/test/util"
+)
+
+func TestPersesFlowMetricsDashboard(t *testing.T) {
+	assert := assert.New(t)
+
+	defs := metrics.GetDefinitions(util.SpecForMetrics("node_ingress_bytes_total"), false)
+	d := BuildFlowMetricsDashboards(defs)["Main"]
+	assert.NotNil(d)
+
+	spec := d.ToPersesSpec()
+	assert.Equal("NetObserv / Main", spec.Display.Name)
+	assert.Len(spec.Layouts, 2)
+	assert.Nil(spec.Layouts[0].Spec.Display)
+	assert.Equal("Traffic rates per node", spec.Layouts[1].Spec.Display.Title)
+	assert.True(spec.Layouts[1].Spec.Display.Collapse.Open)
+
+	// Top row: single stat
+	assert.Len(spec.Layouts[0].Spec.Items, 1)
+	assert.Equal(PersesLayoutItem{X: 0, Y: 0, Width: 6, Height: 8, Content: map[string]string{"$ref": "#/spec/panels/0_0"}}, spec.Layouts[0].Spec.Items[0])
+	stat := spec.Panels["0_0"]
+	assert.Equal("Total ingress traffic", stat.Spec.Display.Name)
+	assert.Equal("StatChart", stat.Spec.Plugin.Kind)
+	assert.Equal(map[string]interface{}{"unit": "bytes/sec"}, stat.Spec.Plugin.Spec["format"])
+	assert.Equal("PrometheusTimeSeriesQuery", stat.Spec.Queries[0].Spec.Plugin.Kind)
+	assert.Equal("sum(rate(netobserv_node_ingress_bytes_total[2m]))", stat.Spec.Queries[0].Spec.Plugin.Spec["query"])
+
+	// Graph
+	graph := spec.Panels["1_0"]
+	assert.Equal("Top ingress traffic per node (Bps)", graph.Spec.Display.Name)
+	assert.Equal("TimeSeriesChart", graph.Spec.Plugin.Kind)
+	assert.Equal("all", graph.Spec.Plugin.Spec["visual"].(map[string]interface{})["stack"])
+	assert.Equal("source:{{SrcK8S_HostName}}, dest:{{DstK8S_HostName}}", graph.Spec.Queries[0].Spec.Plugin.Spec["seriesNameFormat"])
+}
+
+func TestPersesHealthDashboard(t *testing.T) {
+	assert := assert.New(t)
+
+	spec := BuildHealthDashboard("netobserv", "netobserv_namespace_flows_total").ToPersesSpec()
+	assert.Equal("NetObserv / Health", spec.Display.Name)
+	assert.Len(spec.Layouts, 6)
+
+	// Top row has 4 panels of span 3, 100px high
+	items := spec.Layouts[0].Spec.Items
+	assert.Len(items, 4)
+	for i, item := range items {
+		assert.Equal(i*6, item.X)
+		assert.Equal(0, item.Y)
+		assert.Equal(3, item.Height)
+	}
+	assert.Equal("Flows per second", spec.Panels["0_0"].Spec.Display.Name)
+	// Multi-line expressions are flattened
+	assert.NotContains(spec.Panels["0_2"].Spec.Queries[0].Spec.Plugin.Spec["query"], "\n")
+
+	// Panels are wrapped on the grid width
+	for _, l := range spec.Layouts {
+		for _, item := range l.Spec.Items {
+			assert.LessOrEqual(item.X+item.Width, persesGridWidth)
+		}
+	}
+}
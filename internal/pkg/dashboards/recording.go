@@ -0,0 +1,99 @@
+package dashboards
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	metricslatest "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
+)
+
+var (
+	// rateRegexp matches a rate on a NetObserv series, such as `rate(netobserv_namespace_flows_total{K8S_FlowLayer="app"}[2m])`
+	rateRegexp       = regexp.MustCompile(`rate\((netobserv_[a-zA-Z0-9_]+)(\{[^{}]*\})?\[([0-9]+[smh])\]\)`)
+	promLabelsRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// UseRecordingRules rewrites the dashboards queries to use recorded series in place of the rates computed on flow metrics,
+// and returns the recording rules providing these series, sorted by name.
+// Recorded series are aggregated on the metric labels, which drops the scraping labels such as the pod and instance,
+// and are named after the Prometheus convention "level:metric:operations", e.g. `netobserv:namespace_flows_total:rate2m`.
+func UseRecordingRules(metrics []metricslatest.FlowMetric, dashboards ...*Dashboard) []monitoringv1.Rule {
+	seriesLabels := recordableSeries(metrics)
+	rules := make(map[string]string)
+	for _, d := range dashboards {
+		if d == nil {
+			continue
+		}
+		for i := range d.Rows {
+			for j := range d.Rows[i].Panels {
+				p := &d.Rows[i].Panels[j]
+				for k := range p.Targets {
+					t := &p.Targets[k]
+					t.Expr = rateRegexp.ReplaceAllStringFunc(t.Expr, func(rate string) string {
+						parts := rateRegexp.FindStringSubmatch(rate)
+						series, filters, interval := parts[1], parts[2], parts[3]
+						labels, ok := seriesLabels[series]
+						if !ok {
+							// not a flow metric, e.g. operational metrics from the health dashboard
+							return rate
+						}
+						record := recordedSeriesName(series, interval)
+						rules[record] = fmt.Sprintf("sum(rate(%s[%s])) by (%s)", series, interval, strings.Join(labels, ","))
+						return record + filters
+					})
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var recordingRules []monitoringv1.Rule
+	for _, name := range names {
+		recordingRules = append(recordingRules, monitoringv1.Rule{
+			Record: name,
+			Expr:   intstr.FromString(rules[name]),
+		})
+	}
+	return recordingRules
+}
+
+func recordedSeriesName(series, interval string) string {
+	return "netobserv:" + strings.TrimPrefix(series, "netobserv_") + ":rate" + interval
+}
+
+// recordableSeries returns the labels of the counter and histogram series, per series name, as they appear in Prometheus
+func recordableSeries(metrics []metricslatest.FlowMetric) map[string][]string {
+	series := make(map[string][]string)
+	for i := range metrics {
+		spec := &metrics[i].Spec
+		var labels []string
+		for _, label := range spec.Labels {
+			if remapped, ok := spec.Remap[label]; ok {
+				label = string(remapped)
+			}
+			if promLabelsRegexp.MatchString(label) {
+				labels = append(labels, label)
+			}
+		}
+		name := "netobserv_" + spec.MetricName
+		// metric type is case-insensitive, e.g. "counter" is also allowed
+		switch {
+		case strings.EqualFold(string(spec.Type), string(metricslatest.CounterMetric)):
+			series[name] = labels
+		case strings.EqualFold(string(spec.Type), string(metricslatest.HistogramMetric)):
+			series[name+"_bucket"] = append(append([]string{}, labels...), "le")
+			series[name+"_count"] = labels
+			series[name+"_sum"] = labels
+		}
+	}
+	return series
+}
@@ -0,0 +1,104 @@
+package dashboards
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/netobserv/network-observability-operator/internal/pkg/metrics"
+	"github.com/netobserv/network-observability-operator/internal/pkg/test/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseRecordingRules(t *testing.T) {
+	assert := assert.New(t)
+
+	defs := metrics.GetDefinitions(util.SpecForMetrics("node_egress_bytes_total", "namespace_flows_total", "node_rtt_seconds"), false)
+	main := BuildFlowMetricsDashboards(defs)["Main"]
+	health := BuildHealthDashboard("netobserv", "netobserv_namespace_flows_total")
+
+	rules := UseRecordingRules(defs, main, health)
+
+	var names []string
+	for _, r := range rules {
+		names = append(names, r.Record)
+	}
+	assert.Equal([]string{
+		"netobserv:namespace_flows_total:rate1m",
+		"netobserv:node_egress_bytes_total:rate2m",
+		"netobserv:node_rtt_seconds_bucket:rate2m",
+	}, names)
+	assert.Equal(
+		"sum(rate(netobserv_node_rtt_seconds_bucket[2m])) by (K8S_ClusterName,SrcK8S_Zone,DstK8S_Zone,SrcK8S_HostName,DstK8S_HostName,le)",
+		rules[2].Expr.String(),
+	)
+
+	p := main.FindPanel("Top egress traffic per node")
+	require.NotNil(t, p)
+	assert.Equal(
+		`topk(7, (sum(netobserv:node_egress_bytes_total:rate2m{SrcK8S_HostName=~"$node"}) by (SrcK8S_HostName,DstK8S_HostName))`+
+			` or (sum(netobserv:node_egress_bytes_total:rate2m{DstK8S_HostName=~"$node"}) by (SrcK8S_HostName,DstK8S_HostName)))`,
+		p.Targets[0].Expr,
+	)
+	p = main.FindPanel("TCP latency, p99")
+	require.NotNil(t, p)
+	assert.Equal("histogram_quantile(0.99, sum(netobserv:node_rtt_seconds_bucket:rate2m) by (le)) > 0", p.Targets[0].Expr)
+
+	// Operational metrics and disabled flow metrics are not recorded
+	p = health.FindPanel("Flows per second")
+	require.NotNil(t, p)
+	assert.Equal("sum(rate(netobserv_ingest_flows_processed[1m]))", p.Targets[0].Expr)
+	p = health.FindPanel("By node")
+	require.NotNil(t, p)
+	assert.Equal(`topk(10,sum(rate(netobserv_node_flows_total{SrcK8S_HostName!=""}[1m])) by (SrcK8S_HostName))`, p.Targets[0].Expr)
+	p = health.FindPanel("By namespace")
+	require.NotNil(t, p)
+	assert.Equal(`topk(10,sum(netobserv:namespace_flows_total:rate1m{SrcK8S_Namespace!=""}) by (SrcK8S_Namespace))`, p.Targets[0].Expr)
+}
+
+var (
+	recordedSelectorRegexp = regexp.MustCompile(`(netobserv:[a-zA-Z0-9_]+:rate[0-9]+[smh])(\{[^{}]*\})?`)
+	matcherLabelRegexp     = regexp.MustCompile(`([a-zA-Z_][a-zA-Z0-9_]*)\s*(=~|!~|!=|=)`)
+	byLabelsRegexp         = regexp.MustCompile(`by \(([^)]*)\)$`)
+)
+
+func TestUseRecordingRules_AllPredefined(t *testing.T) {
+	defs := metrics.GetDefinitions(util.SpecForMetrics(), true)
+	all := []*Dashboard{BuildHealthDashboard("netobserv", "netobserv_namespace_flows_total")}
+	for _, d := range BuildFlowMetricsDashboards(defs) {
+		all = append(all, d)
+	}
+	rules := UseRecordingRules(defs, all...)
+	require.NotEmpty(t, rules)
+	assert.True(t, sort.SliceIsSorted(rules, func(i, j int) bool { return rules[i].Record < rules[j].Record }))
+
+	recordedLabels := map[string][]string{}
+	for _, r := range rules {
+		parts := byLabelsRegexp.FindStringSubmatch(r.Expr.String())
+		require.Len(t, parts, 2, r.Expr.String())
+		recordedLabels[r.Record] = strings.Split(parts[1], ",")
+	}
+
+	// Every label used in the queries on recorded series must be kept by the recording rule aggregation
+	for i, d := range all {
+		for _, r := range d.Rows {
+			for _, p := range r.Panels {
+				for _, target := range p.Targets {
+					if i > 0 {
+						// flow metrics dashboards only query recorded rates
+						assert.NotContains(t, target.Expr, "rate(netobserv_", "flow metric rate not recorded in panel "+p.Title)
+					}
+					for _, sel := range recordedSelectorRegexp.FindAllStringSubmatch(target.Expr, -1) {
+						labels, ok := recordedLabels[sel[1]]
+						require.True(t, ok, "missing rule for "+sel[1])
+						for _, m := range matcherLabelRegexp.FindAllStringSubmatch(sel[2], -1) {
+							assert.Contains(t, labels, m[1], "label not recorded in panel "+p.Title)
+						}
+					}
+				}
+			}
+		}
+	}
+}
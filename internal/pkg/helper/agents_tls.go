@@ -0,0 +1,67 @@
+package helper
+
+import (
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+)
+
+// AgentsTLSConfig holds the certificate references used for mutual TLS between the eBPF agents and flowlogs-pipeline.
+type AgentsTLSConfig struct {
+	// Server is the flowlogs-pipeline certificate, and ServerCA is used by flowlogs-pipeline to verify the agents
+	Server   flowslatest.CertificateReference
+	ServerCA flowslatest.CertificateReference
+	// Client is the agents certificate, and ClientCA is used by the agents to verify flowlogs-pipeline
+	Client   flowslatest.CertificateReference
+	ClientCA flowslatest.CertificateReference
+}
+
+// GetAgentsTLSConfig returns the certificate references for mutual TLS between the eBPF agents and flowlogs-pipeline,
+// or nil when mutual TLS is not used. When certificates are issued by cert-manager, they are all signed by the CA
+// of the privileged namespace, and the server certificate is copied from there to the main namespace.
+func GetAgentsTLSConfig(spec *flowslatest.FlowCollectorSpec, useCertManager bool) *AgentsTLSConfig {
+	if !spec.UseAgentsMutualTLS() {
+		return nil
+	}
+	if spec.UseProvidedAgentsCertificates() {
+		provided := spec.Processor.AgentsTLS.Provided
+		if provided == nil {
+			return nil
+		}
+		return &AgentsTLSConfig{
+			Server:   provided.ServerCert,
+			ServerCA: provided.CACert,
+			Client:   provided.ClientCert,
+			ClientCA: provided.CACert,
+		}
+	}
+	// Certificates managed by the operator: each secret also contains the CA certificates to trust
+	server := flowslatest.CertificateReference{
+		Type:     flowslatest.RefTypeSecret,
+		Name:     constants.FLPAgentsTLSSecretName,
+		CertFile: "tls.crt",
+		CertKey:  "tls.key",
+	}
+	client := flowslatest.CertificateReference{
+		Type:      flowslatest.RefTypeSecret,
+		Name:      constants.EBPFAgentTLSSecretName,
+		Namespace: spec.GetNamespace() + constants.EBPFPrivilegedNSSuffix,
+		CertFile:  "tls.crt",
+		CertKey:   "tls.key",
+	}
+	if useCertManager {
+		server.Name = constants.FLPAgentsCertSecretName
+		server.Namespace = client.Namespace
+		client.Name = constants.EBPFAgentCertSecretName
+	}
+	cfg := AgentsTLSConfig{
+		Server:   server,
+		ServerCA: server,
+		Client:   client,
+		ClientCA: client,
+	}
+	cfg.ServerCA.CertFile = "ca.crt"
+	cfg.ServerCA.CertKey = ""
+	cfg.ClientCA.CertFile = "ca.crt"
+	cfg.ClientCA.CertKey = ""
+	return &cfg
+}
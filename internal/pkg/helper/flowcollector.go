@@ -63,6 +63,11 @@ func AddManagedLabel(obj client.Object) {
 	obj.SetLabels(labels)
 }
 
+// ManagedSelector selects the objects that have the netobserv-managed label set to true
+func ManagedSelector() client.MatchingLabels {
+	return client.MatchingLabels{netobservManagedLabel: "true"}
+}
+
 func IsManaged(obj client.Object) bool {
 	labels := obj.GetLabels()
 	if labels == nil {
@@ -28,8 +28,25 @@ func GetSecretOrConfigMap(file *flowslatest.FileReference) monitoringv1.SecretOr
 	}
 }
 
-func GetServiceMonitorTLSConfig(tls *flowslatest.ServerTLS, serverName string, isDownstream bool) (monitoringv1.Scheme, *monitoringv1.TLSConfig) {
+// GetServiceMonitorTLSConfig returns the scheme and TLS configuration used by Prometheus to scrape a metrics endpoint.
+// In "Auto" mode, when certManagerSecret is set, the CA is read from the Secret issued by cert-manager, otherwise from the OpenShift service CA.
+func GetServiceMonitorTLSConfig(tls *flowslatest.ServerTLS, serverName, certManagerSecret string, isDownstream bool) (monitoringv1.Scheme, *monitoringv1.TLSConfig) {
 	if tls.Type == flowslatest.ServerTLSAuto {
+		if certManagerSecret != "" {
+			return "https", &monitoringv1.TLSConfig{
+				SafeTLSConfig: monitoringv1.SafeTLSConfig{
+					ServerName: ptr.To(serverName),
+					CA: monitoringv1.SecretOrConfigMap{
+						Secret: &corev1.SecretKeySelector{
+							Key: "ca.crt",
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: certManagerSecret,
+							},
+						},
+					},
+				},
+			}
+		}
 		if isDownstream {
 			return "https", &monitoringv1.TLSConfig{
 				SafeTLSConfig: monitoringv1.SafeTLSConfig{
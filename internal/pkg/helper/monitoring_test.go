@@ -34,3 +34,24 @@ func TestSecretToSecretOrConfig(t *testing.T) {
 	assert.Equal(res.Secret.LocalObjectReference.Name, "Foo")
 	assert.Equal(res.Secret.Key, "test.txt")
 }
+
+func TestServiceMonitorTLSConfig(t *testing.T) {
+	assert := assert.New(t)
+	tls := flowslatest.ServerTLS{Type: flowslatest.ServerTLSAuto}
+
+	scheme, cfg := GetServiceMonitorTLSConfig(&tls, "svc.ns.svc", "", false)
+	assert.Equal("https", string(scheme))
+	assert.Equal("openshift-service-ca.crt", cfg.CA.ConfigMap.Name)
+
+	scheme, cfg = GetServiceMonitorTLSConfig(&tls, "svc.ns.svc", "svc", false)
+	assert.Equal("https", string(scheme))
+	assert.Nil(cfg.CA.ConfigMap)
+	assert.Equal("svc", cfg.CA.Secret.Name)
+	assert.Equal("ca.crt", cfg.CA.Secret.Key)
+	assert.Equal("svc.ns.svc", *cfg.ServerName)
+
+	tls.Type = flowslatest.ServerTLSDisabled
+	scheme, cfg = GetServiceMonitorTLSConfig(&tls, "svc.ns.svc", "svc", false)
+	assert.Equal("http", string(scheme))
+	assert.Nil(cfg)
+}
@@ -80,7 +80,11 @@ func GetOtelMetrics(flpMetrics []api.MetricsItem) ([]api.MetricsItem, error) {
 	if err != nil {
 		return nil, err
 	}
+	return ConvertMetrics(otelRules, flpMetrics), nil
+}
 
+// ConvertMetrics renames the metrics labels and filters according to the provided rules
+func ConvertMetrics(otelRules []api.GenericTransformRule, flpMetrics []api.MetricsItem) []api.MetricsItem {
 	var otelMetrics = []api.MetricsItem{}
 
 	for i := range flpMetrics {
@@ -98,7 +102,7 @@ func GetOtelMetrics(flpMetrics []api.MetricsItem) ([]api.MetricsItem, error) {
 		})
 	}
 
-	return otelMetrics, nil
+	return otelMetrics
 }
 
 func convertToOtelLabel(otelRules []api.GenericTransformRule, input string) string {
@@ -0,0 +1,176 @@
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/netobserv/flowlogs-pipeline/pkg/api"
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper/otel"
+)
+
+// Schema presets map NetObserv flows to third-party formats, so that exported flows can be ingested without
+// any additional mapping layer.
+// OCSF: https://schema.ocsf.io/classes/network_activity (attributes are written as dotted keys such as "src_endpoint.ip",
+// since flowlogs-pipeline cannot build nested objects)
+// ECS: https://www.elastic.co/guide/en/ecs/current/ecs-field-reference.html
+
+//go:embed ocsf-network-activity.json
+var rawOCSFConfig []byte
+
+//go:embed ecs.json
+var rawECSConfig []byte
+
+// Preset is the definition of a schema: fields renaming, unit and value conversions, and constant fields
+type Preset struct {
+	Fields      map[string]string                `json:"fields"`
+	Multipliers map[string]int                   `json:"multipliers,omitempty"`
+	Conversions []Conversion                     `json:"conversions,omitempty"`
+	Constants   []api.TransformFilterGenericRule `json:"constants,omitempty"`
+}
+
+// Conversion adds an output field from the numeric values of an input field, such as protocol names from protocol numbers
+type Conversion struct {
+	Input  string            `json:"input"`
+	Output string            `json:"output"`
+	Values map[string]string `json:"values"`
+}
+
+// Transforms holds the stages configuration to apply a schema, in order: value conversions, fields renaming, constants.
+type Transforms struct {
+	Conversions *api.TransformFilter
+	Generic     api.TransformGeneric
+	Constants   *api.TransformFilter
+}
+
+// GetPreset returns the preset for the given schema, or nil when flows are kept in the native NetObserv format
+func GetPreset(schema flowslatest.ExporterSchema) (*Preset, error) {
+	switch schema {
+	case flowslatest.ExporterSchemaOTel:
+		cfg, err := otel.GetOtelConfig()
+		if err != nil {
+			return nil, err
+		}
+		return &Preset{Fields: cfg}, nil
+	case flowslatest.ExporterSchemaOCSF:
+		return parsePreset(rawOCSFConfig)
+	case flowslatest.ExporterSchemaECS:
+		return parsePreset(rawECSConfig)
+	case flowslatest.ExporterSchemaNetObserv:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown exporter schema: %s", schema)
+}
+
+func parsePreset(raw []byte) (*Preset, error) {
+	var p Preset
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Rules returns the fields renaming rules, sorted by input field, followed by the rules keeping the converted fields
+func (p *Preset) Rules() []api.GenericTransformRule {
+	if p == nil {
+		return nil
+	}
+	rules := make([]api.GenericTransformRule, 0, len(p.Fields)+len(p.Conversions))
+	for k, v := range p.Fields {
+		rules = append(rules, api.GenericTransformRule{
+			Input:      k,
+			Output:     v,
+			Multiplier: p.Multipliers[k],
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Input < rules[j].Input
+	})
+	// Converted fields are already named after the schema, they only need to be kept by "replace_keys"
+	for _, c := range p.Conversions {
+		rules = append(rules, api.GenericTransformRule{Input: c.Output, Output: c.Output})
+	}
+	return rules
+}
+
+// TransformConfig returns the stages configuration to apply the schema, or nil when flows are left unchanged.
+// Custom rules are merged into the preset per input field: a custom rule replaces the preset rule for the same input,
+// or removes it when its output is empty. Custom rules for other inputs are added.
+func TransformConfig(p *Preset, custom *[]flowslatest.GenericTransformRule) *Transforms {
+	rules := mergeRules(p.Rules(), custom)
+	if len(rules) == 0 {
+		return nil
+	}
+	t := Transforms{
+		Generic: api.TransformGeneric{
+			Policy: "replace_keys",
+			Rules:  rules,
+		},
+	}
+	if p == nil {
+		return &t
+	}
+	if len(p.Conversions) > 0 {
+		t.Conversions = &api.TransformFilter{}
+		for _, c := range p.Conversions {
+			values := make([]string, 0, len(c.Values))
+			for v := range c.Values {
+				values = append(values, v)
+			}
+			sort.Strings(values)
+			for _, v := range values {
+				t.Conversions.Rules = append(t.Conversions.Rules, api.TransformFilterRule{
+					Type: api.AddFieldIf,
+					AddFieldIf: &api.TransformFilterRuleWithAssignee{
+						Input:      c.Input,
+						Output:     c.Output,
+						Parameters: "== " + v,
+						Assignee:   c.Values[v],
+					},
+				})
+			}
+		}
+	}
+	if len(p.Constants) > 0 {
+		t.Constants = &api.TransformFilter{}
+		for i := range p.Constants {
+			t.Constants.Rules = append(t.Constants.Rules, api.TransformFilterRule{
+				Type:     api.AddField,
+				AddField: &p.Constants[i],
+			})
+		}
+	}
+	return &t
+}
+
+func mergeRules(preset []api.GenericTransformRule, custom *[]flowslatest.GenericTransformRule) []api.GenericTransformRule {
+	if custom == nil {
+		return preset
+	}
+	overrides := map[string]flowslatest.GenericTransformRule{}
+	for _, r := range *custom {
+		overrides[r.Input] = r
+	}
+	var rules []api.GenericTransformRule
+	for _, r := range preset {
+		if o, ok := overrides[r.Input]; ok {
+			delete(overrides, r.Input)
+			if o.Output == "" {
+				continue
+			}
+			r = api.GenericTransformRule{Input: o.Input, Output: o.Output, Multiplier: o.Multiplier}
+		}
+		rules = append(rules, r)
+	}
+	for _, r := range *custom {
+		if o, ok := overrides[r.Input]; ok {
+			delete(overrides, r.Input)
+			if o.Output != "" {
+				rules = append(rules, api.GenericTransformRule{Input: o.Input, Output: o.Output, Multiplier: o.Multiplier})
+			}
+		}
+	}
+	return rules
+}
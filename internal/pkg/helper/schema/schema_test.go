@@ -0,0 +1,137 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/netobserv/flowlogs-pipeline/pkg/api"
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper/otel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresetsCoverOTelFields(t *testing.T) {
+	otelCfg, err := otel.GetOtelConfig()
+	assert.NoError(t, err)
+
+	for _, s := range []flowslatest.ExporterSchema{flowslatest.ExporterSchemaOCSF, flowslatest.ExporterSchemaECS} {
+		p, err := GetPreset(s)
+		assert.NoError(t, err, s)
+		for field := range otelCfg {
+			assert.NotEmpty(t, p.Fields[field], "%s: missing mapping for %s", s, field)
+		}
+		for field := range p.Multipliers {
+			assert.Contains(t, p.Fields, field, s)
+		}
+		for _, c := range p.Conversions {
+			assert.Contains(t, otelCfg, c.Input, s)
+			assert.NotContains(t, p.Fields, c.Output, s)
+		}
+	}
+}
+
+func TestNetObservPreset(t *testing.T) {
+	p, err := GetPreset(flowslatest.ExporterSchemaNetObserv)
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+
+	assert.Nil(t, TransformConfig(p, nil))
+
+	// Custom rules define the whole mapping
+	transforms := TransformConfig(p, &[]flowslatest.GenericTransformRule{{Input: "SrcAddr", Output: "src"}})
+	assert.Equal(t, []api.GenericTransformRule{{Input: "SrcAddr", Output: "src"}}, transforms.Generic.Rules)
+	assert.Nil(t, transforms.Conversions)
+	assert.Nil(t, transforms.Constants)
+
+	_, err = GetPreset("unknown")
+	assert.ErrorContains(t, err, "unknown exporter schema")
+}
+
+func TestOCSFTransformConfig(t *testing.T) {
+	p, err := GetPreset(flowslatest.ExporterSchemaOCSF)
+	assert.NoError(t, err)
+
+	transforms := TransformConfig(p, nil)
+	generic := transforms.Generic
+	assert.Equal(t, api.TransformGenericOperationEnum("replace_keys"), generic.Policy)
+	assert.Contains(t, generic.Rules, api.GenericTransformRule{Input: "SrcAddr", Output: "src_endpoint.ip"})
+	assert.Contains(t, generic.Rules, api.GenericTransformRule{Input: "TimeReceived", Output: "time", Multiplier: 1000})
+	assert.Contains(t, generic.Rules, api.GenericTransformRule{Input: "connection_info.protocol_name", Output: "connection_info.protocol_name"})
+	assert.Contains(t, transforms.Conversions.Rules, api.TransformFilterRule{
+		Type: api.AddFieldIf,
+		AddFieldIf: &api.TransformFilterRuleWithAssignee{
+			Input:      "Proto",
+			Output:     "connection_info.protocol_name",
+			Parameters: "== 6",
+			Assignee:   "tcp",
+		},
+	})
+	constants := transforms.Constants
+	assert.Equal(t, api.AddField, constants.Rules[0].Type)
+	assert.Equal(t, "class_uid", constants.Rules[0].AddField.Input)
+	assert.True(t, constants.Rules[0].AddField.CastInt)
+
+	// Custom rules are merged into the preset, conversions and constants are kept
+	transforms = TransformConfig(p, &[]flowslatest.GenericTransformRule{
+		{Input: "SrcAddr", Output: "src"},
+		{Input: "TimeReceived", Output: "time", Multiplier: 1000000},
+		{Input: "DstMac"},
+		{Input: "Sampling", Output: "unmapped.sampling"},
+	})
+	generic = transforms.Generic
+	assert.Len(t, generic.Rules, len(p.Fields)+len(p.Conversions))
+	assert.Contains(t, generic.Rules, api.GenericTransformRule{Input: "SrcAddr", Output: "src"})
+	assert.NotContains(t, generic.Rules, api.GenericTransformRule{Input: "SrcAddr", Output: "src_endpoint.ip"})
+	assert.Contains(t, generic.Rules, api.GenericTransformRule{Input: "TimeReceived", Output: "time", Multiplier: 1000000})
+	assert.Contains(t, generic.Rules, api.GenericTransformRule{Input: "DstAddr", Output: "dst_endpoint.ip"})
+	assert.NotContains(t, generic.Rules, api.GenericTransformRule{Input: "DstMac", Output: "dst_endpoint.mac"})
+	assert.Equal(t, api.GenericTransformRule{Input: "Sampling", Output: "unmapped.sampling"}, generic.Rules[len(generic.Rules)-1])
+	assert.NotNil(t, transforms.Conversions)
+	assert.NotNil(t, transforms.Constants)
+}
+
+func TestOCSFRecord(t *testing.T) {
+	p, err := GetPreset(flowslatest.ExporterSchemaOCSF)
+	assert.NoError(t, err)
+
+	// Apply the replace_keys rules to a sample flow, then encode it as the Kafka exporter does
+	flow := map[string]interface{}{"SrcAddr": "10.0.0.1", "SrcPort": 8080, "DstAddr": "10.0.0.2", "Bytes": 100}
+	record := map[string]interface{}{}
+	for _, r := range TransformConfig(p, nil).Generic.Rules {
+		if v, ok := flow[r.Input]; ok {
+			record[r.Output] = v
+		}
+	}
+	raw, err := json.Marshal(record)
+	assert.NoError(t, err)
+
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(raw, &decoded))
+	assert.Equal(t, "10.0.0.1", decoded["src_endpoint.ip"])
+	assert.Equal(t, float64(8080), decoded["src_endpoint.port"])
+	assert.Equal(t, float64(100), decoded["traffic.bytes"])
+	// Attributes are flat: there is no nested OCSF object
+	assert.NotContains(t, decoded, "src_endpoint")
+}
+
+func TestECSTransformConfig(t *testing.T) {
+	p, err := GetPreset(flowslatest.ExporterSchemaECS)
+	assert.NoError(t, err)
+
+	transforms := TransformConfig(p, nil)
+	assert.Contains(t, transforms.Generic.Rules, api.GenericTransformRule{Input: "DstAddr", Output: "destination.ip"})
+	assert.Contains(t, transforms.Generic.Rules, api.GenericTransformRule{Input: "TimeReceived", Output: "@timestamp", Multiplier: 1000})
+	assert.Contains(t, transforms.Conversions.Rules, api.TransformFilterRule{
+		Type: api.AddFieldIf,
+		AddFieldIf: &api.TransformFilterRuleWithAssignee{
+			Input:      "FlowDirection",
+			Output:     "network.direction",
+			Parameters: "== 1",
+			Assignee:   "egress",
+		},
+	})
+	assert.Contains(t, transforms.Constants.Rules, api.TransformFilterRule{
+		Type:     api.AddField,
+		AddField: &api.TransformFilterGenericRule{Input: "event.category", Value: "network"},
+	})
+}
@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// NewUnstructured returns an object of an API that isn't part of the operator scheme, such as third-party CRDs
+func NewUnstructured(gvk schema.GroupVersionKind, name, namespace string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return &obj
+}
+
+// ReconcileUnstructured creates the desired object, or updates it if owned and its spec differs
+func (c *Client) ReconcileUnstructured(ctx context.Context, desired *unstructured.Unstructured) error {
+	current := unstructured.Unstructured{}
+	current.SetGroupVersionKind(desired.GroupVersionKind())
+	err := c.Get(ctx, types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, &current)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return c.CreateOwned(ctx, desired)
+		}
+		return err
+	}
+	if equality.Semantic.DeepDerivative(desired.Object["spec"], current.Object["spec"]) &&
+		equality.Semantic.DeepDerivative(desired.GetLabels(), current.GetLabels()) {
+		return nil
+	}
+	return c.UpdateIfOwned(ctx, &current, desired)
+}
+
+// DeleteUnstructuredIfOwned deletes the object if it exists and is owned by the operator
+func (c *Client) DeleteUnstructuredIfOwned(ctx context.Context, gvk schema.GroupVersionKind, name, namespace string) error {
+	current := unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &current)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return c.DeleteIfOwned(ctx, &current)
+}
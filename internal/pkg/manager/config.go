@@ -18,6 +18,8 @@ type Config struct {
 	ConsolePluginCompatImage string
 	// EBPFByteCodeImage is the ebpf byte code image used by EBPF Manager
 	EBPFByteCodeImage string
+	// DiagnosticImage is the image of the client and server pods deployed by FlowCollectorDiagnostic
+	DiagnosticImage string
 	// Default namespace
 	Namespace string
 	// Release kind is either upstream or downstream
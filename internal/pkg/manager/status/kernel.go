@@ -0,0 +1,51 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func checkAgentKernel(fc *flowslatest.FlowCollector) metav1.Condition {
+	if flowslatest.CurrentClusterInfo == nil {
+		return metav1.Condition{
+			Type:    AgentKernelIssue,
+			Reason:  "Unknown",
+			Status:  metav1.ConditionUnknown,
+			Message: "Cluster info not available",
+		}
+	}
+	nodes, err := flowslatest.CurrentClusterInfo.GetNodesKernelInfo()
+	if err != nil {
+		return metav1.Condition{
+			Type:    AgentKernelIssue,
+			Reason:  "Unknown",
+			Status:  metav1.ConditionUnknown,
+			Message: err.Error(),
+		}
+	}
+	incompats := fc.Spec.Agent.EBPF.GetKernelIncompatibilities(nodes)
+	if len(incompats) == 0 {
+		return metav1.Condition{
+			Type:   AgentKernelIssue,
+			Reason: "NoIssue",
+			Status: metav1.ConditionFalse,
+		}
+	}
+	var issues []string
+	for i := range incompats {
+		issues = append(issues, fmt.Sprintf("%s not supported on: %s", incompats[i].FeatureName(), incompats[i].NodesSummary()))
+	}
+	reason := "IncompatibleNodes"
+	if fc.Spec.Agent.EBPF.UnsupportedFeaturesPolicy == flowslatest.UnsupportedFeaturesDisablePerNode {
+		reason = "DisabledOnIncompatibleNodes"
+	}
+	return metav1.Condition{
+		Type:    AgentKernelIssue,
+		Reason:  reason,
+		Status:  metav1.ConditionTrue,
+		Message: strings.Join(issues, "; "),
+	}
+}
@@ -0,0 +1,40 @@
+package status
+
+import (
+	"testing"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/pkg/cluster"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckAgentKernel(t *testing.T) {
+	info := cluster.Info{}
+	info.Mock("", "")
+	info.MockNodesKernelInfo([]flowslatest.NodeKernelInfo{
+		{Name: "node-1", KernelVersion: "6.12.0"},
+		{Name: "node-2", KernelVersion: "5.15.0-105-generic"},
+	})
+	flowslatest.CurrentClusterInfo = &info
+	defer func() { flowslatest.CurrentClusterInfo = nil }()
+
+	fc := flowslatest.FlowCollector{}
+	condition := checkAgentKernel(&fc)
+	assert.Equal(t, AgentKernelIssue, condition.Type)
+	assert.Equal(t, "IncompatibleNodes", condition.Reason)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "TCX attachment mode not supported on: node-2", condition.Message)
+
+	fc.Spec.Agent.EBPF.Features = []flowslatest.AgentFeature{flowslatest.NetworkEvents}
+	fc.Spec.Agent.EBPF.UnsupportedFeaturesPolicy = flowslatest.UnsupportedFeaturesDisablePerNode
+	condition = checkAgentKernel(&fc)
+	assert.Equal(t, "DisabledOnIncompatibleNodes", condition.Reason)
+	assert.Equal(t, "NetworkEvents feature not supported on: node-2; TCX attachment mode not supported on: node-2", condition.Message)
+
+	fc.Spec.Agent.EBPF.Features = nil
+	fc.Spec.Agent.EBPF.Advanced = &flowslatest.AdvancedAgentConfig{Env: map[string]string{"TC_ATTACH_MODE": "tc"}}
+	condition = checkAgentKernel(&fc)
+	assert.Equal(t, "NoIssue", condition.Reason)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+}
@@ -39,6 +39,14 @@ func checkLoki(ctx context.Context, c client.Client, fc *flowslatest.FlowCollect
 	err := c.Get(ctx, nsname, lokiStack)
 	if err != nil {
 		if kerr.IsNotFound(err) {
+			if fc.Spec.UseLokiStackProvisioning() {
+				return metav1.Condition{
+					Type:    LokiIssue,
+					Reason:  "LokiStackProvisioning",
+					Status:  metav1.ConditionTrue,
+					Message: fmt.Sprintf("The LokiStack is being provisioned [name: %s, namespace: %s]", nsname.Name, nsname.Namespace),
+				}
+			}
 			return metav1.Condition{
 				Type:    LokiIssue,
 				Reason:  "LokiStackNotFound",
@@ -96,6 +96,33 @@ func TestCheckLoki_LokiStackNotFound(t *testing.T) {
 	assert.Contains(t, condition.Message, "netobserv")
 }
 
+func TestCheckLoki_LokiStackProvisioning(t *testing.T) {
+	fc := &flowslatest.FlowCollector{
+		Spec: flowslatest.FlowCollectorSpec{
+			Namespace: "netobserv",
+			Loki: flowslatest.FlowCollectorLoki{
+				Enable: ptr(true),
+				Mode:   flowslatest.LokiModeLokiStack,
+				LokiStack: flowslatest.LokiStackRef{
+					Name:         "loki",
+					Provisioning: &flowslatest.LokiStackProvisioning{Enable: true},
+				},
+			},
+		},
+	}
+
+	client := &mockClient{}
+	nsname := types.NamespacedName{Name: "loki", Namespace: "netobserv"}
+	client.On("Get", mock.Anything, nsname, mock.Anything, mock.Anything).
+		Return(kerr.NewNotFound(schema.GroupResource{}, "loki"))
+
+	condition := checkLoki(context.Background(), client, fc)
+
+	assert.Equal(t, "LokiStackProvisioning", condition.Reason)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "being provisioned")
+}
+
 func TestCheckLoki_LokiStackNotReady(t *testing.T) {
 	lokiStack := &lokiv1.LokiStack{
 		ObjectMeta: metav1.ObjectMeta{
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
@@ -31,12 +32,19 @@ const (
 	ConditionConfigurationIssue               = "ConfigurationIssue"
 	LokiIssue                                 = "LokiIssue"
 	LokiWarning                               = "LokiWarning"
+	AgentKernelIssue                          = "AgentKernelIssue"
+	AgentConfiguredAttachment                 = "AgentConfiguredAttachment"
+	EbpfManagerIssue                          = "EbpfManagerIssue"
 )
 
 var allNames = []ComponentName{FlowCollectorLegacy, Monitoring, StaticController}
 
 type Manager struct {
 	statuses sync.Map
+	// conditions holds additional conditions reported by components, keyed by type
+	conditions sync.Map
+	// agentStatus holds the latest per-node agents health summary
+	agentStatus atomic.Pointer[flowslatest.FlowCollectorAgentStatus]
 }
 
 func NewManager() *Manager {
@@ -96,6 +104,15 @@ func (s *Manager) setUnused(cpnt ComponentName, message string) {
 	})
 }
 
+func (s *Manager) setCondition(cond metav1.Condition) {
+	s.conditions.Store(cond.Type, cond)
+}
+
+// SetAgentStatus stores the agents health summary to report in FlowCollector status, or removes it when nil
+func (s *Manager) SetAgentStatus(st *flowslatest.FlowCollectorAgentStatus) {
+	s.agentStatus.Store(st)
+}
+
 func (s *Manager) getConditions() []metav1.Condition {
 	global := metav1.Condition{
 		Type:   "Ready",
@@ -110,6 +127,10 @@ func (s *Manager) getConditions() []metav1.Condition {
 		counters[status.status]++
 		return true
 	})
+	s.conditions.Range(func(_, v any) bool {
+		conds = append(conds, v.(metav1.Condition))
+		return true
+	})
 	global.Message = fmt.Sprintf("%d ready components, %d with failure, %d pending", counters[StatusReady], counters[StatusFailure], counters[StatusInProgress])
 	if counters[StatusFailure] > 0 {
 		global.Status = metav1.ConditionFalse
@@ -122,10 +143,10 @@ func (s *Manager) getConditions() []metav1.Condition {
 }
 
 func (s *Manager) Sync(ctx context.Context, c client.Client) {
-	updateStatus(ctx, c, s.getConditions()...)
+	updateStatus(ctx, c, s.agentStatus.Load(), s.getConditions()...)
 }
 
-func updateStatus(ctx context.Context, c client.Client, conditions ...metav1.Condition) {
+func updateStatus(ctx context.Context, c client.Client, agentStatus *flowslatest.FlowCollectorAgentStatus, conditions ...metav1.Condition) {
 	log := log.FromContext(ctx)
 	log.Info("Updating FlowCollector status")
 
@@ -141,9 +162,11 @@ func updateStatus(ctx context.Context, c client.Client, conditions ...metav1.Con
 		conditions = append(conditions, checkValidation(ctx, &fc))
 		conditions = append(conditions, checkLoki(ctx, c, &fc))
 		conditions = append(conditions, checkLokiWarnings(ctx, c, &fc))
+		conditions = append(conditions, checkAgentKernel(&fc))
 		for _, c := range conditions {
 			meta.SetStatusCondition(&fc.Status.Conditions, c)
 		}
+		fc.Status.Agent = agentStatus
 		return c.Status().Update(ctx, &fc)
 	})
 
@@ -234,6 +257,15 @@ func (i *Instance) SetCreatingDaemonSet(ds *appsv1.DaemonSet) {
 	i.s.setInProgress(i.cpnt, "CreatingDaemonSet", fmt.Sprintf("Creating daemon set %s", ds.Name))
 }
 
+// SetCondition reports an additional condition, which is kept until replaced
+func (i *Instance) SetCondition(cond metav1.Condition) {
+	i.s.setCondition(cond)
+}
+
+func (i *Instance) SetInProgress(reason, message string) {
+	i.s.setInProgress(i.cpnt, reason, message)
+}
+
 func (i *Instance) SetFailure(reason, message string) {
 	i.s.setFailure(i.cpnt, reason, message)
 }
@@ -3,6 +3,7 @@ package alerts
 import (
 	"context"
 	"slices"
+	"strings"
 	"testing"
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
@@ -14,6 +15,10 @@ func allTemplates() []flowslatest.HealthRuleTemplate {
 	return []flowslatest.HealthRuleTemplate{
 		flowslatest.AlertLokiError,
 		flowslatest.AlertNoFlows,
+		flowslatest.AlertKafkaConsumerLag,
+		flowslatest.AlertFLPMemoryHigh,
+		flowslatest.AlertEncodeErrors,
+		flowslatest.AlertExporterErrors,
 		flowslatest.HealthRulePacketDropsByKernel,
 		flowslatest.HealthRulePacketDropsByDevice,
 		flowslatest.HealthRuleDNSErrors,
@@ -25,6 +30,8 @@ func allTemplates() []flowslatest.HealthRuleTemplate {
 		flowslatest.HealthRuleExternalIngressHighTrend,
 		flowslatest.HealthRuleIngress5xxErrors,
 		flowslatest.HealthRuleIngressHTTPLatencyTrend,
+		flowslatest.HealthRuleTCPResetsHigh,
+		flowslatest.HealthRuleSYNWithoutACK,
 	}
 }
 
@@ -44,6 +51,8 @@ func TestBuildRules_DefaultWithDisabled(t *testing.T) {
 			Metrics: flowslatest.FLPMetrics{
 				DisableAlerts: []flowslatest.AlertTemplate{
 					flowslatest.AlertLokiError,
+					flowslatest.AlertFLPMemoryHigh,
+					flowslatest.AlertEncodeErrors,
 					flowslatest.HealthRulePacketDropsByDevice,
 					flowslatest.HealthRuleExternalEgressHighTrend,
 					flowslatest.HealthRuleExternalIngressHighTrend,
@@ -53,7 +62,7 @@ func TestBuildRules_DefaultWithDisabled(t *testing.T) {
 			},
 		},
 	}
-	rules := BuildMonitoringRules(context.Background(), &fc)
+	rules := BuildMonitoringRules(context.Background(), &fc, nil)
 	assert.Len(t, rules, 1)
 	assert.Contains(t, rules[0].Annotations["description"], "NetObserv flowlogs-pipeline is not receiving any flow")
 }
@@ -94,7 +103,7 @@ func TestBuildRules_DefaultWithFeaturesAndDisabled(t *testing.T) {
 			},
 		},
 	}
-	rules := BuildMonitoringRules(context.Background(), &fc)
+	rules := BuildMonitoringRules(context.Background(), &fc, nil)
 	assert.Equal(t, []string{
 		"netobserv:health:packet_drops_kernel:namespace:src:rate2m",
 		"netobserv:health:packet_drops_kernel:namespace:dst:rate2m",
@@ -115,7 +124,11 @@ func TestBuildRules_DefaultWithFeaturesAndDisabled(t *testing.T) {
 		"netobserv:health:tcp_latency_increase_p90:namespace:dst:rate2m",
 		"netobserv:health:ingress_5xx_errors:namespace:src:rate2m",
 		"netobserv:health:ingress_http_latency_increase_avg:namespace:src:rate2m",
+		"netobserv:health:score:node",
+		"netobserv:health:score:namespace",
 		"NetObservNoFlows",
+		"NetObservFLPMemoryHigh",
+		"NetObservMetricsEncodeErrors",
 	}, allNames(rules))
 	r := findRule("PacketDropsByDevice_PerNodeWarning", rules)
 	assert.NotNil(t, r)
@@ -157,7 +170,7 @@ func TestBuildRules_DefaultWithFeaturesAndAllDisabled(t *testing.T) {
 			},
 		},
 	}
-	rules := BuildMonitoringRules(context.Background(), &fc)
+	rules := BuildMonitoringRules(context.Background(), &fc, nil)
 	assert.Empty(t, rules)
 }
 
@@ -188,10 +201,11 @@ func TestBuildRules_Overidden(t *testing.T) {
 			},
 		},
 	}
-	rules := BuildMonitoringRules(context.Background(), &fc)
-	assert.Len(t, rules, 2)
+	rules := BuildMonitoringRules(context.Background(), &fc, nil)
+	assert.Len(t, rules, 3)
 	assert.Contains(t, rules[0].Annotations["description"], "NetObserv is detecting more than 50% of packets dropped by the kernel [source workload={{ $labels.workload }} ({{ $labels.kind }})]")
 	assert.Contains(t, rules[1].Annotations["description"], "NetObserv is detecting more than 50% of packets dropped by the kernel [dest. workload={{ $labels.workload }} ({{ $labels.kind }})]")
+	assert.Equal(t, "netobserv:health:score:workload", rules[2].Record)
 }
 
 func TestBuildRules_Global(t *testing.T) {
@@ -220,7 +234,7 @@ func TestBuildRules_Global(t *testing.T) {
 			},
 		},
 	}
-	rules := BuildMonitoringRules(context.Background(), &fc)
+	rules := BuildMonitoringRules(context.Background(), &fc, nil)
 	assert.Len(t, rules, 1)
 	assert.Contains(t, rules[0].Annotations["description"], "NetObserv is detecting more than 50% of packets dropped by the kernel.")
 	assert.Equal(t, "100 * (sum(rate(netobserv_namespace_drop_packets_total[2m]))) / (sum(rate(netobserv_namespace_ingress_packets_total[2m]))) > 50", rules[0].Expr.StrVal)
@@ -253,7 +267,7 @@ func TestBuildRules_DisableTakesPrecedence(t *testing.T) {
 			},
 		},
 	}
-	rules := BuildMonitoringRules(context.Background(), &fc)
+	rules := BuildMonitoringRules(context.Background(), &fc, nil)
 	assert.Empty(t, rules)
 }
 
@@ -264,7 +278,7 @@ func TestLatencyPromql(t *testing.T) {
 			Info: "100",
 		},
 	}
-	rules, err := buildHealthRulesForVariant(flowslatest.HealthRuleLatencyHighTrend, flowslatest.ModeAlert, &variant, []string{"namespace_rtt_seconds"})
+	rules, err := buildHealthRulesForVariant(flowslatest.HealthRuleLatencyHighTrend, flowslatest.ModeAlert, &variant, namespaceScope{}, []string{"namespace_rtt_seconds"})
 	assert.NoError(t, err)
 	assert.Len(t, rules, 2)
 	anns, err := rules[0].GetAnnotations()
@@ -289,6 +303,62 @@ func TestLatencyPromql(t *testing.T) {
 	)
 }
 
+func TestTCPResetsPromql(t *testing.T) {
+	variant := flowslatest.HealthRuleVariant{
+		GroupBy: flowslatest.GroupByNamespace,
+		Thresholds: flowslatest.HealthRuleThresholds{
+			Warning: "10",
+		},
+	}
+	rules, err := buildHealthRulesForVariant(flowslatest.HealthRuleTCPResetsHigh, flowslatest.ModeAlert, &variant, namespaceScope{}, []string{"namespace_tcp_flags_total"})
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	anns, err := rules[1].GetAnnotations()
+	assert.NoError(t, err)
+	assert.Contains(t, anns["description"], "NetObserv is detecting more than 10% of TCP resets among the TCP flags observed in flows [dest. namespace={{ $labels.namespace }}].")
+	assert.Equal(t,
+		`{"alertThreshold":"10","unit":"%","namespaceLabels":["namespace"],"trafficLink":{"extraFilter":"tcp_flags=\"RST,RST_ACK\"","backAndForth":true,"filterDestination":true}}`,
+		anns["netobserv_io_network_health"],
+	)
+
+	mr, err := rules[1].Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "TCPResetsHigh_PerDstNamespaceWarning", mr.Alert)
+	assert.Equal(t,
+		`100 * (sum(label_replace(rate(netobserv_namespace_tcp_flags_total{DstK8S_Namespace!="",Flags=~"RST|RST_ACK"}[2m]), "namespace", "$1", "DstK8S_Namespace", "(.*)")) by (namespace))`+
+			// Same flattened metric in the total: flows having several flags are counted once per flag on both sides
+			` / (sum(label_replace(rate(netobserv_namespace_tcp_flags_total{DstK8S_Namespace!=""}[2m]), "namespace", "$1", "DstK8S_Namespace", "(.*)")) by (namespace))`+
+			` > 10`,
+		mr.Expr.StrVal,
+	)
+}
+
+func TestSYNWithoutACKPromql(t *testing.T) {
+	variant := flowslatest.HealthRuleVariant{
+		GroupBy: flowslatest.GroupByNode,
+		Thresholds: flowslatest.HealthRuleThresholds{
+			Info:    "10",
+			Warning: "20",
+		},
+		LowVolumeThreshold: "1",
+	}
+	rules, err := buildHealthRulesForVariant(flowslatest.HealthRuleSYNWithoutACK, flowslatest.ModeRecording, &variant, namespaceScope{}, []string{"node_tcp_flags_total"})
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "netobserv:health:syn_without_ack:node:dst:rate2m", rules[1].RecordingName())
+
+	mr, err := rules[1].Build()
+	assert.NoError(t, err)
+	// The pattern is:
+	// 100 * (<SYN received> - <SYN-ACK sent>) / <SYN received>
+	synReceived := `sum(label_replace(rate(netobserv_node_tcp_flags_total{DstK8S_HostName!="",Flags="SYN"}[2m]), "node", "$1", "DstK8S_HostName", "(.*)")) by (node)`
+	synAckSent := `sum(label_replace(rate(netobserv_node_tcp_flags_total{SrcK8S_HostName!="",Flags="SYN_ACK"}[2m]), "node", "$1", "SrcK8S_HostName", "(.*)")) by (node)`
+	assert.Equal(t,
+		`100 * (clamp_min(`+synReceived+` - (`+synAckSent+` or 0 * `+synReceived+`), 0)) / (`+synReceived+` > 1)`,
+		mr.Expr.StrVal,
+	)
+}
+
 func TestAllAlertsHaveRunbookURL(t *testing.T) {
 	// Create a FlowCollector with all features enabled
 	fc := flowslatest.FlowCollectorSpec{
@@ -304,19 +374,93 @@ func TestAllAlertsHaveRunbookURL(t *testing.T) {
 				},
 			},
 		},
+		DeploymentModel: flowslatest.DeploymentModelKafka,
 		Processor: flowslatest.FlowCollectorFLP{
 			Metrics: flowslatest.FLPMetrics{},
 		},
 	}
 
-	rules := BuildMonitoringRules(context.Background(), &fc)
+	rules := BuildMonitoringRules(context.Background(), &fc, nil)
+	assert.Contains(t, allNames(rules), string(flowslatest.AlertKafkaConsumerLag))
 
 	// Verify all rules have a runbook_url annotation
 	for _, rule := range rules {
 		if rule.Alert != "" {
 			url := rule.Annotations["runbook_url"]
-			assert.Contains(t, url, runbookURLBase+"/", "Alert %s has invalid runbook_url: %s", rule.Alert, url)
+			assert.True(t,
+				strings.HasPrefix(url, runbookURLBase+"/") || strings.HasPrefix(url, netobservRunbookURLBase+"/"),
+				"Alert %s has invalid runbook_url: %s", rule.Alert, url,
+			)
 			assert.Contains(t, url, ".md", "Alert %s runbook_url doesn't end with .md: %s", rule.Alert, url)
 		}
 	}
 }
+
+func TestOperationalAlerts(t *testing.T) {
+	fc := flowslatest.FlowCollectorSpec{
+		Namespace:       "netobserv",
+		DeploymentModel: flowslatest.DeploymentModelKafka,
+		Kafka:           flowslatest.FlowCollectorKafka{Topic: "network-flows"},
+		Processor: flowslatest.FlowCollectorFLP{
+			Metrics: flowslatest.FLPMetrics{
+				DisableAlerts: allTemplatesBut(flowslatest.OperationalAlerts...),
+			},
+		},
+	}
+	rules := BuildMonitoringRules(context.Background(), &fc, nil)
+	assert.Equal(t, []string{
+		"NetObservNoFlows",
+		"NetObservLokiError",
+		"NetObservKafkaConsumerLag",
+		"NetObservFLPMemoryHigh",
+		"NetObservMetricsEncodeErrors",
+	}, allNames(rules))
+	assert.Equal(t, `sum(delta(kafka_consumergroup_lag{consumergroup="flowlogs-pipeline",topic="network-flows"}[10m])) > 0`, rules[2].Expr.StrVal)
+	assert.Equal(t, "https://github.com/netobserv/network-observability-operator/blob/main/docs/runbooks/NetObservKafkaConsumerLag.md", rules[2].Annotations["runbook_url"])
+	assert.Equal(t,
+		`max by (pod) (max by (namespace,pod,container) (container_memory_working_set_bytes{namespace="netobserv",container="flowlogs-pipeline"})`+
+			` / max by (namespace,pod,container) (kube_pod_container_resource_limits{namespace="netobserv",container="flowlogs-pipeline",resource="memory"})) > 0.9`,
+		rules[3].Expr.StrVal,
+	)
+	for _, r := range rules {
+		assert.Equal(t, "warning", r.Labels["severity"])
+		assert.Empty(t, r.Labels["netobserv"], "operational alerts are not health rules")
+	}
+
+	// Kafka alert is only for the Kafka deployment model; each alert can be disabled
+	fc.DeploymentModel = flowslatest.DeploymentModelDirect
+	fc.Processor.Metrics.DisableAlerts = append(fc.Processor.Metrics.DisableAlerts, flowslatest.AlertLokiError, flowslatest.AlertFLPMemoryHigh)
+	rules = BuildMonitoringRules(context.Background(), &fc, nil)
+	assert.Equal(t, []string{"NetObservNoFlows", "NetObservMetricsEncodeErrors"}, allNames(rules))
+}
+
+func TestExporterErrorsAlert(t *testing.T) {
+	fc := flowslatest.FlowCollectorSpec{
+		Processor: flowslatest.FlowCollectorFLP{
+			Metrics: flowslatest.FLPMetrics{
+				DisableAlerts: allTemplatesBut(flowslatest.AlertExporterErrors),
+			},
+		},
+	}
+	// No exporter, no alert
+	assert.Empty(t, BuildMonitoringRules(context.Background(), &fc, nil))
+
+	fc.Exporters = []*flowslatest.FlowCollectorExporter{
+		{Type: flowslatest.IpfixExporter},
+		{Type: flowslatest.KafkaExporter},
+		{Type: flowslatest.OpenTelemetryExporter},
+	}
+	rules := BuildMonitoringRules(context.Background(), &fc, nil)
+	assert.Equal(t, []string{"NetObservFLPExporterErrors"}, allNames(rules))
+	assert.Equal(t,
+		`(sum by (stage) (rate(netobserv_records_written{stage=~"kafka-export-1"}[5m])) == 0 and on() sum(rate(netobserv_ingest_flows_processed[5m])) > 0)`+
+			` or max by (stage) (min_over_time(netobserv_stage_in_queue_size{stage=~"IPFIX-export-0|kafka-export-1|Otel-export-2"}[10m])) > 0`,
+		rules[0].Expr.StrVal,
+	)
+	assert.Equal(t, "https://github.com/netobserv/network-observability-operator/blob/main/docs/runbooks/NetObservFLPExporterErrors.md", rules[0].Annotations["runbook_url"])
+
+	// Without Kafka exporter, only the queue is monitored
+	fc.Exporters = fc.Exporters[:1]
+	rules = BuildMonitoringRules(context.Background(), &fc, nil)
+	assert.Equal(t, `max by (stage) (min_over_time(netobserv_stage_in_queue_size{stage=~"IPFIX-export-0"}[10m])) > 0`, rules[0].Expr.StrVal)
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -17,6 +19,8 @@ import (
 const (
 	healthAnnotationKey = "netobserv_io_network_health"
 	runbookURLBase      = "https://github.com/openshift/runbooks/blob/master/alerts/network-observability-operator"
+	// Runbooks for the operational alerts not yet published in the OpenShift runbooks
+	netobservRunbookURLBase = "https://github.com/netobserv/network-observability-operator/blob/main/docs/runbooks"
 )
 
 type HealthRule interface {
@@ -31,11 +35,12 @@ func encodeHealthAnnotation(hann *healthAnnotation) string {
 	return string(bAnnot)
 }
 
-func BuildMonitoringRules(ctx context.Context, fc *flowslatest.FlowCollectorSpec) []monitoringv1.Rule {
+// BuildMonitoringRules returns all the rules managed by NetObserv: health rules, including the overrides from FlowCollectorSlices, health scores and operational alerts
+func BuildMonitoringRules(ctx context.Context, fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice) []monitoringv1.Rule {
 	log := log.FromContext(ctx)
 	rules := []monitoringv1.Rule{}
 
-	healthRules, err := BuildHealthRules(fc)
+	healthRules, err := BuildHealthRules(fc, fcSlices)
 	if err != nil {
 		log.Error(err, "Can't build some health rules")
 		// do not return: other rules might have been created
@@ -48,41 +53,110 @@ func BuildMonitoringRules(ctx context.Context, fc *flowslatest.FlowCollectorSpec
 		}
 	}
 
-	if !slices.Contains(fc.Processor.Metrics.DisableAlerts, flowslatest.AlertNoFlows) {
-		r := alertNoFlows()
-		rules = append(rules, *r)
+	for _, hs := range BuildHealthScoreRules(fc, fcSlices) {
+		if mr, err := hs.Build(); err != nil {
+			log.Error(err, "Can't build a health score rule")
+		} else if mr != nil {
+			rules = append(rules, *mr)
+		}
 	}
-	if !slices.Contains(fc.Processor.Metrics.DisableAlerts, flowslatest.AlertLokiError) {
-		r := alertLokiError()
-		rules = append(rules, *r)
+
+	for _, template := range flowslatest.OperationalAlerts {
+		if slices.Contains(fc.Processor.Metrics.DisableAlerts, template) {
+			continue
+		}
+		if r := buildOperationalAlert(template, fc); r != nil {
+			rules = append(rules, *r)
+		}
 	}
 
 	return rules
 }
 
-func BuildHealthRules(fc *flowslatest.FlowCollectorSpec) ([]HealthRule, error) {
+// BuildHealthRules returns the health rules of the FlowCollector. The health rules overridden in FlowCollectorSlices
+// are split per namespace: each slice namespace gets its own rules, and is excluded from the FlowCollector ones.
+func BuildHealthRules(fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice) ([]HealthRule, error) {
 	var rules []HealthRule
 	var errs []error
-	healthRules := fc.GetFLPHealthRules()
 	metrics := fc.GetIncludeList()
-	for _, healthRule := range healthRules {
-		if ok, _ := healthRule.IsAllowed(fc); !ok {
+	for _, v := range healthRuleVariants(fc, fcSlices) {
+		if r, err := buildHealthRulesForVariant(v.template, v.mode, v.variant, v.namespaces, metrics); err != nil {
+			errs = append(errs, err)
+		} else if len(r) > 0 {
+			r = withMaintenanceInhibition(r, v.template, v.variant.GroupBy, fc.Processor.Metrics.MaintenanceWindows)
+			rules = append(rules, r...)
+		}
+	}
+	return rules, errors.Join(errs...)
+}
+
+// BuildHealthRecordingAnnotations returns the annotations of the health recording rules and health scores, per recording name, for the console plugin.
+// The recording rules overridden in FlowCollectorSlices share their name with the FlowCollector ones: their thresholds are merged per namespace.
+func BuildHealthRecordingAnnotations(fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice) map[string]map[string]string {
+	annotsPerRecording := make(map[string]map[string]string)
+	healthRules, _ := BuildHealthRules(fc, fcSlices)
+	healthRules = append(healthRules, BuildHealthScoreRules(fc, fcSlices)...)
+	for _, r := range healthRules {
+		rname := r.RecordingName()
+		if rname == "" {
 			continue
 		}
-		for _, variant := range healthRule.Variants {
-			// Get effective mode: variant.Mode if specified, otherwise healthRule.Mode
-			effectiveMode := variant.GetMode(healthRule.Mode)
-			if r, err := buildHealthRulesForVariant(healthRule.Template, effectiveMode, &variant, metrics); err != nil {
-				errs = append(errs, err)
-			} else if len(r) > 0 {
-				rules = append(rules, r...)
-			}
+		a, _ := r.GetAnnotations()
+		if len(a) == 0 {
+			continue
+		}
+		if existing, ok := annotsPerRecording[rname]; ok {
+			a = mergeRecordingAnnotations(existing, a)
 		}
+		annotsPerRecording[rname] = a
 	}
-	return rules, errors.Join(errs...)
+	return annotsPerRecording
+}
+
+// mergeRecordingAnnotations merges the namespace thresholds of two annotations of the same recording rule.
+// The annotation of the FlowCollector rule, without namespace thresholds, is kept as the base.
+func mergeRecordingAnnotations(a, b map[string]string) map[string]string {
+	var hA, hB healthAnnotation
+	if json.Unmarshal([]byte(a[healthAnnotationKey]), &hA) != nil || json.Unmarshal([]byte(b[healthAnnotationKey]), &hB) != nil {
+		return a
+	}
+	base, hBase, other := a, &hA, &hB
+	if len(hA.NamespaceThresholds) > 0 && len(hB.NamespaceThresholds) == 0 {
+		base, hBase, other = b, &hB, &hA
+	}
+	if hBase.NamespaceThresholds == nil {
+		hBase.NamespaceThresholds = make(map[string]*recordingThresholds)
+	}
+	maps.Copy(hBase.NamespaceThresholds, other.NamespaceThresholds)
+	merged := maps.Clone(base)
+	merged[healthAnnotationKey] = encodeHealthAnnotation(hBase)
+	return merged
+}
+
+// healthRuleContexts returns the contexts of all the active health rules
+func healthRuleContexts(fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice) []ruleContext {
+	var contexts []ruleContext
+	metrics := fc.GetIncludeList()
+	for _, v := range healthRuleVariants(fc, fcSlices) {
+		contexts = append(contexts, buildRuleContextsForVariant(v.template, v.mode, v.variant, v.namespaces, metrics)...)
+	}
+	return contexts
 }
 
-func buildHealthRulesForVariant(template flowslatest.HealthRuleTemplate, mode flowslatest.HealthRuleMode, healthRule *flowslatest.HealthRuleVariant, enabledMetrics []string) ([]HealthRule, error) {
+func buildHealthRulesForVariant(template flowslatest.HealthRuleTemplate, mode flowslatest.HealthRuleMode, healthRule *flowslatest.HealthRuleVariant, namespaces namespaceScope, enabledMetrics []string) ([]HealthRule, error) {
+	allContexts := buildRuleContextsForVariant(template, mode, healthRule, namespaces, enabledMetrics)
+	var rules []HealthRule
+	for i := range allContexts {
+		if r := allContexts[i].toRule(); r != nil {
+			rules = append(rules, r)
+		} else {
+			return nil, fmt.Errorf("no rule for template: %s", allContexts[i].template)
+		}
+	}
+	return rules, nil
+}
+
+func buildRuleContextsForVariant(template flowslatest.HealthRuleTemplate, mode flowslatest.HealthRuleMode, healthRule *flowslatest.HealthRuleVariant, namespaces namespaceScope, enabledMetrics []string) []ruleContext {
 	var allContexts []ruleContext
 	var upperThreshold string
 	sides := []srcOrDst{asSource, asDest}
@@ -100,6 +174,7 @@ func buildHealthRulesForVariant(template flowslatest.HealthRuleTemplate, mode fl
 				healthRule:     healthRule,
 				mode:           mode,
 				enabledMetrics: enabledMetrics,
+				namespaces:     namespaces,
 				side:           side,
 				recordingThresholds: &recordingThresholds{
 					Info:     healthRule.Thresholds.Info,
@@ -126,6 +201,7 @@ func buildHealthRulesForVariant(template flowslatest.HealthRuleTemplate, mode fl
 						healthRule:     healthRule,
 						mode:           mode,
 						enabledMetrics: enabledMetrics,
+						namespaces:     namespaces,
 						side:           side,
 						severity:       st.s,
 						alertThreshold: st.t,
@@ -137,15 +213,7 @@ func buildHealthRulesForVariant(template flowslatest.HealthRuleTemplate, mode fl
 			}
 		}
 	}
-	var rules []HealthRule
-	for i := range allContexts {
-		if r := allContexts[i].toRule(); r != nil {
-			rules = append(rules, r)
-		} else {
-			return nil, fmt.Errorf("no rule for template: %s", allContexts[i].template)
-		}
-	}
-	return rules, nil
+	return allContexts
 }
 
 func (ctx *ruleContext) toRule() HealthRule {
@@ -172,8 +240,12 @@ func (ctx *ruleContext) toRule() HealthRule {
 		return newIngressErrors(ctx)
 	case flowslatest.HealthRuleIngressHTTPLatencyTrend:
 		return newIngressHTTPLatencyTrend(ctx)
-	case flowslatest.AlertLokiError, flowslatest.AlertNoFlows:
-		// ?
+	case flowslatest.HealthRuleTCPResetsHigh:
+		return newTCPResets(ctx)
+	case flowslatest.HealthRuleSYNWithoutACK:
+		return newSYNWithoutACK(ctx)
+	case flowslatest.AlertLokiError, flowslatest.AlertNoFlows, flowslatest.AlertKafkaConsumerLag, flowslatest.AlertFLPMemoryHigh, flowslatest.AlertEncodeErrors, flowslatest.AlertExporterErrors:
+		// operational alerts, see buildOperationalAlert
 	}
 	return nil
 }
@@ -199,6 +271,19 @@ func buildRunbookURL(template flowslatest.AlertTemplate) string {
 	return fmt.Sprintf("%s/%s.md", runbookURLBase, template)
 }
 
+// BuildNetObservRunbookURL constructs the URL of a runbook documented in the NetObserv repository
+func BuildNetObservRunbookURL(alert string) string {
+	return fmt.Sprintf("%s/%s.md", netobservRunbookURLBase, alert)
+}
+
+func alertName(ctx *ruleContext) string {
+	var gr string
+	if ctx.healthRule.GroupBy != "" {
+		gr = "Per" + string(ctx.side) + string(ctx.healthRule.GroupBy)
+	}
+	return fmt.Sprintf("%s_%s%s", ctx.template, gr, strings.ToUpper(ctx.severity[:1])+ctx.severity[1:])
+}
+
 func createRule(ctx *ruleContext, r HealthRule, promQL string) (*monitoringv1.Rule, error) {
 	// Generate recording rule
 	if ctx.mode == flowslatest.ModeRecording {
@@ -216,12 +301,8 @@ func createRule(ctx *ruleContext, r HealthRule, promQL string) (*monitoringv1.Ru
 		return nil, err
 	}
 
-	var gr string
-	if ctx.healthRule.GroupBy != "" {
-		gr = "Per" + string(ctx.side) + string(ctx.healthRule.GroupBy)
-	}
 	return &monitoringv1.Rule{
-		Alert:       fmt.Sprintf("%s_%s%s", ctx.template, gr, strings.ToUpper(ctx.severity[:1])+ctx.severity[1:]),
+		Alert:       alertName(ctx),
 		Annotations: ann,
 		Expr:        intstr.FromString(promQL),
 		For:         &ctx.duration,
@@ -2,6 +2,9 @@ package alerts
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
@@ -347,3 +350,21 @@ func TestRunbookURLsExist(t *testing.T) {
 		})
 	}
 }
+
+func TestNetObservRunbooksExist(t *testing.T) {
+	alerts := []string{
+		string(flowslatest.AlertKafkaConsumerLag),
+		string(flowslatest.AlertFLPMemoryHigh),
+		string(flowslatest.AlertEncodeErrors),
+		string(flowslatest.AlertExporterErrors),
+		string(flowslatest.AlertAgentMapFull),
+		string(flowslatest.AlertAgentExporterErrors),
+		string(flowslatest.AlertAgentNoFlows),
+	}
+	for _, alert := range alerts {
+		url := BuildNetObservRunbookURL(alert)
+		path := filepath.Join("../../../../docs/runbooks", strings.TrimPrefix(url, netobservRunbookURLBase+"/"))
+		_, err := os.Stat(path)
+		assert.NoError(t, err, "Missing runbook for %s: %s", alert, path)
+	}
+}
@@ -27,6 +27,7 @@ type ruleContext struct {
 	recordingThresholds *recordingThresholds
 	upperThreshold      string
 	duration            monitoringv1.Duration
+	namespaces          namespaceScope
 }
 
 type healthAnnotation struct {
@@ -39,6 +40,9 @@ type healthAnnotation struct {
 	WorkloadLabels      []string             `json:"workloadLabels,omitempty"`
 	KindLabels          []string             `json:"kindLabels,omitempty"`
 	TrafficLink         *trafficLink         `json:"trafficLink,omitempty"`
+	IsScore             bool                 `json:"isScore,omitempty"`
+	// NamespaceThresholds are the recording thresholds overridden per namespace, in FlowCollectorSlices
+	NamespaceThresholds map[string]*recordingThresholds `json:"namespaceThresholds,omitempty"`
 }
 
 type recordingThresholds struct {
@@ -92,6 +96,12 @@ func newHealthAnnotation(ctx *ruleContext) *healthAnnotation {
 		RecordingThresholds: ctx.recordingThresholds,
 		Unit:                "%",
 	}
+	if ctx.recordingThresholds != nil && len(ctx.namespaces.include) > 0 {
+		annotation.NamespaceThresholds = make(map[string]*recordingThresholds)
+		for _, ns := range ctx.namespaces.include {
+			annotation.NamespaceThresholds[ns] = ctx.recordingThresholds
+		}
+	}
 	switch ctx.healthRule.GroupBy {
 	case flowslatest.GroupByNode:
 		annotation.NodeLabels = []string{"node"}
@@ -175,6 +185,13 @@ func getPromQLFilters(ctx *ruleContext, additionalFilter string) string {
 		filters = append(filters, string(ctx.side)+`K8S_OwnerType!=""`)
 	}
 
+	// Restrict to, or exclude, the namespaces having their own health rules in FlowCollectorSlices
+	if isNamespaced(ctx.healthRule.GroupBy) {
+		if m := ctx.namespaces.matcher(string(ctx.side) + "K8S_Namespace"); m != "" {
+			filters = append(filters, m)
+		}
+	}
+
 	// Add additional business logic filters
 	if additionalFilter != "" {
 		filters = append(filters, additionalFilter)
@@ -0,0 +1,198 @@
+package alerts
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Score penalties per severity: each active health rule lowers the score of the affected node, namespace or workload
+// by the weight of its highest severity. The score is clamped between 0 and 100.
+var scoreWeights = []struct {
+	severity string
+	weight   int
+}{
+	{severity: "critical", weight: 50},
+	{severity: "warning", weight: 25},
+	{severity: "info", weight: 10},
+}
+
+type healthScore struct {
+	groupBy        flowslatest.HealthRuleGroupBy
+	contexts       []ruleContext
+	enabledMetrics []string
+}
+
+// HealthScoreRecordingName returns the name of the series recording the health score for the given grouping
+func HealthScoreRecordingName(groupBy flowslatest.HealthRuleGroupBy) string {
+	return "netobserv:health:score:" + strings.ToLower(string(groupBy))
+}
+
+// BuildHealthScoreRules returns the rules recording a health score between 0 and 100 per node, namespace and workload.
+// Scores are computed from the active health rules having the same grouping, whether alerts or recording rules.
+func BuildHealthScoreRules(fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice) []HealthRule {
+	perGroup := make(map[flowslatest.HealthRuleGroupBy][]ruleContext)
+	for _, ctx := range healthRuleContexts(fc, fcSlices) {
+		if ctx.healthRule.GroupBy != "" {
+			perGroup[ctx.healthRule.GroupBy] = append(perGroup[ctx.healthRule.GroupBy], ctx)
+		}
+	}
+	var rules []HealthRule
+	for _, groupBy := range []flowslatest.HealthRuleGroupBy{flowslatest.GroupByNode, flowslatest.GroupByNamespace, flowslatest.GroupByWorkload} {
+		if contexts := perGroup[groupBy]; len(contexts) > 0 {
+			rules = append(rules, &healthScore{groupBy: groupBy, contexts: contexts, enabledMetrics: fc.GetIncludeList()})
+		}
+	}
+	return rules
+}
+
+func (s *healthScore) RecordingName() string {
+	return HealthScoreRecordingName(s.groupBy)
+}
+
+func (s *healthScore) labels() []string {
+	switch s.groupBy {
+	case flowslatest.GroupByNode:
+		return []string{"node"}
+	case flowslatest.GroupByNamespace:
+		return []string{"namespace"}
+	case flowslatest.GroupByWorkload:
+		return []string{"namespace", "workload", "kind"}
+	}
+	return nil
+}
+
+func (s *healthScore) GetAnnotations() (map[string]string, error) {
+	healthAnnot := healthAnnotation{UpperBound: "100", IsScore: true}
+	switch s.groupBy {
+	case flowslatest.GroupByNode:
+		healthAnnot.NodeLabels = []string{"node"}
+	case flowslatest.GroupByNamespace:
+		healthAnnot.NamespaceLabels = []string{"namespace"}
+	case flowslatest.GroupByWorkload:
+		healthAnnot.NamespaceLabels = []string{"namespace"}
+		healthAnnot.WorkloadLabels = []string{"workload"}
+		healthAnnot.KindLabels = []string{"kind"}
+	}
+	return map[string]string{
+		"summary": "Network health score",
+		"description": fmt.Sprintf(
+			"Network health score per %s, from 0 to 100, computed from the active health rules.",
+			strings.ToLower(string(s.groupBy)),
+		),
+		healthAnnotationKey: encodeHealthAnnotation(&healthAnnot),
+	}, nil
+}
+
+// Build returns the recording rule of the health score, such as:
+// clamp_min(100 - (sum by (namespace) (max by (namespace,template) (<penalties>)) or 0 * group by (namespace) (<recorded health rules>) or 0 * <flows>), 0)
+// Penalties are the weights of the firing alerts, or of the highest threshold exceeded by the recorded health rules.
+// The flows series, when a metric is available for the grouping, gives a score of 100 to every healthy node, namespace or workload.
+func (s *healthScore) Build() (*monitoringv1.Rule, error) {
+	var penalties []string
+	var recordings []string
+	for i := range s.contexts {
+		ctx := &s.contexts[i]
+		r := ctx.toRule()
+		if r == nil {
+			continue
+		}
+		// Some rules do not apply to a side, e.g. DNS errors are in return traffic only
+		if mr, err := r.Build(); err != nil || mr == nil {
+			continue
+		}
+		if ctx.mode == flowslatest.ModeRecording {
+			// Health rules overridden in FlowCollectorSlices are recorded in the same series, with different thresholds per namespace
+			if p := recordingPenalty(r.RecordingName(), ctx.namespaces.selector("namespace"), ctx.recordingThresholds); p != "" {
+				penalties = append(penalties, p)
+				if !slices.Contains(recordings, r.RecordingName()) {
+					recordings = append(recordings, r.RecordingName())
+				}
+			}
+		} else {
+			penalties = append(penalties, fmt.Sprintf(`ALERTS{alertstate="firing",alertname="%s"} * 0 + %d`, alertName(ctx), severityWeight(ctx.severity)))
+		}
+	}
+	if len(penalties) == 0 {
+		return nil, nil
+	}
+
+	labels := strings.Join(s.labels(), ",")
+	promQL := fmt.Sprintf("sum by (%s) (max by (%s,template) (%s))", labels, labels, strings.Join(penalties, " or "))
+	if len(recordings) > 0 {
+		promQL = fmt.Sprintf(`%s or 0 * group by (%s) ({__name__=~"%s"})`, promQL, labels, strings.Join(recordings, "|"))
+	}
+	if base := s.baseSeries(); base != "" {
+		promQL = fmt.Sprintf("%s or 0 * %s", promQL, base)
+	}
+	return &monitoringv1.Rule{
+		Record: s.RecordingName(),
+		Expr:   intstr.FromString(fmt.Sprintf("clamp_min(100 - (%s), 0)", promQL)),
+		Labels: map[string]string{"app": "netobserv"},
+	}, nil
+}
+
+// baseSeries returns the nodes, namespaces or workloads seen in flows, from the first enabled metric of the grouping, such as:
+// group by (namespace) (label_replace(netobserv_namespace_flows_total{SrcK8S_Namespace!=""}, "namespace", "$1", "SrcK8S_Namespace", "(.*)") or <same for Dst>)
+func (s *healthScore) baseSeries() string {
+	group := strings.ToLower(string(s.groupBy))
+	var candidates []string
+	for _, pattern := range []string{"%s_flows_total", "%s_ingress_bytes_total", "%s_egress_bytes_total", "%s_ingress_packets_total", "%s_egress_packets_total"} {
+		candidates = append(candidates, fmt.Sprintf(pattern, group))
+	}
+	metric := flowslatest.GetFirstRequiredMetrics(candidates, s.enabledMetrics)
+	if metric == "" {
+		return ""
+	}
+	var sides []string
+	for _, side := range []srcOrDst{asSource, asDest} {
+		nooLabels, labelsOut := groupByLabels(s.groupBy, side)
+		var filters []string
+		for _, l := range nooLabels {
+			filters = append(filters, l+`!=""`)
+		}
+		sides = append(sides, relabel(fmt.Sprintf("netobserv_%s{%s}", metric, strings.Join(filters, ",")), nooLabels, labelsOut))
+	}
+	return fmt.Sprintf("group by (%s) (%s)", strings.Join(s.labels(), ","), strings.Join(sides, " or "))
+}
+
+// recordingPenalty returns the weight of the highest threshold exceeded by a recorded health rule, optionally restricted by a namespace selector.
+// As several health rules can be recorded for the same template (e.g. as source and as destination), a distinct label is added to each.
+func recordingPenalty(recordingName, selector string, thresholds *recordingThresholds) string {
+	if thresholds == nil {
+		return ""
+	}
+	var parts []string
+	for _, sw := range scoreWeights {
+		var threshold string
+		switch sw.severity {
+		case "critical":
+			threshold = thresholds.Critical
+		case "warning":
+			threshold = thresholds.Warning
+		case "info":
+			threshold = thresholds.Info
+		}
+		if threshold != "" {
+			parts = append(parts, fmt.Sprintf("(%s%s > %s) * 0 + %d", recordingName, selector, threshold, sw.weight))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`label_replace(%s, "rule", "%s", "", "")`, strings.Join(parts, " or "), recordingName)
+}
+
+func severityWeight(severity string) int {
+	for _, sw := range scoreWeights {
+		if sw.severity == severity {
+			return sw.weight
+		}
+	}
+	return 0
+}
@@ -0,0 +1,128 @@
+package alerts
+
+import (
+	"encoding/json"
+	"testing"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func healthScoreFC(healthRules ...flowslatest.FLPHealthRule) *flowslatest.FlowCollectorSpec {
+	return &flowslatest.FlowCollectorSpec{
+		Agent: flowslatest.FlowCollectorAgent{
+			EBPF: flowslatest.FlowCollectorEBPF{
+				Privileged: true,
+				Features:   []flowslatest.AgentFeature{flowslatest.DNSTracking, flowslatest.PacketDrop},
+			},
+		},
+		Processor: flowslatest.FlowCollectorFLP{
+			Metrics: flowslatest.FLPMetrics{
+				DisableAlerts: allTemplatesBut(flowslatest.HealthRulePacketDropsByKernel, flowslatest.HealthRuleDNSErrors),
+				HealthRules:   &healthRules,
+			},
+		},
+	}
+}
+
+func TestHealthScore_Namespace(t *testing.T) {
+	recording := flowslatest.ModeRecording
+	fc := healthScoreFC(
+		flowslatest.FLPHealthRule{
+			Template: flowslatest.HealthRulePacketDropsByKernel,
+			Mode:     flowslatest.ModeRecording,
+			Variants: []flowslatest.HealthRuleVariant{
+				{Thresholds: flowslatest.HealthRuleThresholds{Warning: "10", Critical: "20"}, GroupBy: flowslatest.GroupByNamespace},
+				// Global variants are not part of the score
+				{Thresholds: flowslatest.HealthRuleThresholds{Critical: "20"}},
+			},
+		},
+		flowslatest.FLPHealthRule{
+			Template: flowslatest.HealthRuleDNSErrors,
+			Variants: []flowslatest.HealthRuleVariant{
+				{Thresholds: flowslatest.HealthRuleThresholds{Info: "5", Critical: "30"}, GroupBy: flowslatest.GroupByNamespace},
+				{Thresholds: flowslatest.HealthRuleThresholds{Info: "5"}, GroupBy: flowslatest.GroupByNode, Mode: &recording},
+			},
+		},
+	)
+
+	scores := BuildHealthScoreRules(fc, nil)
+	require.Len(t, scores, 2)
+	assert.Equal(t, "netobserv:health:score:node", scores[0].RecordingName())
+	assert.Equal(t, "netobserv:health:score:namespace", scores[1].RecordingName())
+
+	mr, err := scores[1].Build()
+	require.NoError(t, err)
+	assert.Equal(t, "netobserv:health:score:namespace", mr.Record)
+	assert.Equal(t, map[string]string{"app": "netobserv"}, mr.Labels)
+	assert.Equal(t,
+		`clamp_min(100 - (sum by (namespace) (max by (namespace,template) (`+
+			`label_replace((netobserv:health:packet_drops_kernel:namespace:src:rate2m > 20) * 0 + 50 or (netobserv:health:packet_drops_kernel:namespace:src:rate2m > 10) * 0 + 25, "rule", "netobserv:health:packet_drops_kernel:namespace:src:rate2m", "", "")`+
+			` or label_replace((netobserv:health:packet_drops_kernel:namespace:dst:rate2m > 20) * 0 + 50 or (netobserv:health:packet_drops_kernel:namespace:dst:rate2m > 10) * 0 + 25, "rule", "netobserv:health:packet_drops_kernel:namespace:dst:rate2m", "", "")`+
+			// DNS errors only apply to destination
+			` or ALERTS{alertstate="firing",alertname="DNSErrors_PerDstNamespaceCritical"} * 0 + 50`+
+			` or ALERTS{alertstate="firing",alertname="DNSErrors_PerDstNamespaceInfo"} * 0 + 10))`+
+			` or 0 * group by (namespace) ({__name__=~"netobserv:health:packet_drops_kernel:namespace:src:rate2m|netobserv:health:packet_drops_kernel:namespace:dst:rate2m"})`+
+			// Healthy namespaces get a score of 100
+			` or 0 * group by (namespace) (label_replace(netobserv_namespace_flows_total{SrcK8S_Namespace!=""}, "namespace", "$1", "SrcK8S_Namespace", "(.*)")`+
+			` or label_replace(netobserv_namespace_flows_total{DstK8S_Namespace!=""}, "namespace", "$1", "DstK8S_Namespace", "(.*)"))), 0)`,
+		mr.Expr.StrVal,
+	)
+
+	anns, err := scores[1].GetAnnotations()
+	require.NoError(t, err)
+	assert.Equal(t, "Network health score", anns["summary"])
+	var hann healthAnnotation
+	require.NoError(t, json.Unmarshal([]byte(anns[healthAnnotationKey]), &hann))
+	assert.Equal(t, healthAnnotation{UpperBound: "100", IsScore: true, NamespaceLabels: []string{"namespace"}}, hann)
+}
+
+func TestHealthScore_AlertsOnly(t *testing.T) {
+	fc := healthScoreFC(
+		flowslatest.FLPHealthRule{
+			Template: flowslatest.HealthRulePacketDropsByKernel,
+			Variants: []flowslatest.HealthRuleVariant{
+				{Thresholds: flowslatest.HealthRuleThresholds{Warning: "10"}, GroupBy: flowslatest.GroupByWorkload},
+			},
+		},
+	)
+	fc.Processor.Metrics.DisableAlerts = allTemplatesBut(flowslatest.HealthRulePacketDropsByKernel)
+
+	scores := BuildHealthScoreRules(fc, nil)
+	require.Len(t, scores, 1)
+	mr, err := scores[0].Build()
+	require.NoError(t, err)
+	assert.Equal(t, "netobserv:health:score:workload", mr.Record)
+	assert.Equal(t,
+		`clamp_min(100 - (sum by (namespace,workload,kind) (max by (namespace,workload,kind,template) (`+
+			`ALERTS{alertstate="firing",alertname="PacketDropsByKernel_PerSrcWorkloadWarning"} * 0 + 25`+
+			` or ALERTS{alertstate="firing",alertname="PacketDropsByKernel_PerDstWorkloadWarning"} * 0 + 25))`+
+			// Healthy workloads get a score of 100, even though there is no recorded health rule
+			` or 0 * group by (namespace,workload,kind) (`+
+			`label_replace(label_replace(label_replace(netobserv_workload_ingress_bytes_total{SrcK8S_Namespace!="",SrcK8S_OwnerName!="",SrcK8S_OwnerType!=""}, "namespace", "$1", "SrcK8S_Namespace", "(.*)"), "workload", "$1", "SrcK8S_OwnerName", "(.*)"), "kind", "$1", "SrcK8S_OwnerType", "(.*)")`+
+			` or label_replace(label_replace(label_replace(netobserv_workload_ingress_bytes_total{DstK8S_Namespace!="",DstK8S_OwnerName!="",DstK8S_OwnerType!=""}, "namespace", "$1", "DstK8S_Namespace", "(.*)"), "workload", "$1", "DstK8S_OwnerName", "(.*)"), "kind", "$1", "DstK8S_OwnerType", "(.*)"))), 0)`,
+		mr.Expr.StrVal,
+	)
+
+	// Without any workload metric, only the workloads with firing alerts get a score
+	fc.Processor.Metrics.IncludeList = &[]flowslatest.FLPMetric{"workload_drop_packets_total"}
+	scores = BuildHealthScoreRules(fc, nil)
+	require.Len(t, scores, 1)
+	mr, err = scores[0].Build()
+	require.NoError(t, err)
+	assert.NotContains(t, mr.Expr.StrVal, "group by")
+}
+
+func TestHealthScore_NoGroupBy(t *testing.T) {
+	fc := healthScoreFC(
+		flowslatest.FLPHealthRule{
+			Template: flowslatest.HealthRulePacketDropsByKernel,
+			Variants: []flowslatest.HealthRuleVariant{
+				{Thresholds: flowslatest.HealthRuleThresholds{Warning: "10"}},
+			},
+		},
+	)
+	fc.Processor.Metrics.DisableAlerts = allTemplatesBut(flowslatest.HealthRulePacketDropsByKernel)
+	assert.Empty(t, BuildHealthScoreRules(fc, nil))
+}
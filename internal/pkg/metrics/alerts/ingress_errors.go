@@ -52,9 +52,15 @@ func (r *ingressErrors) Build() (*monitoringv1.Rule, error) {
 	// Build PromQL with label_replace to rename exported_namespace to namespace
 	var errorsQuery, totalQuery string
 	if r.ctx.healthRule.GroupBy == flowslatest.GroupByNamespace {
+		// Namespaces having their own health rules in FlowCollectorSlices
+		errorsFilter := `code="5xx"`
+		if m := r.ctx.namespaces.matcher("exported_namespace"); m != "" {
+			errorsFilter += "," + m
+		}
+		totalSelector := r.ctx.namespaces.selector("exported_namespace")
 		// Rename exported_namespace to namespace for console plugin compatibility
-		errorsQuery = `sum(label_replace(rate(haproxy_server_http_responses_total{code="5xx"}[2m]), "namespace", "$1", "exported_namespace", "(.*)")) by (namespace)`
-		totalQuery = `sum(label_replace(rate(haproxy_server_http_responses_total[2m]), "namespace", "$1", "exported_namespace", "(.*)")) by (namespace)`
+		errorsQuery = fmt.Sprintf(`sum(label_replace(rate(haproxy_server_http_responses_total{%s}[2m]), "namespace", "$1", "exported_namespace", "(.*)")) by (namespace)`, errorsFilter)
+		totalQuery = fmt.Sprintf(`sum(label_replace(rate(haproxy_server_http_responses_total%s[2m]), "namespace", "$1", "exported_namespace", "(.*)")) by (namespace)`, totalSelector)
 	} else {
 		// Global (no groupBy)
 		errorsQuery = `sum(rate(haproxy_server_http_responses_total{code="5xx"}[2m]))`
@@ -57,9 +57,11 @@ func (r *ingressHTTPLatencyTrend) Build() (*monitoringv1.Rule, error) {
 	var currentMetric, baselineMetric string
 	// TODO: check p90 rather than avg?
 	if r.ctx.healthRule.GroupBy == flowslatest.GroupByNamespace {
+		// Namespaces having their own health rules in FlowCollectorSlices
+		selector := r.ctx.namespaces.selector("exported_namespace")
 		// Rename exported_namespace to namespace for console plugin compatibility
-		currentMetric = `avg(label_replace(haproxy_server_http_average_response_latency_milliseconds, "namespace", "$1", "exported_namespace", "(.*)")) by (namespace)`
-		baselineMetric = fmt.Sprintf(`avg(label_replace(haproxy_server_http_average_response_latency_milliseconds offset %s, "namespace", "$1", "exported_namespace", "(.*)")) by (namespace)`, offset)
+		currentMetric = fmt.Sprintf(`avg(label_replace(haproxy_server_http_average_response_latency_milliseconds%s, "namespace", "$1", "exported_namespace", "(.*)")) by (namespace)`, selector)
+		baselineMetric = fmt.Sprintf(`avg(label_replace(haproxy_server_http_average_response_latency_milliseconds%s offset %s, "namespace", "$1", "exported_namespace", "(.*)")) by (namespace)`, selector, offset)
 	} else {
 		// Global (no groupBy)
 		currentMetric = `avg(haproxy_server_http_average_response_latency_milliseconds)`
@@ -0,0 +1,233 @@
+package alerts
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// MaintenanceRecordingName is the name of the series recorded for each active maintenance window
+	MaintenanceRecordingName = "netobserv:maintenance:active"
+	// SilenceCreator identifies the Alertmanager silences created by the operator
+	SilenceCreator = "netobserv-operator"
+)
+
+// Silence is an Alertmanager silence of the health rule alerts, following the Alertmanager v2 API
+type Silence struct {
+	Matchers  []SilenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Comment   string           `json:"comment"`
+}
+
+type SilenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// MaintenanceOccurrence returns the current occurrence of a maintenance window when it is active, or else the next one.
+// It returns false when the window has no current or future occurrence.
+func MaintenanceOccurrence(w *flowslatest.MaintenanceWindow, now time.Time) (time.Time, time.Time, bool) {
+	if w.Schedule == "" {
+		if w.Start == nil || w.End == nil || !w.End.Time.After(now) {
+			return time.Time{}, time.Time{}, false
+		}
+		return w.Start.Time, w.End.Time, true
+	}
+	if w.Duration == nil || w.Duration.Duration <= 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	// The first start after (now - duration) is either the active occurrence, or the next one
+	start := schedule.Next(now.UTC().Add(-w.Duration.Duration))
+	if start.IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(w.Duration.Duration), true
+}
+
+// BuildMaintenanceRules returns the rules recording the maintenance windows, from their current or next occurrence,
+// and the time when the rules must be rebuilt, which is the end of the earliest occurrence.
+// Each rule records a series while the window is active, labelled with the window name, and the namespace or the node when the window is scoped.
+// When `inhibit` is true, such as when silences cannot be created in Alertmanager, the series are labelled to inhibit the health rule alerts.
+func BuildMaintenanceRules(fc *flowslatest.FlowCollectorSpec, now time.Time, inhibit bool) ([]monitoringv1.Rule, time.Time) {
+	var rules []monitoringv1.Rule
+	var nextUpdate time.Time
+	for i := range fc.Processor.Metrics.MaintenanceWindows {
+		w := &fc.Processor.Metrics.MaintenanceWindows[i]
+		start, end, ok := MaintenanceOccurrence(w, now)
+		if !ok {
+			continue
+		}
+		if nextUpdate.IsZero() || end.Before(nextUpdate) {
+			nextUpdate = end
+		}
+		expr := intstr.FromString(fmt.Sprintf("vector(1) and on() (vector(time()) >= %d < %d)", start.Unix(), end.Unix()))
+		labels := map[string]string{"app": "netobserv", "window": w.Name, "inhibit": strconv.FormatBool(inhibit)}
+		scopes := maintenanceScopes(w)
+		if len(scopes) == 0 {
+			rules = append(rules, monitoringv1.Rule{
+				Record: MaintenanceRecordingName,
+				Expr:   expr,
+				Labels: labels,
+			})
+		}
+		for _, scope := range scopes {
+			for _, value := range scope.values {
+				scopedLabels := maps.Clone(labels)
+				scopedLabels[scope.label] = value
+				rules = append(rules, monitoringv1.Rule{
+					Record: MaintenanceRecordingName,
+					Expr:   expr,
+					Labels: scopedLabels,
+				})
+			}
+		}
+	}
+	return rules, nextUpdate
+}
+
+// BuildMaintenanceSilences returns the Alertmanager silences of the health rule alerts, for the current or next occurrence of the maintenance windows.
+// A window scoped to both namespaces and nodes results in two silences.
+func BuildMaintenanceSilences(fc *flowslatest.FlowCollectorSpec, now time.Time) []Silence {
+	var silences []Silence
+	for i := range fc.Processor.Metrics.MaintenanceWindows {
+		w := &fc.Processor.Metrics.MaintenanceWindows[i]
+		start, end, ok := MaintenanceOccurrence(w, now)
+		if !ok {
+			continue
+		}
+		// Operational alerts do not have the "netobserv" label
+		matchers := []SilenceMatcher{{Name: "netobserv", Value: "true", IsEqual: true}}
+		if len(w.Templates) > 0 {
+			matchers = append(matchers, SilenceMatcher{Name: "template", Value: regexAlternatives(templateNames(w.Templates)), IsRegex: true, IsEqual: true})
+		}
+		silence := Silence{
+			StartsAt:  start,
+			EndsAt:    end,
+			CreatedBy: SilenceCreator,
+			Comment:   "NetObserv maintenance window " + w.Name,
+		}
+		scopes := maintenanceScopes(w)
+		if len(scopes) == 0 {
+			silence.Matchers = matchers
+			silences = append(silences, silence)
+		}
+		for _, scope := range scopes {
+			s := silence
+			s.Matchers = append(slices.Clone(matchers), SilenceMatcher{Name: scope.label, Value: regexAlternatives(scope.values), IsRegex: true, IsEqual: true})
+			silences = append(silences, s)
+		}
+	}
+	return silences
+}
+
+type maintenanceScope struct {
+	label  string
+	values []string
+}
+
+func maintenanceScopes(w *flowslatest.MaintenanceWindow) []maintenanceScope {
+	var scopes []maintenanceScope
+	if len(w.Namespaces) > 0 {
+		scopes = append(scopes, maintenanceScope{label: "namespace", values: w.Namespaces})
+	}
+	if len(w.Nodes) > 0 {
+		scopes = append(scopes, maintenanceScope{label: "node", values: w.Nodes})
+	}
+	return scopes
+}
+
+// maintenanceInhibited wraps a health rule so that its alerts do not fire during the maintenance windows, unless silences are created in Alertmanager.
+// Recording rules are unchanged.
+type maintenanceInhibited struct {
+	HealthRule
+	groupBy flowslatest.HealthRuleGroupBy
+	windows []flowslatest.MaintenanceWindow
+}
+
+func withMaintenanceInhibition(rules []HealthRule, template flowslatest.HealthRuleTemplate, groupBy flowslatest.HealthRuleGroupBy, all []flowslatest.MaintenanceWindow) []HealthRule {
+	var windows []flowslatest.MaintenanceWindow
+	for i := range all {
+		if len(all[i].Templates) == 0 || slices.Contains(all[i].Templates, template) {
+			windows = append(windows, all[i])
+		}
+	}
+	if len(windows) == 0 {
+		return rules
+	}
+	wrapped := make([]HealthRule, 0, len(rules))
+	for _, r := range rules {
+		wrapped = append(wrapped, &maintenanceInhibited{HealthRule: r, groupBy: groupBy, windows: windows})
+	}
+	return wrapped
+}
+
+// Build returns the wrapped alert, which is not firing while an applicable window is recorded as inhibiting, such as:
+// (<alert expr>) unless on() netobserv:maintenance:active{window=~"weekly",inhibit="true"} unless on(namespace) netobserv:maintenance:active{window=~"upgrade",inhibit="true",namespace!=""}
+// Windows scoped to namespaces apply to alerts grouped by namespace or workload, and windows scoped to nodes apply to alerts grouped by node.
+func (m *maintenanceInhibited) Build() (*monitoringv1.Rule, error) {
+	r, err := m.HealthRule.Build()
+	if err != nil || r == nil || r.Alert == "" {
+		return r, err
+	}
+	var global, perNamespace, perNode []string
+	for i := range m.windows {
+		w := &m.windows[i]
+		if len(w.Namespaces) == 0 && len(w.Nodes) == 0 {
+			global = append(global, w.Name)
+		}
+		if len(w.Namespaces) > 0 && (m.groupBy == flowslatest.GroupByNamespace || m.groupBy == flowslatest.GroupByWorkload) {
+			perNamespace = append(perNamespace, w.Name)
+		}
+		if len(w.Nodes) > 0 && m.groupBy == flowslatest.GroupByNode {
+			perNode = append(perNode, w.Name)
+		}
+	}
+	var clauses []string
+	if len(global) > 0 {
+		clauses = append(clauses, fmt.Sprintf(`unless on() %s{window=~"%s",inhibit="true"}`, MaintenanceRecordingName, regexAlternatives(global)))
+	}
+	if len(perNamespace) > 0 {
+		clauses = append(clauses, fmt.Sprintf(`unless on(namespace) %s{window=~"%s",inhibit="true",namespace!=""}`, MaintenanceRecordingName, regexAlternatives(perNamespace)))
+	}
+	if len(perNode) > 0 {
+		clauses = append(clauses, fmt.Sprintf(`unless on(node) %s{window=~"%s",inhibit="true",node!=""}`, MaintenanceRecordingName, regexAlternatives(perNode)))
+	}
+	if len(clauses) > 0 {
+		r.Expr = intstr.FromString(fmt.Sprintf("(%s) %s", r.Expr.String(), strings.Join(clauses, " ")))
+	}
+	return r, nil
+}
+
+func templateNames(templates []flowslatest.HealthRuleTemplate) []string {
+	var names []string
+	for _, t := range templates {
+		names = append(names, string(t))
+	}
+	return names
+}
+
+func regexAlternatives(values []string) string {
+	var quoted []string
+	for _, v := range values {
+		quoted = append(quoted, regexp.QuoteMeta(v))
+	}
+	return strings.Join(quoted, "|")
+}
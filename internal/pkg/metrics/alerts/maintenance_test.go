@@ -0,0 +1,150 @@
+package alerts
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var maintenanceNow = time.Date(2026, 10, 24, 3, 0, 0, 0, time.UTC) // Saturday
+
+func weeklyWindow() flowslatest.MaintenanceWindow {
+	return flowslatest.MaintenanceWindow{Name: "weekly", Schedule: "0 2 * * 6", Duration: &metav1.Duration{Duration: 2 * time.Hour}}
+}
+
+func upgradeWindow() flowslatest.MaintenanceWindow {
+	start := metav1.NewTime(time.Date(2026, 11, 7, 22, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(time.Date(2026, 11, 8, 2, 0, 0, 0, time.UTC))
+	return flowslatest.MaintenanceWindow{
+		Name:       "upgrade",
+		Start:      &start,
+		End:        &end,
+		Namespaces: []string{"app-a", "app-b"},
+		Nodes:      []string{"worker-1.example.com"},
+		Templates:  []flowslatest.HealthRuleTemplate{flowslatest.HealthRuleDNSErrors},
+	}
+}
+
+func TestMaintenanceOccurrence(t *testing.T) {
+	w := weeklyWindow()
+	// active
+	start, end, ok := MaintenanceOccurrence(&w, maintenanceNow)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 10, 24, 4, 0, 0, 0, time.UTC), end)
+	// next
+	start, end, ok = MaintenanceOccurrence(&w, maintenanceNow.Add(time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 10, 31, 2, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 10, 31, 4, 0, 0, 0, time.UTC), end)
+	// day names, as accepted by the standard parser
+	w.Schedule = "0 2 * * SAT"
+	start, _, ok = MaintenanceOccurrence(&w, maintenanceNow.Add(time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 10, 31, 2, 0, 0, 0, time.UTC), start)
+	// never occurs
+	w.Schedule = "0 0 30 2 *"
+	_, _, ok = MaintenanceOccurrence(&w, maintenanceNow)
+	assert.False(t, ok)
+	// invalid
+	w.Schedule = "0 25 * * 6"
+	_, _, ok = MaintenanceOccurrence(&w, maintenanceNow)
+	assert.False(t, ok)
+
+	w = upgradeWindow()
+	start, end, ok = MaintenanceOccurrence(&w, maintenanceNow)
+	assert.True(t, ok)
+	assert.Equal(t, w.Start.Time, start)
+	assert.Equal(t, w.End.Time, end)
+	// ended
+	_, _, ok = MaintenanceOccurrence(&w, w.End.Time)
+	assert.False(t, ok)
+}
+
+func TestBuildMaintenanceRules(t *testing.T) {
+	fc := flowslatest.FlowCollectorSpec{}
+	fc.Processor.Metrics.MaintenanceWindows = []flowslatest.MaintenanceWindow{weeklyWindow(), upgradeWindow()}
+
+	rules, next := BuildMaintenanceRules(&fc, maintenanceNow, true)
+	assert.Equal(t, time.Date(2026, 10, 24, 4, 0, 0, 0, time.UTC), next)
+	require.Len(t, rules, 4)
+	for _, r := range rules {
+		assert.Equal(t, "netobserv:maintenance:active", r.Record)
+	}
+	assert.Equal(t, "vector(1) and on() (vector(time()) >= 1792807200 < 1792814400)", rules[0].Expr.String())
+	assert.Equal(t, map[string]string{"app": "netobserv", "window": "weekly", "inhibit": "true"}, rules[0].Labels)
+	assert.Equal(t, "vector(1) and on() (vector(time()) >= 1794088800 < 1794103200)", rules[1].Expr.String())
+	assert.Equal(t, map[string]string{"app": "netobserv", "window": "upgrade", "inhibit": "true", "namespace": "app-a"}, rules[1].Labels)
+	assert.Equal(t, map[string]string{"app": "netobserv", "window": "upgrade", "inhibit": "true", "namespace": "app-b"}, rules[2].Labels)
+	assert.Equal(t, map[string]string{"app": "netobserv", "window": "upgrade", "inhibit": "true", "node": "worker-1.example.com"}, rules[3].Labels)
+
+	// After the upgrade, only the weekly window remains; with Alertmanager silences, the series do not inhibit alerts
+	rules, next = BuildMaintenanceRules(&fc, time.Date(2026, 11, 8, 3, 0, 0, 0, time.UTC), false)
+	assert.Equal(t, time.Date(2026, 11, 14, 4, 0, 0, 0, time.UTC), next)
+	require.Len(t, rules, 1)
+	assert.Equal(t, map[string]string{"app": "netobserv", "window": "weekly", "inhibit": "false"}, rules[0].Labels)
+}
+
+func TestBuildMaintenanceSilences(t *testing.T) {
+	fc := flowslatest.FlowCollectorSpec{}
+	fc.Processor.Metrics.MaintenanceWindows = []flowslatest.MaintenanceWindow{weeklyWindow(), upgradeWindow()}
+
+	silences := BuildMaintenanceSilences(&fc, maintenanceNow)
+	require.Len(t, silences, 3)
+	assert.Equal(t, Silence{
+		Matchers:  []SilenceMatcher{{Name: "netobserv", Value: "true", IsEqual: true}},
+		StartsAt:  time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC),
+		EndsAt:    time.Date(2026, 10, 24, 4, 0, 0, 0, time.UTC),
+		CreatedBy: "netobserv-operator",
+		Comment:   "NetObserv maintenance window weekly",
+	}, silences[0])
+	assert.Equal(t, []SilenceMatcher{
+		{Name: "netobserv", Value: "true", IsEqual: true},
+		{Name: "template", Value: "DNSErrors", IsRegex: true, IsEqual: true},
+		{Name: "namespace", Value: "app-a|app-b", IsRegex: true, IsEqual: true},
+	}, silences[1].Matchers)
+	assert.Equal(t, []SilenceMatcher{
+		{Name: "netobserv", Value: "true", IsEqual: true},
+		{Name: "template", Value: "DNSErrors", IsRegex: true, IsEqual: true},
+		{Name: "node", Value: `worker-1\.example\.com`, IsRegex: true, IsEqual: true},
+	}, silences[2].Matchers)
+	assert.Equal(t, "NetObserv maintenance window upgrade", silences[2].Comment)
+}
+
+func TestMaintenanceInhibition(t *testing.T) {
+	fc := healthScoreFC(
+		flowslatest.FLPHealthRule{
+			Template: flowslatest.HealthRuleDNSErrors,
+			Variants: []flowslatest.HealthRuleVariant{
+				{Thresholds: flowslatest.HealthRuleThresholds{Warning: "10"}, GroupBy: flowslatest.GroupByNamespace},
+			},
+		},
+		flowslatest.FLPHealthRule{
+			Template: flowslatest.HealthRulePacketDropsByKernel,
+			Variants: []flowslatest.HealthRuleVariant{
+				{Thresholds: flowslatest.HealthRuleThresholds{Warning: "10"}, GroupBy: flowslatest.GroupByNode},
+			},
+		},
+	)
+	fc.Processor.Metrics.MaintenanceWindows = []flowslatest.MaintenanceWindow{weeklyWindow(), upgradeWindow()}
+
+	rules := BuildMonitoringRules(context.Background(), fc, nil)
+	r := findRule("DNSErrors_PerDstNamespaceWarning", rules)
+	require.NotNil(t, r)
+	assert.True(t, strings.HasSuffix(r.Expr.String(),
+		`) unless on() netobserv:maintenance:active{window=~"weekly",inhibit="true"} unless on(namespace) netobserv:maintenance:active{window=~"upgrade",inhibit="true",namespace!=""}`,
+	), r.Expr.String())
+	assert.True(t, strings.HasPrefix(r.Expr.String(), "(100 * (sum("), r.Expr.String())
+
+	// The upgrade window only applies to DNSErrors
+	r = findRule("PacketDropsByKernel_PerDstNodeWarning", rules)
+	require.NotNil(t, r)
+	assert.True(t, strings.HasSuffix(r.Expr.String(), `) unless on() netobserv:maintenance:active{window=~"weekly",inhibit="true"}`), r.Expr.String())
+
+}
@@ -1,11 +1,41 @@
 package alerts
 
 import (
+	"fmt"
+	"strings"
+
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+const (
+	// flowlogs-pipeline memory usage, as a ratio of its limit, above which NetObservFLPMemoryHigh is triggered
+	flpMemoryAlertRatio = "0.9"
+)
+
+func buildOperationalAlert(template flowslatest.AlertTemplate, fc *flowslatest.FlowCollectorSpec) *monitoringv1.Rule {
+	switch template {
+	case flowslatest.AlertNoFlows:
+		return alertNoFlows()
+	case flowslatest.AlertLokiError:
+		return alertLokiError()
+	case flowslatest.AlertKafkaConsumerLag:
+		if !fc.UseKafka() {
+			return nil
+		}
+		return alertKafkaConsumerLag(fc.Kafka.Topic)
+	case flowslatest.AlertFLPMemoryHigh:
+		return alertFLPMemoryHigh(fc.GetNamespace())
+	case flowslatest.AlertEncodeErrors:
+		return alertEncodeErrors()
+	case flowslatest.AlertExporterErrors:
+		return alertExporterErrors(fc.Exporters)
+	}
+	return nil
+}
+
 func alertNoFlows() *monitoringv1.Rule {
 	d := monitoringv1.Duration("10m")
 
@@ -38,3 +68,107 @@ func alertLokiError() *monitoringv1.Rule {
 		Labels: buildLabels(flowslatest.AlertLokiError, "warning", false),
 	}
 }
+
+func alertKafkaConsumerLag(topic string) *monitoringv1.Rule {
+	d := monitoringv1.Duration("15m")
+
+	// The consumer group lag is provided by the Kafka exporter, such as the one deployed by Strimzi
+	return &monitoringv1.Rule{
+		Alert: string(flowslatest.AlertKafkaConsumerLag),
+		Annotations: map[string]string{
+			"description": fmt.Sprintf(
+				"NetObserv flowlogs-pipeline is not consuming flows from the Kafka topic %s as fast as they are produced, and the consumer lag keeps growing. flowlogs-pipeline may need more replicas or resources.",
+				topic,
+			),
+			"summary":     "NetObserv flowlogs-pipeline Kafka consumer lag is growing",
+			"runbook_url": BuildNetObservRunbookURL(string(flowslatest.AlertKafkaConsumerLag)),
+		},
+		Expr: intstr.FromString(fmt.Sprintf(
+			`sum(delta(kafka_consumergroup_lag{consumergroup="%s",topic="%s"}[10m])) > 0`,
+			constants.FLPName,
+			topic,
+		)),
+		For:    &d,
+		Labels: buildLabels(flowslatest.AlertKafkaConsumerLag, "warning", false),
+	}
+}
+
+func alertFLPMemoryHigh(namespace string) *monitoringv1.Rule {
+	d := monitoringv1.Duration("15m")
+
+	selector := fmt.Sprintf(`namespace="%s",container="%s"`, namespace, constants.FLPName)
+	return &monitoringv1.Rule{
+		Alert: string(flowslatest.AlertFLPMemoryHigh),
+		Annotations: map[string]string{
+			"description": "NetObserv flowlogs-pipeline pod {{ $labels.pod }} memory usage is close to its limit. It may get OOM-killed, causing flows to be lost or back pressure on the eBPF agents.",
+			"summary":     "NetObserv flowlogs-pipeline memory usage is close to its limit",
+			"runbook_url": BuildNetObservRunbookURL(string(flowslatest.AlertFLPMemoryHigh)),
+		},
+		Expr: intstr.FromString(fmt.Sprintf(
+			`max by (pod) (max by (namespace,pod,container) (container_memory_working_set_bytes{%s}) / max by (namespace,pod,container) (kube_pod_container_resource_limits{%s,resource="memory"})) > %s`,
+			selector,
+			selector,
+			flpMemoryAlertRatio,
+		)),
+		For:    &d,
+		Labels: buildLabels(flowslatest.AlertFLPMemoryHigh, "warning", false),
+	}
+}
+
+func alertEncodeErrors() *monitoringv1.Rule {
+	d := monitoringv1.Duration("10m")
+
+	return &monitoringv1.Rule{
+		Alert: string(flowslatest.AlertEncodeErrors),
+		Annotations: map[string]string{
+			"description": "NetObserv flowlogs-pipeline is failing to generate some metrics. The metric netobserv_encode_prom_errors provides more information on the cause, such as a FlowMetric with invalid labels.",
+			"summary":     "NetObserv flowlogs-pipeline is failing to generate metrics",
+			"runbook_url": BuildNetObservRunbookURL(string(flowslatest.AlertEncodeErrors)),
+		},
+		Expr:   intstr.FromString("sum(rate(netobserv_encode_prom_errors[5m])) > 0"),
+		For:    &d,
+		Labels: buildLabels(flowslatest.AlertEncodeErrors, "warning", false),
+	}
+}
+
+// alertExporterErrors is triggered when a flowlogs-pipeline exporter is not writing flows. flowlogs-pipeline does not count
+// write errors: failures are detected from Kafka exporters not writing any record while flows are ingested,
+// and from exporters whose input queue never drains, such as when the receiver is unreachable or too slow.
+func alertExporterErrors(exporters []*flowslatest.FlowCollectorExporter) *monitoringv1.Rule {
+	var kafkaStages, allStages []string
+	for i, exp := range exporters {
+		switch exp.Type {
+		case flowslatest.KafkaExporter:
+			kafkaStages = append(kafkaStages, fmt.Sprintf(constants.FLPKafkaExportStage, i))
+			allStages = append(allStages, fmt.Sprintf(constants.FLPKafkaExportStage, i))
+		case flowslatest.IpfixExporter:
+			allStages = append(allStages, fmt.Sprintf(constants.FLPIPFIXExportStage, i))
+		case flowslatest.OpenTelemetryExporter:
+			allStages = append(allStages, fmt.Sprintf(constants.FLPOtelExportStage, i))
+		}
+	}
+	if len(allStages) == 0 {
+		return nil
+	}
+	d := monitoringv1.Duration("10m")
+
+	expr := fmt.Sprintf(`max by (stage) (min_over_time(netobserv_stage_in_queue_size{stage=~"%s"}[10m])) > 0`, strings.Join(allStages, "|"))
+	if len(kafkaStages) > 0 {
+		expr = fmt.Sprintf(
+			`(sum by (stage) (rate(netobserv_records_written{stage=~"%s"}[5m])) == 0 and on() sum(rate(netobserv_ingest_flows_processed[5m])) > 0) or %s`,
+			strings.Join(kafkaStages, "|"),
+			expr,
+		)
+	}
+	return &monitoringv1.Rule{
+		Alert: string(flowslatest.AlertExporterErrors),
+		Annotations: map[string]string{
+			"description": "NetObserv flowlogs-pipeline exporter {{ $labels.stage }} is not writing flows: flows are not received by the configured Kafka, IPFIX or OpenTelemetry endpoint. Please check the exporter configuration and the flowlogs-pipeline logs.",
+			"summary":     "NetObserv flowlogs-pipeline is failing to export flows",
+			"runbook_url": BuildNetObservRunbookURL(string(flowslatest.AlertExporterErrors)),
+		},
+		Expr:   intstr.FromString(expr),
+		For:    &d,
+		Labels: buildLabels(flowslatest.AlertExporterErrors, "warning", false),
+	}
+}
@@ -13,9 +13,8 @@ func promQLRateFromMetric(metric, suffix, filters, interval, offset string) prom
 	return promQLRate(fmt.Sprintf("rate(netobserv_%s%s%s[%s]%s)", metric, suffix, filters, interval, offset))
 }
 
-func sumBy(promQL promQLRate, groupBy flowslatest.HealthRuleGroupBy, side srcOrDst, extraLabel string) string {
-	var nooLabels []string
-	var labelsOut []string
+// groupByLabels returns the flow labels of the given side, and the labels they are renamed to, for a grouping
+func groupByLabels(groupBy flowslatest.HealthRuleGroupBy, side srcOrDst) (nooLabels, labelsOut []string) {
 	switch groupBy {
 	case flowslatest.GroupByNode:
 		nooLabels = []string{string(side) + "K8S_HostName"}
@@ -27,17 +26,25 @@ func sumBy(promQL promQLRate, groupBy flowslatest.HealthRuleGroupBy, side srcOrD
 		nooLabels = []string{string(side) + "K8S_Namespace", string(side) + "K8S_OwnerName", string(side) + "K8S_OwnerType"}
 		labelsOut = []string{"namespace", "workload", "kind"}
 	}
+	return nooLabels, labelsOut
+}
+
+// relabel renames src / dst labels to the same label name in order to allow adding them, e.g.:
+// label_replace(rate(netobserv_workload_ingress_bytes_total[1m]), "namespace", "$1", "SrcK8S_Namespace", "(.*)")
+func relabel(promQL string, nooLabels, labelsOut []string) string {
+	for i := range labelsOut {
+		promQL = fmt.Sprintf(`label_replace(%s, "%s", "$1", "%s", "(.*)")`, promQL, labelsOut[i], nooLabels[i])
+	}
+	return promQL
+}
+
+func sumBy(promQL promQLRate, groupBy flowslatest.HealthRuleGroupBy, side srcOrDst, extraLabel string) string {
+	nooLabels, labelsOut := groupByLabels(groupBy, side)
 	if len(labelsOut) > 0 {
 		// promQL input is like "rate(netobserv_workload_ingress_bytes_total[1m])"
-		// we need to relabel src / dst labels to the same label name in order to allow adding them
 		// e.g. of desired output:
 		// sum(label_replace(rate(netobserv_workload_ingress_bytes_total[1m]), "namespace", "$1", "SrcK8S_Namespace", "(.*)")) by (namespace)
-		replacedLabels := string(promQL)
-		for i := range labelsOut {
-			in := nooLabels[i]
-			out := labelsOut[i]
-			replacedLabels = fmt.Sprintf(`label_replace(%s, "%s", "$1", "%s", "(.*)")`, replacedLabels, out, in)
-		}
+		replacedLabels := relabel(string(promQL), nooLabels, labelsOut)
 		joinedLabels := strings.Join(labelsOut, ",")
 		if extraLabel != "" {
 			joinedLabels += "," + extraLabel
@@ -0,0 +1,61 @@
+package alerts
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+type synWithoutACK struct {
+	ctx *ruleContext
+}
+
+func newSYNWithoutACK(ctx *ruleContext) HealthRule {
+	return &synWithoutACK{ctx: ctx}
+}
+
+func (r *synWithoutACK) RecordingName() string {
+	return buildRecordingRuleName(r.ctx, "syn_without_ack", "2m")
+}
+
+func (r *synWithoutACK) GetAnnotations() (map[string]string, error) {
+	healthAnnot := newHealthAnnotation(r.ctx)
+	healthAnnot.TrafficLink = &trafficLink{
+		BackAndForth:      false,
+		ExtraFilter:       `tcp_flags="SYN"`,
+		FilterDestination: r.ctx.side == asDest,
+	}
+
+	return map[string]string{
+		"summary": "Too many unanswered TCP connection attempts",
+		"description": fmt.Sprintf(
+			"NetObserv is detecting more than %s%% of TCP connection attempts (SYN) not acknowledged (SYN-ACK)%s.",
+			r.ctx.getLowestThreshold(),
+			getAlertLegend(r.ctx),
+		),
+		"runbook_url":       buildRunbookURL(r.ctx.template),
+		healthAnnotationKey: encodeHealthAnnotation(healthAnnot),
+	}, nil
+}
+
+func (r *synWithoutACK) Build() (*monitoringv1.Rule, error) {
+	metric, _ := getMetricsForRule(r.ctx)
+	// SYN-ACK are sent in return traffic: they are counted from the opposite side
+	answers := *r.ctx
+	switch r.ctx.side {
+	case asSource:
+		answers.side = asDest
+	case asDest:
+		answers.side = asSource
+	}
+	synFilter := getPromQLFilters(r.ctx, `Flags="SYN"`)
+	synAckFilter := getPromQLFilters(&answers, `Flags="SYN_ACK"`)
+	synRate := promQLRateFromMetric(metric, "", synFilter, "2m", "")
+	synAckRate := promQLRateFromMetric(metric, "", synAckFilter, "2m", "")
+	synSumBy := sumBy(synRate, r.ctx.healthRule.GroupBy, r.ctx.side, "")
+	synAckSumBy := sumBy(synAckRate, r.ctx.healthRule.GroupBy, answers.side, "")
+	// When no SYN-ACK is seen at all, consider it as 0
+	unanswered := fmt.Sprintf("clamp_min(%s - (%s or 0 * %s), 0)", synSumBy, synAckSumBy, synSumBy)
+	promql := percentagePromQL(unanswered, synSumBy, r.ctx.alertThreshold, r.ctx.upperThreshold, r.ctx.healthRule.LowVolumeThreshold)
+	return createRule(r.ctx, r, promql)
+}
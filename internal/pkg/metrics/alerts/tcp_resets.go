@@ -0,0 +1,53 @@
+package alerts
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+type tcpResets struct {
+	ctx *ruleContext
+}
+
+func newTCPResets(ctx *ruleContext) HealthRule {
+	return &tcpResets{ctx: ctx}
+}
+
+func (r *tcpResets) RecordingName() string {
+	return buildRecordingRuleName(r.ctx, "tcp_resets", "2m")
+}
+
+func (r *tcpResets) GetAnnotations() (map[string]string, error) {
+	healthAnnot := newHealthAnnotation(r.ctx)
+	healthAnnot.TrafficLink = &trafficLink{
+		BackAndForth:      true,
+		ExtraFilter:       `tcp_flags="RST,RST_ACK"`,
+		FilterDestination: r.ctx.side == asDest,
+	}
+
+	return map[string]string{
+		"summary": "Too many TCP resets",
+		"description": fmt.Sprintf(
+			"NetObserv is detecting more than %s%% of TCP resets among the TCP flags observed in flows%s.",
+			r.ctx.getLowestThreshold(),
+			getAlertLegend(r.ctx),
+		),
+		"runbook_url":       buildRunbookURL(r.ctx.template),
+		healthAnnotationKey: encodeHealthAnnotation(healthAnnot),
+	}, nil
+}
+
+// Build compares the RST and RST_ACK flags with all the flags of the same flattened metric: as a flow having several flags
+// is counted once per flag, comparing with *_flows_total would count some flows twice.
+func (r *tcpResets) Build() (*monitoringv1.Rule, error) {
+	metric, totalMetric := getMetricsForRule(r.ctx)
+	filter := getPromQLFilters(r.ctx, `Flags=~"RST|RST_ACK"`)
+	totalFilter := getPromQLFilters(r.ctx, "")
+	metricsRate := promQLRateFromMetric(metric, "", filter, "2m", "")
+	totalRate := promQLRateFromMetric(totalMetric, "", totalFilter, "2m", "")
+	metricsSumBy := sumBy(metricsRate, r.ctx.healthRule.GroupBy, r.ctx.side, "")
+	totalSumBy := sumBy(totalRate, r.ctx.healthRule.GroupBy, r.ctx.side, "")
+	promql := percentagePromQL(metricsSumBy, totalSumBy, r.ctx.alertThreshold, r.ctx.upperThreshold, r.ctx.healthRule.LowVolumeThreshold)
+	return createRule(r.ctx, r, promql)
+}
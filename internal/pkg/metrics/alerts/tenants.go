@@ -0,0 +1,216 @@
+package alerts
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
+)
+
+// namespaceScope restricts health rules to some namespaces, or excludes some namespaces from them.
+// It only applies to the health rules grouped by namespace or workload.
+type namespaceScope struct {
+	include []string
+	exclude []string
+}
+
+// matcher returns the label matcher implementing the scope on the given namespace label, such as `SrcK8S_Namespace!~"app-a|app-b"`
+func (s *namespaceScope) matcher(label string) string {
+	if len(s.include) == 1 {
+		return fmt.Sprintf(`%s="%s"`, label, s.include[0])
+	}
+	if len(s.include) > 0 {
+		return fmt.Sprintf(`%s=~"%s"`, label, regexAlternatives(s.include))
+	}
+	if len(s.exclude) > 0 {
+		return fmt.Sprintf(`%s!~"%s"`, label, regexAlternatives(s.exclude))
+	}
+	return ""
+}
+
+// selector returns the series selector implementing the scope on the given namespace label, such as `{namespace="app-a"}`
+func (s *namespaceScope) selector(label string) string {
+	if m := s.matcher(label); m != "" {
+		return "{" + m + "}"
+	}
+	return ""
+}
+
+// scopedVariant is a health rule variant from the FlowCollector, or a variant overridden by a FlowCollectorSlice for its namespace
+type scopedVariant struct {
+	template   flowslatest.HealthRuleTemplate
+	mode       flowslatest.HealthRuleMode
+	variant    *flowslatest.HealthRuleVariant
+	namespaces namespaceScope
+	overridden bool
+}
+
+type tenantOverride struct {
+	namespace string
+	override  *sliceslatest.HealthRuleOverride
+}
+
+func isNamespaced(groupBy flowslatest.HealthRuleGroupBy) bool {
+	return groupBy == flowslatest.GroupByNamespace || groupBy == flowslatest.GroupByWorkload
+}
+
+// healthRuleVariants returns the variants of all the active health rules. The variants grouped by namespace or workload
+// that are overridden in a FlowCollectorSlice exclude the slice namespace, which gets its own variant instead, unless disabled.
+func healthRuleVariants(fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice) []scopedVariant {
+	overrides := tenantOverrides(fc, fcSlices)
+	var variants []scopedVariant
+	for _, healthRule := range fc.GetFLPHealthRules() {
+		if ok, _ := healthRule.IsAllowed(fc); !ok {
+			continue
+		}
+		for i := range healthRule.Variants {
+			variant := &healthRule.Variants[i]
+			// Get effective mode: variant.Mode if specified, otherwise healthRule.Mode
+			global := scopedVariant{template: healthRule.Template, mode: variant.GetMode(healthRule.Mode), variant: variant}
+			var perTenant []scopedVariant
+			if isNamespaced(variant.GroupBy) {
+				for _, o := range overrides[healthRule.Template] {
+					global.namespaces.exclude = append(global.namespaces.exclude, o.namespace)
+					if o.override.Disable {
+						continue
+					}
+					perTenant = append(perTenant, overriddenVariant(&global, o))
+				}
+			}
+			variants = append(variants, global)
+			variants = append(variants, perTenant...)
+		}
+	}
+	return variants
+}
+
+func overriddenVariant(global *scopedVariant, o tenantOverride) scopedVariant {
+	variant := *global.variant
+	if o.override.Thresholds != nil {
+		variant.Thresholds = flowslatest.HealthRuleThresholds{
+			Info:     o.override.Thresholds.Info,
+			Warning:  o.override.Thresholds.Warning,
+			Critical: o.override.Thresholds.Critical,
+		}
+	}
+	mode := global.mode
+	if o.override.Mode != "" {
+		mode = flowslatest.HealthRuleMode(o.override.Mode)
+	}
+	return scopedVariant{
+		template:   global.template,
+		mode:       mode,
+		variant:    &variant,
+		namespaces: namespaceScope{include: []string{o.namespace}},
+		overridden: true,
+	}
+}
+
+// tenantOverrides returns the valid health rule overrides per template. When several slices in the same namespace
+// define health rules, only the first one is used; the same applies to several overrides of the same template.
+func tenantOverrides(fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice) map[flowslatest.HealthRuleTemplate][]tenantOverride {
+	if !fc.IsSliceEnabled() {
+		return nil
+	}
+	overrides := make(map[flowslatest.HealthRuleTemplate][]tenantOverride)
+	var namespaces []string
+	for i := range fcSlices {
+		slice := &fcSlices[i]
+		if len(slice.Spec.HealthRules) == 0 || slices.Contains(namespaces, slice.Namespace) {
+			continue
+		}
+		namespaces = append(namespaces, slice.Namespace)
+		var templates []string
+		for j := range slice.Spec.HealthRules {
+			o := &slice.Spec.HealthRules[j]
+			if slices.Contains(templates, o.Template) || ValidateHealthRuleOverride(o) != nil {
+				continue
+			}
+			templates = append(templates, o.Template)
+			template := flowslatest.HealthRuleTemplate(o.Template)
+			overrides[template] = append(overrides[template], tenantOverride{namespace: slice.Namespace, override: o})
+		}
+	}
+	return overrides
+}
+
+// ValidateHealthRuleOverride checks that the thresholds of a health rule override are valid
+func ValidateHealthRuleOverride(o *sliceslatest.HealthRuleOverride) error {
+	if o.Disable || o.Thresholds == nil {
+		return nil
+	}
+	for _, threshold := range []string{o.Thresholds.Info, o.Thresholds.Warning, o.Thresholds.Critical} {
+		if threshold == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(threshold, 64); err != nil {
+			return fmt.Errorf("invalid threshold %q for template %s: it must be parsable as a float", threshold, o.Template)
+		}
+	}
+	return nil
+}
+
+// TenantHealthRules returns the health rules applied to the namespace of a FlowCollectorSlice, grouped by namespace or workload,
+// and warnings about the overrides of this slice that are ignored.
+func TenantHealthRules(fc *flowslatest.FlowCollectorSpec, fcSlices []sliceslatest.FlowCollectorSlice, slice *sliceslatest.FlowCollectorSlice) ([]sliceslatest.EffectiveHealthRule, []string) {
+	var warnings []string
+	if len(slice.Spec.HealthRules) > 0 {
+		idx := slices.IndexFunc(fcSlices, func(s sliceslatest.FlowCollectorSlice) bool {
+			return s.Namespace == slice.Namespace && len(s.Spec.HealthRules) > 0
+		})
+		if idx >= 0 && fcSlices[idx].Name != slice.Name {
+			warnings = append(warnings, fmt.Sprintf("health rules of this namespace are already overridden by FlowCollectorSlice %s, overrides are ignored", fcSlices[idx].Name))
+		}
+	}
+
+	var effective []sliceslatest.EffectiveHealthRule
+	var namespacedTemplates []string
+	for _, v := range healthRuleVariants(fc, fcSlices) {
+		if !isNamespaced(v.variant.GroupBy) {
+			continue
+		}
+		if !v.overridden {
+			namespacedTemplates = append(namespacedTemplates, string(v.template))
+		}
+		if v.overridden && !slices.Contains(v.namespaces.include, slice.Namespace) ||
+			!v.overridden && slices.Contains(v.namespaces.exclude, slice.Namespace) {
+			continue
+		}
+		mode := v.mode
+		if mode == "" {
+			mode = flowslatest.ModeAlert
+		}
+		effective = append(effective, sliceslatest.EffectiveHealthRule{
+			Template: string(v.template),
+			GroupBy:  string(v.variant.GroupBy),
+			Mode:     string(mode),
+			Thresholds: sliceslatest.HealthRuleThresholds{
+				Info:     v.variant.Thresholds.Info,
+				Warning:  v.variant.Thresholds.Warning,
+				Critical: v.variant.Thresholds.Critical,
+			},
+			Overridden: v.overridden,
+		})
+	}
+
+	if len(warnings) > 0 {
+		return effective, warnings
+	}
+	var templates []string
+	for i := range slice.Spec.HealthRules {
+		o := &slice.Spec.HealthRules[i]
+		if slices.Contains(templates, o.Template) {
+			warnings = append(warnings, fmt.Sprintf("template %s is overridden more than once, only the first override is used", o.Template))
+			continue
+		}
+		templates = append(templates, o.Template)
+		if err := ValidateHealthRuleOverride(o); err != nil {
+			warnings = append(warnings, err.Error()+", override is ignored")
+		} else if !slices.Contains(namespacedTemplates, o.Template) {
+			warnings = append(warnings, fmt.Sprintf("template %s has no active health rule grouped by Namespace or Workload in FlowCollector, override has no effect", o.Template))
+		}
+	}
+	return effective, warnings
+}
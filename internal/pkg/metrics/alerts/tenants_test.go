@@ -0,0 +1,202 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	sliceslatest "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+func tenantsFC() *flowslatest.FlowCollectorSpec {
+	fc := healthScoreFC(
+		flowslatest.FLPHealthRule{
+			Template: flowslatest.HealthRuleDNSErrors,
+			Variants: []flowslatest.HealthRuleVariant{
+				{Thresholds: flowslatest.HealthRuleThresholds{Warning: "10"}, GroupBy: flowslatest.GroupByNamespace},
+				// Not overridable per namespace
+				{Thresholds: flowslatest.HealthRuleThresholds{Critical: "20"}, GroupBy: flowslatest.GroupByNode},
+			},
+		},
+	)
+	fc.Processor.Metrics.DisableAlerts = allTemplatesBut(flowslatest.HealthRuleDNSErrors)
+	fc.Processor.SlicesConfig = &flowslatest.SlicesConfig{Enable: true}
+	return fc
+}
+
+func tenantSlices() []sliceslatest.FlowCollectorSlice {
+	return []sliceslatest.FlowCollectorSlice{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "loose", Namespace: "app-a"},
+			Spec: sliceslatest.FlowCollectorSliceSpec{
+				HealthRules: []sliceslatest.HealthRuleOverride{
+					{Template: "DNSErrors", Thresholds: &sliceslatest.HealthRuleThresholds{Warning: "40", Critical: "60"}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "quiet", Namespace: "app-b"},
+			Spec: sliceslatest.FlowCollectorSliceSpec{
+				HealthRules: []sliceslatest.HealthRuleOverride{
+					{Template: "DNSErrors", Disable: true},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "recorded", Namespace: "app-c"},
+			Spec: sliceslatest.FlowCollectorSliceSpec{
+				HealthRules: []sliceslatest.HealthRuleOverride{
+					{Template: "DNSErrors", Mode: "Recording"},
+					{Template: "DNSErrors", Disable: true},
+					{Template: "PacketDropsByKernel", Thresholds: &sliceslatest.HealthRuleThresholds{Info: "high"}},
+					{Template: "LatencyHighTrend", Disable: true},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "app-c"},
+			Spec: sliceslatest.FlowCollectorSliceSpec{
+				HealthRules: []sliceslatest.HealthRuleOverride{
+					{Template: "DNSErrors", Disable: true},
+				},
+			},
+		},
+	}
+}
+
+func findRules(rules []monitoringv1.Rule, name string) []monitoringv1.Rule {
+	var found []monitoringv1.Rule
+	for _, r := range rules {
+		if r.Alert == name || r.Record == name {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+func TestTenantOverrides(t *testing.T) {
+	rules := BuildMonitoringRules(context.Background(), tenantsFC(), tenantSlices())
+
+	// FlowCollector rule, excluding the overridden namespaces
+	warnings := findRules(rules, "DNSErrors_PerDstNamespaceWarning")
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0].Expr.String(), `DstK8S_Namespace!="",DstK8S_Namespace!~"app-a|app-b|app-c"`)
+	assert.Contains(t, warnings[0].Expr.String(), "> 10")
+
+	// Overridden thresholds for app-a
+	assert.Contains(t, warnings[1].Expr.String(), `DstK8S_Namespace!="",DstK8S_Namespace="app-a"`)
+	assert.Contains(t, warnings[1].Expr.String(), "> 40 < 60")
+	critical := findRules(rules, "DNSErrors_PerDstNamespaceCritical")
+	require.Len(t, critical, 1)
+	assert.Contains(t, critical[0].Expr.String(), `DstK8S_Namespace="app-a"`)
+	assert.Contains(t, critical[0].Expr.String(), "> 60")
+
+	// Recording for app-c, nothing for app-b
+	recordings := findRules(rules, "netobserv:health:dns_errors:namespace:dst:rate2m")
+	require.Len(t, recordings, 1)
+	assert.Contains(t, recordings[0].Expr.String(), `DstK8S_Namespace="app-c"`)
+
+	// Node rules are unchanged
+	node := findRules(rules, "DNSErrors_PerDstNodeCritical")
+	require.Len(t, node, 1)
+	assert.NotContains(t, node[0].Expr.String(), "app-a")
+
+	// The health score uses the thresholds of each namespace
+	score := findRules(rules, "netobserv:health:score:namespace")
+	require.Len(t, score, 1)
+	assert.Contains(t, score[0].Expr.String(), `ALERTS{alertstate="firing",alertname="DNSErrors_PerDstNamespaceCritical"} * 0 + 50`)
+	assert.Contains(t, score[0].Expr.String(), `(netobserv:health:dns_errors:namespace:dst:rate2m{namespace="app-c"} > 10) * 0 + 25`)
+}
+
+func TestTenantOverrides_SlicesDisabled(t *testing.T) {
+	fc := tenantsFC()
+	fc.Processor.SlicesConfig = nil
+	rules := BuildMonitoringRules(context.Background(), fc, tenantSlices())
+
+	warnings := findRules(rules, "DNSErrors_PerDstNamespaceWarning")
+	require.Len(t, warnings, 1)
+	assert.NotContains(t, warnings[0].Expr.String(), "app-a")
+	assert.Empty(t, findRules(rules, "DNSErrors_PerDstNamespaceCritical"))
+}
+
+func TestTenantHealthRules(t *testing.T) {
+	fc := tenantsFC()
+	fcSlices := tenantSlices()
+
+	effective, warnings := TenantHealthRules(fc, fcSlices, &fcSlices[0])
+	assert.Empty(t, warnings)
+	assert.Equal(t, []sliceslatest.EffectiveHealthRule{{
+		Template:   "DNSErrors",
+		GroupBy:    "Namespace",
+		Mode:       "Alert",
+		Thresholds: sliceslatest.HealthRuleThresholds{Warning: "40", Critical: "60"},
+		Overridden: true,
+	}}, effective)
+
+	effective, warnings = TenantHealthRules(fc, fcSlices, &fcSlices[1])
+	assert.Empty(t, warnings)
+	assert.Empty(t, effective)
+
+	effective, warnings = TenantHealthRules(fc, fcSlices, &fcSlices[2])
+	assert.Equal(t, []string{
+		"template DNSErrors is overridden more than once, only the first override is used",
+		`invalid threshold "high" for template PacketDropsByKernel: it must be parsable as a float, override is ignored`,
+		"template LatencyHighTrend has no active health rule grouped by Namespace or Workload in FlowCollector, override has no effect",
+	}, warnings)
+	assert.Equal(t, []sliceslatest.EffectiveHealthRule{{
+		Template:   "DNSErrors",
+		GroupBy:    "Namespace",
+		Mode:       "Recording",
+		Thresholds: sliceslatest.HealthRuleThresholds{Warning: "10"},
+		Overridden: true,
+	}}, effective)
+
+	_, warnings = TenantHealthRules(fc, fcSlices, &fcSlices[3])
+	assert.Equal(t, []string{"health rules of this namespace are already overridden by FlowCollectorSlice recorded, overrides are ignored"}, warnings)
+
+	// Namespaces without overrides inherit from the FlowCollector
+	other := sliceslatest.FlowCollectorSlice{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "app-d"}}
+	effective, warnings = TenantHealthRules(fc, fcSlices, &other)
+	assert.Empty(t, warnings)
+	assert.Equal(t, []sliceslatest.EffectiveHealthRule{{
+		Template:   "DNSErrors",
+		GroupBy:    "Namespace",
+		Mode:       "Alert",
+		Thresholds: sliceslatest.HealthRuleThresholds{Warning: "10"},
+	}}, effective)
+}
+
+func TestTenantRecordingAnnotations(t *testing.T) {
+	const recording = "netobserv:health:dns_errors:namespace:dst:rate2m"
+	decode := func(annots map[string]map[string]string) healthAnnotation {
+		require.Contains(t, annots, recording)
+		var h healthAnnotation
+		require.NoError(t, json.Unmarshal([]byte(annots[recording][healthAnnotationKey]), &h))
+		return h
+	}
+
+	// Alerts in the FlowCollector, app-c switched to recording
+	h := decode(BuildHealthRecordingAnnotations(tenantsFC(), tenantSlices()))
+	assert.Equal(t, map[string]*recordingThresholds{"app-c": {Warning: "10"}}, h.NamespaceThresholds)
+
+	// Recording in the FlowCollector, with thresholds overridden for app-a
+	fc := tenantsFC()
+	(*fc.Processor.Metrics.HealthRules)[0].Mode = flowslatest.ModeRecording
+	h = decode(BuildHealthRecordingAnnotations(fc, tenantSlices()))
+	assert.Equal(t, &recordingThresholds{Warning: "10"}, h.RecordingThresholds)
+	assert.Equal(t, map[string]*recordingThresholds{
+		"app-a": {Warning: "40", Critical: "60"},
+		"app-c": {Warning: "10"},
+	}, h.NamespaceThresholds)
+
+	// Without slices
+	h = decode(BuildHealthRecordingAnnotations(fc, nil))
+	assert.Equal(t, &recordingThresholds{Warning: "10"}, h.RecordingThresholds)
+	assert.Empty(t, h.NamespaceThresholds)
+}
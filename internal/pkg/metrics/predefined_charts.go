@@ -11,6 +11,13 @@ const (
 	mainDashboard = "Main"
 )
 
+// Dashboard template variables used by the predefined charts
+var (
+	nodeVariable      = metricslatest.ChartVariable{Name: "node", Label: "SrcK8S_HostName"}
+	namespaceVariable = metricslatest.ChartVariable{Name: "namespace", Label: "SrcK8S_Namespace"}
+	workloadVariable  = metricslatest.ChartVariable{Name: "workload", Label: "SrcK8S_OwnerName"}
+)
+
 func trafficCharts(group, vt, dir string) []metricslatest.Chart {
 	sectionName := "Traffic rates per " + strings.TrimSuffix(group, "s")
 	var unit metricslatest.Unit
@@ -243,6 +250,42 @@ func ipsecStatusChart(group string) []metricslatest.Chart {
 	return charts
 }
 
+func tcpFlagsCharts(group string) []metricslatest.Chart {
+	sectionName := "TCP connections"
+	charts := []metricslatest.Chart{
+		{
+			Type:          metricslatest.ChartTypeSingleStat,
+			SectionName:   "",
+			DashboardName: mainDashboard,
+			Title:         "TCP resets rate",
+			Queries:       []metricslatest.Query{{PromQL: `sum(rate($METRIC{Flags=~"RST|RST_ACK"}[2m]))`}},
+		},
+	}
+
+	charts = append(charts,
+		chartVariantsFor(&metricslatest.Chart{
+			Type:          metricslatest.ChartTypeStackArea,
+			SectionName:   sectionName,
+			DashboardName: mainDashboard,
+			Title:         "TCP resets rate",
+			Queries: []metricslatest.Query{{
+				PromQL: `sum(rate($METRIC{Flags=~"RST|RST_ACK",$FILTERS}[2m])) by ($LABELS)`,
+				Legend: "$LEGEND",
+			}},
+		}, group, "")...)
+	return append(charts,
+		chartVariantsFor(&metricslatest.Chart{
+			Type:          metricslatest.ChartTypeStackArea,
+			SectionName:   sectionName,
+			DashboardName: mainDashboard,
+			Title:         "Connection attempts rate",
+			Queries: []metricslatest.Query{{
+				PromQL: `sum(rate($METRIC{Flags="SYN",$FILTERS}[2m])) by ($LABELS)`,
+				Legend: "$LEGEND",
+			}},
+		}, group, "")...)
+}
+
 func chartVariantsFor(chart *metricslatest.Chart, group, unit string) []metricslatest.Chart {
 	var additionalCharts []metricslatest.Chart
 	if group == tagWorkloads {
@@ -281,24 +324,32 @@ func chartVariantFor(c *metricslatest.Chart, group, layer, unit string) metricsl
 		chart.Title += "node"
 		labels = "SrcK8S_HostName,DstK8S_HostName"
 		legend = "source:{{SrcK8S_HostName}}, dest:{{DstK8S_HostName}}"
+		orFilters = []string{
+			`SrcK8S_HostName=~"$node"`,
+			`DstK8S_HostName=~"$node"`,
+		}
+		chart.Variables = []metricslatest.ChartVariable{nodeVariable}
 	case tagNamespaces:
 		chart.Title += "namespace"
 		labels = "SrcK8S_Namespace,DstK8S_Namespace"
 		legend = "source:{{SrcK8S_Namespace}}, dest:{{DstK8S_Namespace}}"
-		// orFilters aim to eliminate node-to-node traffic when looking at namespace-based metrics
+		// orFilters aim to eliminate node-to-node traffic when looking at namespace-based metrics:
+		// when all namespaces are selected, the variable matches any non-empty namespace
 		orFilters = []string{
-			flowLayerFilter + `SrcK8S_Namespace!=""`,
-			flowLayerFilter + `DstK8S_Namespace!=""`,
+			flowLayerFilter + `SrcK8S_Namespace=~"$namespace"`,
+			flowLayerFilter + `DstK8S_Namespace=~"$namespace"`,
 		}
+		chart.Variables = []metricslatest.ChartVariable{namespaceVariable}
 	case tagWorkloads:
 		chart.Title += "workload"
 		labels = "SrcK8S_Namespace,SrcK8S_OwnerName,DstK8S_Namespace,DstK8S_OwnerName"
 		legend = "source:{{SrcK8S_OwnerName}}/{{SrcK8S_Namespace}}, dest:{{DstK8S_OwnerName}}/{{DstK8S_Namespace}}"
 		// orFilters aim to eliminate node-to-node traffic when looking at workload-based metrics
 		orFilters = []string{
-			flowLayerFilter + `SrcK8S_Namespace!=""`,
-			flowLayerFilter + `DstK8S_Namespace!=""`,
+			flowLayerFilter + `SrcK8S_Namespace=~"$namespace",SrcK8S_OwnerName=~"$workload"`,
+			flowLayerFilter + `DstK8S_Namespace=~"$namespace",DstK8S_OwnerName=~"$workload"`,
 		}
+		chart.Variables = []metricslatest.ChartVariable{namespaceVariable, workloadVariable}
 	}
 	if unit != "" {
 		chart.Title += " (" + unit + ")"
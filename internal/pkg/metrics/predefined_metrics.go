@@ -213,6 +213,28 @@ func init() {
 			tags: []string{group, "ipsec"},
 		})
 	}
+	for _, group := range []string{tagNodes, tagNamespaces, tagWorkloads} {
+		groupTrimmed := strings.TrimSuffix(group, "s")
+		labels := mapLabels[group]
+		// TCP flags
+		flagsLabels := labels
+		flagsLabels = append(flagsLabels, "Flags")
+		predefinedMetrics = append(predefinedMetrics, taggedMetricDefinition{
+			FlowMetricSpec: metricslatest.FlowMetricSpec{
+				MetricName: fmt.Sprintf("%s_tcp_flags_total", groupTrimmed),
+				Type:       metricslatest.CounterMetric,
+				Help:       fmt.Sprintf("Total TCP flows per flag and per %s", groupTrimmed),
+				Filters: []metricslatest.MetricFilter{
+					{Field: "Proto", Value: "6"},
+					{Field: "Flags", MatchType: metricslatest.MatchPresence},
+				},
+				Labels:  flagsLabels,
+				Flatten: []string{"Flags"},
+				Charts:  tcpFlagsCharts(group),
+			},
+			tags: []string{group, "tcp-flags"},
+		})
+	}
 	// Cross-nodes metric
 	predefinedMetrics = append(predefinedMetrics, taggedMetricDefinition{
 		FlowMetricSpec: metricslatest.FlowMetricSpec{
@@ -0,0 +1,222 @@
+package strimzi
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	"github.com/netobserv/network-observability-operator/internal/controller/constants"
+	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
+)
+
+const (
+	clusterLabel    = "strimzi.io/cluster"
+	userIDKey       = "username"
+	userPasswordKey = "password"
+)
+
+var (
+	TopicGVK = schema.GroupVersionKind{Group: "kafka.strimzi.io", Version: "v1beta2", Kind: "KafkaTopic"}
+	UserGVK  = schema.GroupVersionKind{Group: "kafka.strimzi.io", Version: "v1beta2", Kind: "KafkaUser"}
+)
+
+// ApplyProvisioning configures the Kafka client TLS or SASL settings with the credentials that Strimzi generates
+// for the provisioned KafkaUser. It does nothing when provisioning isn't enabled.
+func ApplyProvisioning(spec *flowslatest.FlowCollectorSpec) {
+	if !spec.UseKafkaProvisioning() {
+		return
+	}
+	prov := spec.Kafka.Provisioning
+	if prov.Authentication == flowslatest.KafkaProvisioningAuthScramSHA512 {
+		spec.Kafka.SASL = flowslatest.SASLConfig{
+			Type: flowslatest.SASLScramSHA512,
+			ClientIDReference: flowslatest.FileReference{
+				Type:      flowslatest.RefTypeConfigMap,
+				Name:      constants.StrimziUserIDConfigMap,
+				Namespace: prov.Namespace,
+				File:      userIDKey,
+			},
+			ClientSecretReference: flowslatest.FileReference{
+				Type:      flowslatest.RefTypeSecret,
+				Name:      constants.StrimziUserName,
+				Namespace: prov.Namespace,
+				File:      userPasswordKey,
+			},
+		}
+		return
+	}
+	spec.Kafka.TLS = flowslatest.ClientTLS{
+		Enable: true,
+		CACert: flowslatest.CertificateReference{
+			Type:      flowslatest.RefTypeSecret,
+			Name:      prov.Cluster + "-cluster-ca-cert",
+			Namespace: prov.Namespace,
+			CertFile:  "ca.crt",
+		},
+		UserCert: flowslatest.CertificateReference{
+			Type:      flowslatest.RefTypeSecret,
+			Name:      constants.StrimziUserName,
+			Namespace: prov.Namespace,
+			CertFile:  "user.crt",
+			CertKey:   "user.key",
+		},
+	}
+}
+
+// Partitions returns the number of partitions of the provisioned topic, aligned with the number of consumers by default
+func Partitions(spec *flowslatest.FlowCollectorSpec) int32 {
+	if spec.Kafka.Provisioning != nil && spec.Kafka.Provisioning.Partitions != nil {
+		return *spec.Kafka.Provisioning.Partitions
+	}
+	if spec.Processor.KafkaConsumerAutoscaler.IsHPAEnabled() && spec.Processor.KafkaConsumerAutoscaler.MaxReplicas > 0 {
+		return spec.Processor.KafkaConsumerAutoscaler.MaxReplicas
+	}
+	return spec.Processor.GetFLPReplicas()
+}
+
+// Reconcile creates or updates the KafkaTopic and KafkaUser when provisioning is enabled. When it is disabled or removed,
+// or when its namespace changes, the previously provisioned resources are deleted.
+// It must only be called when the Strimzi API is available.
+func Reconcile(ctx context.Context, cl *helper.Client, spec *flowslatest.FlowCollectorSpec) error {
+	if !spec.UseKafkaProvisioning() {
+		return deleteProvisioned(ctx, cl, "")
+	}
+	prov := spec.Kafka.Provisioning
+	if err := cl.ReconcileUnstructured(ctx, newTopic(spec)); err != nil {
+		return err
+	}
+	if err := cl.ReconcileUnstructured(ctx, newUser(spec)); err != nil {
+		return err
+	}
+	if prov.Authentication == flowslatest.KafkaProvisioningAuthScramSHA512 {
+		if err := reconcileUserIDConfigMap(ctx, cl, prov.Namespace); err != nil {
+			return err
+		}
+	} else if err := deleteUserIDConfigMap(ctx, cl, prov.Namespace); err != nil {
+		return err
+	}
+	return deleteProvisioned(ctx, cl, prov.Namespace)
+}
+
+// deleteProvisioned deletes the KafkaTopic, KafkaUser and user ID ConfigMap managed by the operator in every namespace
+// but the kept one. The provisioning namespace isn't known anymore when spec.kafka.provisioning is removed, hence the lookup.
+func deleteProvisioned(ctx context.Context, cl *helper.Client, keepNamespace string) error {
+	for _, obj := range []struct {
+		gvk  schema.GroupVersionKind
+		name string
+	}{
+		{gvk: UserGVK, name: constants.StrimziUserName},
+		{gvk: TopicGVK, name: constants.StrimziTopicName},
+		{gvk: corev1.SchemeGroupVersion.WithKind("ConfigMap"), name: constants.StrimziUserIDConfigMap},
+	} {
+		list := unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(obj.gvk.GroupVersion().WithKind(obj.gvk.Kind + "List"))
+		if err := cl.List(ctx, &list, helper.ManagedSelector()); err != nil {
+			return err
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			if item.GetName() != obj.name || item.GetNamespace() == keepNamespace {
+				continue
+			}
+			if err := cl.DeleteIfOwned(ctx, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newTopic(spec *flowslatest.FlowCollectorSpec) *unstructured.Unstructured {
+	prov := spec.Kafka.Provisioning
+	topicSpec := map[string]interface{}{
+		"topicName":  spec.Kafka.Topic,
+		"partitions": int64(Partitions(spec)),
+	}
+	if prov.Replicas != nil {
+		topicSpec["replicas"] = int64(*prov.Replicas)
+	}
+	if prov.Retention != nil {
+		topicSpec["config"] = map[string]interface{}{
+			"retention.ms": prov.Retention.Milliseconds(),
+		}
+	}
+	obj := helper.NewUnstructured(TopicGVK, constants.StrimziTopicName, prov.Namespace, topicSpec)
+	obj.SetLabels(map[string]string{clusterLabel: prov.Cluster})
+	return obj
+}
+
+func newUser(spec *flowslatest.FlowCollectorSpec) *unstructured.Unstructured {
+	prov := spec.Kafka.Provisioning
+	authType := "tls"
+	if prov.Authentication == flowslatest.KafkaProvisioningAuthScramSHA512 {
+		authType = "scram-sha-512"
+	}
+	obj := helper.NewUnstructured(UserGVK, constants.StrimziUserName, prov.Namespace, map[string]interface{}{
+		"authentication": map[string]interface{}{"type": authType},
+		"authorization": map[string]interface{}{
+			"type": "simple",
+			"acls": []interface{}{
+				acl("topic", spec.Kafka.Topic, "Describe", "Read", "Write"),
+				acl("group", constants.FLPName, "Read"),
+			},
+		},
+	})
+	obj.SetLabels(map[string]string{clusterLabel: prov.Cluster})
+	return obj
+}
+
+func acl(resourceType, name string, operations ...string) map[string]interface{} {
+	ops := make([]interface{}, 0, len(operations))
+	for _, op := range operations {
+		ops = append(ops, op)
+	}
+	return map[string]interface{}{
+		"resource": map[string]interface{}{
+			"type":        resourceType,
+			"name":        name,
+			"patternType": "literal",
+		},
+		"operations": ops,
+	}
+}
+
+// reconcileUserIDConfigMap provides the SASL username, which Strimzi doesn't include in the generated Secret
+func reconcileUserIDConfigMap(ctx context.Context, cl *helper.Client, namespace string) error {
+	desired := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.StrimziUserIDConfigMap,
+			Namespace: namespace,
+		},
+		Data: map[string]string{userIDKey: constants.StrimziUserName},
+	}
+	current := corev1.ConfigMap{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: namespace}, &current); err != nil {
+		if kerr.IsNotFound(err) {
+			return cl.CreateOwned(ctx, &desired)
+		}
+		return err
+	}
+	if equality.Semantic.DeepDerivative(desired.Data, current.Data) {
+		return nil
+	}
+	return cl.UpdateIfOwned(ctx, &current, &desired)
+}
+
+func deleteUserIDConfigMap(ctx context.Context, cl *helper.Client, namespace string) error {
+	current := corev1.ConfigMap{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: constants.StrimziUserIDConfigMap, Namespace: namespace}, &current); err != nil {
+		if kerr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return cl.DeleteIfOwned(ctx, &current)
+}
@@ -0,0 +1,106 @@
+package strimzi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+)
+
+func kafkaSpec(auth flowslatest.KafkaProvisioningAuthentication) *flowslatest.FlowCollectorSpec {
+	return &flowslatest.FlowCollectorSpec{
+		DeploymentModel: flowslatest.DeploymentModelKafka,
+		Kafka: flowslatest.FlowCollectorKafka{
+			Address: "my-cluster-kafka-bootstrap.kafka:9093",
+			Topic:   "network-flows",
+			Provisioning: &flowslatest.KafkaProvisioning{
+				Enable:         true,
+				Cluster:        "my-cluster",
+				Namespace:      "kafka",
+				Authentication: auth,
+			},
+		},
+	}
+}
+
+func TestApplyProvisioningTLS(t *testing.T) {
+	spec := kafkaSpec(flowslatest.KafkaProvisioningAuthTLS)
+	ApplyProvisioning(spec)
+
+	assert.True(t, spec.Kafka.TLS.Enable)
+	assert.Equal(t, flowslatest.CertificateReference{
+		Type:      flowslatest.RefTypeSecret,
+		Name:      "my-cluster-cluster-ca-cert",
+		Namespace: "kafka",
+		CertFile:  "ca.crt",
+	}, spec.Kafka.TLS.CACert)
+	assert.Equal(t, "netobserv", spec.Kafka.TLS.UserCert.Name)
+	assert.Equal(t, "user.key", spec.Kafka.TLS.UserCert.CertKey)
+	assert.False(t, spec.Kafka.SASL.UseSASL())
+}
+
+func TestApplyProvisioningScram(t *testing.T) {
+	spec := kafkaSpec(flowslatest.KafkaProvisioningAuthScramSHA512)
+	ApplyProvisioning(spec)
+
+	assert.False(t, spec.Kafka.TLS.Enable)
+	assert.Equal(t, flowslatest.SASLScramSHA512, spec.Kafka.SASL.Type)
+	assert.Equal(t, "netobserv-kafka-user", spec.Kafka.SASL.ClientIDReference.Name)
+	assert.Equal(t, flowslatest.RefTypeConfigMap, spec.Kafka.SASL.ClientIDReference.Type)
+	assert.Equal(t, "password", spec.Kafka.SASL.ClientSecretReference.File)
+}
+
+func TestApplyProvisioningDisabled(t *testing.T) {
+	spec := kafkaSpec(flowslatest.KafkaProvisioningAuthTLS)
+	spec.DeploymentModel = flowslatest.DeploymentModelService
+	ApplyProvisioning(spec)
+	assert.False(t, spec.Kafka.TLS.Enable)
+
+	spec = kafkaSpec(flowslatest.KafkaProvisioningAuthTLS)
+	spec.Kafka.Provisioning.Enable = false
+	ApplyProvisioning(spec)
+	assert.False(t, spec.Kafka.TLS.Enable)
+}
+
+func TestPartitions(t *testing.T) {
+	spec := kafkaSpec(flowslatest.KafkaProvisioningAuthTLS)
+	assert.Equal(t, int32(3), Partitions(spec))
+
+	spec.Processor.ConsumerReplicas = ptr.To(int32(6))
+	assert.Equal(t, int32(6), Partitions(spec))
+
+	spec.Processor.KafkaConsumerAutoscaler = flowslatest.FlowCollectorHPA{Status: flowslatest.HPAStatusEnabled, MaxReplicas: 12}
+	assert.Equal(t, int32(12), Partitions(spec))
+
+	spec.Kafka.Provisioning.Partitions = ptr.To(int32(24))
+	assert.Equal(t, int32(24), Partitions(spec))
+}
+
+func TestNewTopicAndUser(t *testing.T) {
+	spec := kafkaSpec(flowslatest.KafkaProvisioningAuthScramSHA512)
+	spec.Kafka.Provisioning.Replicas = ptr.To(int32(3))
+	spec.Kafka.Provisioning.Retention = &metav1.Duration{Duration: 2 * time.Hour}
+
+	topic := newTopic(spec)
+	assert.Equal(t, "KafkaTopic", topic.GetKind())
+	assert.Equal(t, "kafka", topic.GetNamespace())
+	assert.Equal(t, "my-cluster", topic.GetLabels()["strimzi.io/cluster"])
+	topicName, _, _ := unstructured.NestedString(topic.Object, "spec", "topicName")
+	assert.Equal(t, "network-flows", topicName)
+	partitions, _, _ := unstructured.NestedInt64(topic.Object, "spec", "partitions")
+	assert.Equal(t, int64(3), partitions)
+	retention, _, _ := unstructured.NestedInt64(topic.Object, "spec", "config", "retention.ms")
+	assert.Equal(t, int64(7200000), retention)
+
+	user := newUser(spec)
+	assert.Equal(t, "KafkaUser", user.GetKind())
+	authType, _, _ := unstructured.NestedString(user.Object, "spec", "authentication", "type")
+	assert.Equal(t, "scram-sha-512", authType)
+	acls, _, _ := unstructured.NestedSlice(user.Object, "spec", "authorization", "acls")
+	assert.Len(t, acls, 2)
+}
@@ -40,6 +40,7 @@ import (
 	_ "github.com/openshift/api/security/v1/zz_generated.crd-manifests"
 
 	flowsv1beta2 "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
+	diagnosticv1alpha1 "github.com/netobserv/network-observability-operator/api/flowcollectordiagnostic/v1alpha1"
 	slicesv1alpha1 "github.com/netobserv/network-observability-operator/api/flowcollectorslice/v1alpha1"
 	metricsv1alpha1 "github.com/netobserv/network-observability-operator/api/flowmetrics/v1alpha1"
 	"github.com/netobserv/network-observability-operator/internal/pkg/helper"
@@ -103,6 +104,9 @@ func PrepareEnvTest(controllers []manager.Registerer, namespaces []string, baseP
 	err = slicesv1alpha1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = diagnosticv1alpha1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = corev1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
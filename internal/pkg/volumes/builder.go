@@ -5,6 +5,7 @@ import (
 	"path"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	flowslatest "github.com/netobserv/network-observability-operator/api/flowcollector/v1beta2"
 	"github.com/netobserv/network-observability-operator/internal/controller/constants"
@@ -52,6 +53,67 @@ func (b *Builder) AddVolume(config *flowslatest.FileReference, volumeName string
 	return path.Join("var", volumeName, config.File)
 }
 
+// addFile is like AddVolume, but returns an absolute path, for configurations that are not read from the container working directory
+func (b *Builder) addFile(config *flowslatest.FileReference, volumeName string) string {
+	return "/" + b.AddVolume(config, volumeName)
+}
+
+// HTTPClientAuthPaths holds the absolute paths of the mounted HTTP client credentials
+type HTTPClientAuthPaths struct {
+	UsernamePath string
+	PasswordPath string
+	TokenPath    string
+	// HeaderPaths maps header names to the path of the file containing their value
+	HeaderPaths map[string]string
+}
+
+// AddHTTPClientAuth will add volumes + volume mounts for the HTTP client credentials in use
+func (b *Builder) AddHTTPClientAuth(auth *flowslatest.HTTPClientAuth, namePrefix string) HTTPClientAuthPaths {
+	var paths HTTPClientAuthPaths
+	switch auth.Type {
+	case flowslatest.HTTPClientAuthBasic:
+		if auth.Username != nil {
+			paths.UsernamePath = b.addFile(auth.Username, namePrefix+"-username")
+		}
+		if auth.Password != nil {
+			paths.PasswordPath = b.addFile(auth.Password, namePrefix+"-password")
+		}
+	case flowslatest.HTTPClientAuthBearer:
+		if auth.Token != nil {
+			paths.TokenPath = b.addFile(auth.Token, namePrefix+"-token")
+		}
+	case flowslatest.HTTPClientAuthNone:
+	}
+	for i := range auth.Headers {
+		if paths.HeaderPaths == nil {
+			paths.HeaderPaths = map[string]string{}
+		}
+		paths.HeaderPaths[auth.Headers[i].Name] = b.addFile(&auth.Headers[i].ValueFrom, fmt.Sprintf("%s-header-%d", namePrefix, i))
+	}
+	return paths
+}
+
+// AddCSI will add an inline CSI volume + volume mount, and returns the mount path
+func (b *Builder) AddCSI(driver string, attributes map[string]string, volumeName string) string {
+	vol := corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           driver,
+				ReadOnly:         ptr.To(true),
+				VolumeAttributes: attributes,
+			},
+		},
+	}
+	vm := corev1.VolumeMount{
+		Name:      volumeName,
+		ReadOnly:  true,
+		MountPath: "/var/" + volumeName,
+	}
+	b.insertOrReplace(&VolumeInfo{Volume: vol, Mount: vm})
+	return vm.MountPath
+}
+
 // AddToken will add a volume + volume mount for a service account token if defined
 func (b *Builder) AddToken(name string) string {
 	for i := range b.info {
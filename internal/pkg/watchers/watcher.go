@@ -169,6 +169,23 @@ func (w *Watcher) ProcessSASL(ctx context.Context, cl helper.Client, sasl *flows
 	return idDigest, secretDigest, nil
 }
 
+// ProcessHTTPClientAuth watches the references used for HTTP client credentials, and returns a digest covering all of them
+func (w *Watcher) ProcessHTTPClientAuth(ctx context.Context, cl helper.Client, auth *flowslatest.HTTPClientAuth, targetNamespace string) (string, error) {
+	refs := auth.References()
+	if len(refs) == 0 {
+		return "", nil
+	}
+	var digests []string
+	for i := range refs {
+		digest, err := w.reconcile(ctx, cl, w.refFromFile(&refs[i]), targetNamespace)
+		if err != nil {
+			return "", err
+		}
+		digests = append(digests, digest)
+	}
+	return getDigest(digests, func(d string) interface{} { return d })
+}
+
 func (w *Watcher) reconcile(ctx context.Context, cl helper.Client, ref objectRef, destNamespace string) (string, error) {
 	rlog := log.FromContext(ctx, "Name", ref.name, "Source namespace", ref.namespace, "Target namespace", destNamespace)
 	ctx = log.IntoContext(ctx, rlog)